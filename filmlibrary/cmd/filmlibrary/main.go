@@ -4,8 +4,11 @@ import (
 	"context"
 	"filmlibrary/internal/config"
 	handleR "filmlibrary/internal/handler"
+	"filmlibrary/internal/lib/logger/sl"
 	servicE "filmlibrary/internal/service"
+	"filmlibrary/internal/storage/blob"
 	"filmlibrary/internal/storage/postgresql"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -13,6 +16,9 @@ import (
 	"syscall"
 )
 
+//go:generate swag init --dir ../.. -g cmd/filmlibrary/main.go --instanceName v1 --tags !v2 --output ../../docs/v1
+//go:generate swag init --dir ../.. -g cmd/filmlibrary/swagger_v2.go --instanceName v2 --tags !v1 --output ../../docs/v2
+
 const (
 	envLocal = "local"
 	envDev   = "dev"
@@ -29,6 +35,7 @@ const (
 // @contact.email zhiborkin_ei@mail.ru
 
 // @host localhost:8080
+// @BasePath /api/v1
 
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
@@ -49,13 +56,28 @@ func main() {
 
 	repo, err := postgresql.New(cfg.DataSourceName)
 	if err != nil {
-		log.Error("failed to initialize storage", err)
+		log.Error("failed to initialize storage", sl.Err(err))
+		os.Exit(1)
+	}
+
+	blobStore, err := setupBlobStore(cfg.Images)
+	if err != nil {
+		log.Error("failed to initialize image storage", sl.Err(err))
 		os.Exit(1)
 	}
 
-	service := servicE.New(log, repo, repo, repo)
+	service := servicE.New(log, repo, blobStore)
+	service.SetScorer(servicE.WeightedScorer(servicE.ScoreWeights{
+		SharedActor:      cfg.Recommendations.SharedActorWeight,
+		SharedGenre:      cfg.Recommendations.SharedGenreWeight,
+		ReleaseProximity: cfg.Recommendations.ReleaseProximityWeight,
+		Rating:           cfg.Recommendations.RatingWeight,
+	}))
+	service.SetMaxImageSize(cfg.Images.MaxSize)
+	service.SetMaxImagePixels(cfg.Images.MaxPixels)
+	service.SetMaxBatchSize(cfg.Batch.MaxSize)
 
-	handler := handleR.New(log, service, service, service, service)
+	handler := handleR.New(log, service)
 
 	router := handler.InitRoutes()
 
@@ -86,7 +108,7 @@ func main() {
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Error("failed to stop server", err)
+		log.Error("failed to stop server", sl.Err(err))
 
 		return
 	}
@@ -119,3 +141,22 @@ func setupLogger(env string) *slog.Logger {
 
 	return log
 }
+
+func setupBlobStore(cfg config.Images) (blob.BlobStore, error) {
+	switch cfg.Store {
+	case "local":
+		return blob.NewLocalStore(cfg.LocalDir)
+	case "s3":
+		return blob.NewS3Store(blob.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			UseSSL:    cfg.S3.UseSSL,
+			Timeout:   cfg.S3.Timeout,
+		})
+	default:
+		return nil, fmt.Errorf("unknown image store %q", cfg.Store)
+	}
+}
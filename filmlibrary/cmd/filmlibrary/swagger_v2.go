@@ -0,0 +1,20 @@
+package main
+
+// General API info of the v2 docs. The operations are shared with v1 except the ones
+// tagged with the version that replaces them.
+
+// @title Film Library API
+// @version 2.0
+// @description This is a test assignment for VK Internship.
+// @termsOfService http://swagger.io/terms/
+
+// @contact.name Evgenii Zhiborkin
+// @contact.url https://t.me/zyltrcuj
+// @contact.email zhiborkin_ei@mail.ru
+
+// @host localhost:8080
+// @BasePath /api/v2
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization Bearer ""
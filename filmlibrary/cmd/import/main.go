@@ -0,0 +1,74 @@
+// Command import imports a catalog dump into the film library like the catalog import
+// endpoint and writes the report to stdout as JSON. The format is taken from the file
+// extension unless given:
+//
+//	CONFIG_PATH=config/local.yaml go run ./cmd/import -dry-run catalog.csv
+package main
+
+import (
+	"encoding/json"
+	"filmlibrary/internal/config"
+	"filmlibrary/internal/lib/logger/sl"
+	servicE "filmlibrary/internal/service"
+	"filmlibrary/internal/storage/postgresql"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the changes without making them")
+	format := flag.String("format", "", "csv or json, taken from the file extension when left out")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: import [-dry-run] [-format csv|json] file")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	cfg := config.MustLoad()
+
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	repo, err := postgresql.New(cfg.DataSourceName)
+	if err != nil {
+		log.Error("failed to initialize storage", sl.Err(err))
+		os.Exit(1)
+	}
+	defer repo.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Error("failed to open the catalog dump", sl.Err(err))
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	service := servicE.New(log, repo, nil)
+
+	report, err := service.ImportCatalog(file, *format, *dryRun)
+	if err != nil {
+		log.Error("failed to import the catalog", sl.Err(err))
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Error("failed to write the report", sl.Err(err))
+		os.Exit(1)
+	}
+
+	log.Info("catalog imported", slog.Int("rows", report.Rows), slog.Int("failed", report.Failed), slog.Bool("dry_run", *dryRun))
+}
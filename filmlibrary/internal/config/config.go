@@ -9,9 +9,12 @@ import (
 )
 
 type Config struct {
-	Env            string `yaml:"env" env-default:"local"`
-	DataSourceName string `yaml:"data_source_name" env-default:"postgres://postgres:postgres@db:5432/postgres?sslmode=disable"`
-	HTTPServer     `yaml:"http_server"`
+	Env             string `yaml:"env" env-default:"local"`
+	DataSourceName  string `yaml:"data_source_name" env-default:"postgres://postgres:postgres@db:5432/postgres?sslmode=disable"`
+	HTTPServer      `yaml:"http_server"`
+	Recommendations `yaml:"recommendations"`
+	Images          `yaml:"images"`
+	Batch           `yaml:"batch"`
 }
 
 type HTTPServer struct {
@@ -20,6 +23,39 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `yaml:"idle-timeout"`
 }
 
+// Recommendations holds the weights of the movie similarity score.
+type Recommendations struct {
+	SharedActorWeight      float64 `yaml:"shared_actor_weight" env-default:"3"`
+	SharedGenreWeight      float64 `yaml:"shared_genre_weight" env-default:"2"`
+	ReleaseProximityWeight float64 `yaml:"release_proximity_weight" env-default:"1"`
+	RatingWeight           float64 `yaml:"rating_weight" env-default:"1"`
+}
+
+// Images configures image uploads and where image files are kept. Store is
+// either "local", for a directory on disk, or "s3", for an S3-compatible bucket.
+type Images struct {
+	MaxSize   int64  `yaml:"max_size" env-default:"5242880"`
+	MaxPixels int64  `yaml:"max_pixels" env-default:"40000000"`
+	Store     string `yaml:"store" env-default:"local"`
+	LocalDir  string `yaml:"local_dir" env-default:"images"`
+	S3        `yaml:"s3"`
+}
+
+// Batch limits the bulk create, update and delete endpoints.
+type Batch struct {
+	MaxSize int `yaml:"max_size" env-default:"500"`
+}
+
+type S3 struct {
+	Endpoint  string        `yaml:"endpoint"`
+	Region    string        `yaml:"region"`
+	Bucket    string        `yaml:"bucket"`
+	AccessKey string        `yaml:"access_key" env:"S3_ACCESS_KEY"`
+	SecretKey string        `yaml:"secret_key" env:"S3_SECRET_KEY"`
+	UseSSL    bool          `yaml:"use_ssl"`
+	Timeout   time.Duration `yaml:"timeout" env-default:"30s"`
+}
+
 func MustLoad() *Config {
 	//env
 	configPath := os.Getenv("CONFIG_PATH")
@@ -2,45 +2,43 @@ package models
 
 import "time"
 
+// Actor is the profile of an actor as it is added and read, it is edited with an
+// ActorPatch.
 type Actor struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name,omitempty"`
-	Sex       string    `json:"sex,omitempty"`
-	Birthday  time.Time `json:"birthday,omitempty"`
+	ID          int64        `json:"id"`
+	Name        string       `json:"name,omitempty"`
+	Sex         string       `json:"sex,omitempty"`
+	Birthday    time.Time    `json:"birthday,omitempty"`
+	DeathDate   *time.Time   `json:"death_date,omitempty"`
+	Birthplace  string       `json:"birthplace,omitempty"`
+	Biography   string       `json:"biography,omitempty"`
+	Aliases     []string     `json:"aliases,omitempty"`
+	ExternalIDs *ExternalIDs `json:"external_ids,omitempty"`
+	// Age is computed from the birthday, up to the date of death.
+	Age       *int      `json:"age,omitempty"`
 	MoviesID  []int     `json:"movies_id,omitempty"`
 	DeletedAt time.Time `json:"deleted_at,omitempty"`
+	// Version grows with every edit, it is sent as the ETag of the actor.
+	Version int64 `json:"version,omitempty"`
 }
 
-type ActorListing struct {
-	ID       int64     `json:"id"`
-	Name     string    `json:"name,omitempty"`
-	Sex      string    `json:"sex,omitempty"`
-	Birthday time.Time `json:"birthday,omitempty"`
-	Movies   []string  `json:"movies,omitempty"`
-}
-
-type getActor struct {
-	ID       int64     `json:"id" binding:"required" example:"1"`
-	Name     string    `json:"name,omitempty"  example:"Vladimir Putin"`
-	Sex      string    `json:"sex,omitempty"  example:"male"`
-	Birthday time.Time `json:"birthday,omitempty"  example:"1952-10-07" format:"date"`
-	Movies   []string  `json:"movies,omitempty" example:"['The Shawshank Redemption', 'The Godfather']"`
+// ExternalIDs identify an actor or a movie in other movie databases.
+type ExternalIDs struct {
+	IMDb      string `json:"imdb,omitempty" validate:"max=12"`
+	Kinopoisk string `json:"kinopoisk,omitempty" validate:"max=12"`
 }
 
-type editActor struct {
-	ID       int64     `json:"id" binding:"required" example:"1"`
-	Name     string    `json:"name,omitempty"  example:"Vladimir Putin"`
-	Sex      string    `json:"sex,omitempty"  example:"male"`
-	Birthday time.Time `json:"birthday,omitempty"  example:"1952-10-07" format:"date"`
-}
-
-type addActor struct {
-	Name     string    `json:"name,omitempty" binding:"required" example:"Vladimir Putin"`
-	Sex      string    `json:"sex,omitempty"  binding:"required" example:"male"`
-	Birthday time.Time `json:"birthday,omitempty" binding:"required" example:"1952-10-07" format:"date"`
+type ActorListing struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name,omitempty"`
+	Sex       string     `json:"sex,omitempty"`
+	Birthday  time.Time  `json:"birthday,omitempty"`
+	DeathDate *time.Time `json:"death_date,omitempty"`
+	Age       *int       `json:"age,omitempty"`
+	Movies    []string   `json:"movies,omitempty"`
 }
 
 type ActorsTo struct {
-	MovieID int64   `json:"id" binding:"required" example:"2"`
-	Actors  []int64 `json:"actors_id,omitempty" binding:"required" example:"123"`
+	MovieID int64   `json:"id" validate:"required" example:"2"`
+	Actors  []int64 `json:"actors_id,omitempty" validate:"required" example:"123"`
 }
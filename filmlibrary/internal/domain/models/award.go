@@ -0,0 +1,70 @@
+package models
+
+const (
+	NominationsOfMovie    = "movie"
+	NominationsOfPerson   = "person"
+	NominationsOfCeremony = "ceremony"
+)
+
+type Award struct {
+	ID         int64            `json:"id"`
+	Name       string           `json:"name" validate:"required,max=100"`
+	Ceremonies []*Ceremony      `json:"ceremonies,omitempty"`
+	Categories []*AwardCategory `json:"categories,omitempty"`
+}
+
+// Ceremony is the yearly event an award is given at.
+type Ceremony struct {
+	ID      int64 `json:"id"`
+	AwardID int64 `json:"award_id" validate:"required"`
+	Year    int   `json:"year" validate:"required"`
+}
+
+type AwardCategory struct {
+	ID      int64  `json:"id"`
+	AwardID int64  `json:"award_id" validate:"required"`
+	Name    string `json:"name" validate:"required,max=100"`
+}
+
+// Nomination nominates a movie in a category of a ceremony, and a person for
+// their work on it when the category is given to people.
+type Nomination struct {
+	ID         int64  `json:"id"`
+	CeremonyID int64  `json:"ceremony_id" validate:"required"`
+	CategoryID int64  `json:"category_id" validate:"required"`
+	MovieID    int64  `json:"movie_id" validate:"required"`
+	PersonID   *int64 `json:"person_id,omitempty"`
+	Won        bool   `json:"won"`
+}
+
+type NominationListing struct {
+	ID       int64     `json:"id"`
+	Award    string    `json:"award"`
+	Year     int       `json:"year"`
+	Category string    `json:"category"`
+	Movie    *MovieRef `json:"movie"`
+	Person   *ActorRef `json:"person,omitempty"`
+	Won      bool      `json:"won"`
+}
+
+type addAward struct {
+	Name string `json:"name" validate:"required" example:"Academy Awards"`
+}
+
+type addCeremony struct {
+	AwardID int64 `json:"award_id" validate:"required" example:"1"`
+	Year    int   `json:"year" validate:"required" example:"1995"`
+}
+
+type addAwardCategory struct {
+	AwardID int64  `json:"award_id" validate:"required" example:"1"`
+	Name    string `json:"name" validate:"required" example:"Best Actor"`
+}
+
+type addNomination struct {
+	CeremonyID int64  `json:"ceremony_id" validate:"required" example:"1"`
+	CategoryID int64  `json:"category_id" validate:"required" example:"2"`
+	MovieID    int64  `json:"movie_id" validate:"required" example:"1"`
+	PersonID   *int64 `json:"person_id,omitempty" example:"3"`
+	Won        bool   `json:"won" example:"true"`
+}
@@ -0,0 +1,36 @@
+package models
+
+// Operations of a batch item.
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+// MovieBatchItem is one operation of a batch of movies. A create carries the Movie,
+// an update the Patch, and both an update and a delete the ID and the Version the
+// client has seen.
+type MovieBatchItem struct {
+	Op      string
+	ID      int64
+	Version int64
+	Movie   *Movie
+	Patch   *MoviePatch
+}
+
+// ActorBatchItem is one operation of a batch of actors, like MovieBatchItem.
+type ActorBatchItem struct {
+	Op      string
+	ID      int64
+	Version int64
+	Actor   *Actor
+	Patch   *ActorPatch
+}
+
+// BatchResult is the outcome of one batch item, the ID and version of the row it
+// created or updated, or the error it failed with.
+type BatchResult struct {
+	ID      int64
+	Version int64
+	Err     error
+}
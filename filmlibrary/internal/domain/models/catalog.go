@@ -0,0 +1,77 @@
+package models
+
+// Kinds of catalog records.
+const (
+	CatalogMovie = "movie"
+	CatalogActor = "actor"
+)
+
+// Changes a catalog import makes to a movie or an actor.
+const (
+	ImportCreate    = "create"
+	ImportUpdate    = "update"
+	ImportUnchanged = "unchanged"
+)
+
+// ImportRecord is a record of a catalog dump, a movie or an actor. Dates are written
+// as 2006-01-02 and a movie lists its cast by the IMDb IDs of the actors, who are
+// either stored or imported earlier in the dump.
+type ImportRecord struct {
+	// Row is the number of the record in the dump, counting the header of a CSV file.
+	Row              int      `json:"-"`
+	Type             string   `json:"type" validate:"required,oneof=movie actor"`
+	IMDbID           string   `json:"imdb_id,omitempty" validate:"max=12"`
+	KinopoiskID      string   `json:"kinopoisk_id,omitempty" validate:"max=12"`
+	Title            string   `json:"title,omitempty" validate:"max=150"`
+	Description      string   `json:"description,omitempty" validate:"max=1000"`
+	ReleaseDate      string   `json:"release_date,omitempty"`
+	Rating           *float64 `json:"rating,omitempty" validate:"min=0,max=10"`
+	Runtime          int      `json:"runtime,omitempty" validate:"min=1"`
+	OriginalLanguage string   `json:"original_language,omitempty" validate:"max=3"`
+	Cast             []string `json:"cast,omitempty"`
+	Name             string   `json:"name,omitempty" validate:"max=100"`
+	Sex              string   `json:"sex,omitempty" validate:"oneof=male female"`
+	Birthday         string   `json:"birthday,omitempty"`
+	DeathDate        string   `json:"death_date,omitempty"`
+	Birthplace       string   `json:"birthplace,omitempty" validate:"max=150"`
+}
+
+// ImportItem is a valid record of a catalog dump, either a Movie with the IMDb IDs of
+// its Cast or an Actor.
+type ImportItem struct {
+	Row   int
+	Movie *Movie
+	Cast  []string
+	Actor *Actor
+}
+
+// ImportChange is what importing a record changes. Fields lists the fields an update
+// changes, cast among them when actors are linked to the movie.
+type ImportChange struct {
+	Row    int      `json:"row" example:"2"`
+	Type   string   `json:"type" example:"movie"`
+	Action string   `json:"action" example:"update"`
+	ID     int64    `json:"id,omitempty" example:"1"`
+	Name   string   `json:"name" example:"Heat"`
+	Fields []string `json:"fields,omitempty" example:"runtime"`
+	Err    error    `json:"-"`
+}
+
+// ImportError is why a record of a catalog dump was not imported.
+type ImportError struct {
+	Row     int    `json:"row" example:"3"`
+	Message string `json:"message" example:"release_date is required"`
+}
+
+// ImportReport sums up a catalog import. A dry run reports the changes the import
+// would make without making them.
+type ImportReport struct {
+	DryRun    bool            `json:"dry_run"`
+	Rows      int             `json:"rows" example:"3"`
+	Created   int             `json:"created" example:"1"`
+	Updated   int             `json:"updated" example:"1"`
+	Unchanged int             `json:"unchanged" example:"0"`
+	Failed    int             `json:"failed" example:"1"`
+	Changes   []*ImportChange `json:"changes"`
+	Errors    []*ImportError  `json:"errors"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type Collection struct {
+	ID          int64             `json:"id"`
+	OwnerID     int64             `json:"owner_id"`
+	Title       string            `json:"title" validate:"required,max=150"`
+	Description string            `json:"description,omitempty" validate:"max=1000"`
+	Slug        string            `json:"slug" validate:"max=100"`
+	Public      bool              `json:"public"`
+	ItemsCount  int64             `json:"items_count"`
+	Items       []*CollectionItem `json:"items,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type CollectionItem struct {
+	MovieID     int64     `json:"movie_id"`
+	Title       string    `json:"title"`
+	ReleaseDate time.Time `json:"release_date"`
+	Position    int       `json:"position"`
+}
+
+type CollectionsPage struct {
+	Collections []*Collection `json:"collections"`
+	Total       int64         `json:"total"`
+	Page        int           `json:"page"`
+	Limit       int           `json:"limit"`
+}
+
+type CollectionMovies struct {
+	CollectionID int64   `json:"collection_id" validate:"required" example:"1"`
+	MoviesID     []int64 `json:"movies_id" validate:"required" example:"3,1,2"`
+}
+
+type addCollection struct {
+	Title       string `json:"title" validate:"required" example:"Best of 1994"`
+	Description string `json:"description,omitempty" example:"The year of Pulp Fiction and The Shawshank Redemption"`
+	Slug        string `json:"slug,omitempty" example:"best-of-1994"`
+	Public      bool   `json:"public,omitempty" example:"true"`
+}
+
+type editCollection struct {
+	ID          int64  `json:"id" validate:"required" example:"1"`
+	Title       string `json:"title" validate:"required" example:"Best of 1994"`
+	Description string `json:"description,omitempty" example:"The year of Pulp Fiction and The Shawshank Redemption"`
+	Public      bool   `json:"public,omitempty" example:"true"`
+}
@@ -0,0 +1,32 @@
+package models
+
+// ActorCredit links an actor to a movie they played in.
+type ActorCredit struct {
+	PersonID   int64
+	PersonName string
+	MovieID    int64
+	MovieTitle string
+}
+
+type ActorRef struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type MovieRef struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+// Separation is a chain of actors where Movies[i] connects Actors[i] and Actors[i+1].
+type Separation struct {
+	Degrees int         `json:"degrees"`
+	Actors  []*ActorRef `json:"actors"`
+	Movies  []*MovieRef `json:"movies"`
+}
+
+type Costar struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	SharedMovies int    `json:"shared_movies"`
+}
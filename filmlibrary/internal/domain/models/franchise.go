@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+const (
+	RelationSequel  = "sequel"
+	RelationPrequel = "prequel"
+	RelationRemake  = "remake"
+	RelationSpinOff = "spin_off"
+	// RelationOriginal is shown on the movie a remake or a spin-off is based on,
+	// it is never stored.
+	RelationOriginal = "original"
+)
+
+// RelationTypes lists the relations that can be set between two movies.
+var RelationTypes = []string{RelationSequel, RelationPrequel, RelationRemake, RelationSpinOff}
+
+type Franchise struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name" validate:"required,max=150"`
+	Description string            `json:"description,omitempty" validate:"max=1000"`
+	MoviesCount int64             `json:"movies_count"`
+	Movies      []*FranchiseMovie `json:"movies,omitempty"`
+}
+
+type FranchiseMovie struct {
+	MovieID     int64     `json:"movie_id"`
+	Title       string    `json:"title"`
+	ReleaseDate time.Time `json:"release_date"`
+	Position    int       `json:"position"`
+}
+
+type FranchiseMovies struct {
+	FranchiseID int64   `json:"franchise_id" validate:"required" example:"1"`
+	MoviesID    []int64 `json:"movies_id" validate:"required" example:"1,2,3"`
+}
+
+// MovieRelation says that the related movie is the Type of the movie,
+// e.g. The Godfather Part II is the sequel of The Godfather.
+type MovieRelation struct {
+	MovieID        int64  `json:"movie_id" validate:"required" example:"1"`
+	RelatedMovieID int64  `json:"related_movie_id" validate:"required" example:"2"`
+	Type           string `json:"type" validate:"required,oneof=sequel prequel remake spin_off" example:"sequel"`
+}
+
+type RelatedMovie struct {
+	Type  string    `json:"type"`
+	Movie *MovieRef `json:"movie"`
+}
+
+// MovieFranchise is the place of a movie in its franchise.
+type MovieFranchise struct {
+	ID       int64     `json:"id"`
+	Name     string    `json:"name"`
+	Position int       `json:"position"`
+	Previous *MovieRef `json:"previous,omitempty"`
+	Next     *MovieRef `json:"next,omitempty"`
+}
+
+type MovieDetail struct {
+	*MovieListing
+	Franchise *MovieFranchise `json:"franchise,omitempty"`
+	Related   []*RelatedMovie `json:"related"`
+}
+
+type addFranchise struct {
+	Name        string `json:"name" validate:"required" example:"The Godfather"`
+	Description string `json:"description,omitempty" example:"The Corleone family saga"`
+}
+
+type editFranchise struct {
+	ID          int64  `json:"id" validate:"required" example:"1"`
+	Name        string `json:"name" validate:"required" example:"The Godfather"`
+	Description string `json:"description,omitempty" example:"The Corleone family saga"`
+}
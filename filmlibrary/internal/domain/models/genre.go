@@ -0,0 +1,32 @@
+package models
+
+type Genre struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name,omitempty" validate:"required,max=50"`
+}
+
+type GenreListing struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	MoviesCount int64  `json:"movies_count"`
+}
+
+// MovieFacets counts the movies a filtered listing keeps by the values it can be
+// narrowed down further by.
+type MovieFacets struct {
+	Genres []*GenreListing `json:"genres"`
+}
+
+type addGenre struct {
+	Name string `json:"name" validate:"required" example:"Comedy"`
+}
+
+type editGenre struct {
+	ID   int64  `json:"id" validate:"required" example:"1"`
+	Name string `json:"name" validate:"required" example:"Comedy"`
+}
+
+type GenresTo struct {
+	MovieID int64   `json:"id" validate:"required" example:"2"`
+	Genres  []int64 `json:"genres_id,omitempty" validate:"required" example:"1"`
+}
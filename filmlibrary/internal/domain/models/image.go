@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	ImageOwnerMovie = "movie"
+	ImageOwnerActor = "actor"
+)
+
+type Image struct {
+	ID          int64     `json:"id"`
+	OwnerType   string    `json:"owner_type"`
+	OwnerID     int64     `json:"owner_id"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	Sizes       []string  `json:"sizes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
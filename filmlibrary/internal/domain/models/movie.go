@@ -2,6 +2,7 @@ package models
 
 import "time"
 
+// Movie is a movie as it is added, it is edited with a MoviePatch.
 type Movie struct {
 	ID          int64     `json:"id"`
 	Title       string    `json:"title,omitempty"`
@@ -9,7 +10,27 @@ type Movie struct {
 	ReleaseDate time.Time `json:"release_date,omitempty"`
 	Rating      *float64  `json:"rating,omitempty"`
 	ActorsID    []int     `json:"actors_id,omitempty"`
-	DeletedAt   time.Time `json:"deleted_at,omitempty"`
+	GenresID    []int     `json:"genres_id,omitempty"`
+	MovieFacts
+	DeletedAt time.Time `json:"deleted_at,omitempty"`
+}
+
+// MovieFacts are the production facts of a movie.
+type MovieFacts struct {
+	// Runtime is the length of the movie in minutes.
+	Runtime          int          `json:"runtime,omitempty"`
+	Budget           *Money       `json:"budget,omitempty"`
+	Gross            *Money       `json:"gross,omitempty"`
+	OriginalLanguage string       `json:"original_language,omitempty"`
+	SpokenLanguages  []string     `json:"spoken_languages,omitempty"`
+	Countries        []string     `json:"production_countries,omitempty"`
+	ExternalIDs      *ExternalIDs `json:"external_ids,omitempty"`
+}
+
+// Money is an amount in whole units of an ISO 4217 currency.
+type Money struct {
+	Amount   int64  `json:"amount" validate:"min=0"`
+	Currency string `json:"currency" validate:"required"`
 }
 
 type MovieListing struct {
@@ -18,26 +39,37 @@ type MovieListing struct {
 	Description string    `json:"description,omitempty"`
 	ReleaseDate time.Time `json:"release_date,omitempty"`
 	Rating      *float64  `json:"rating,omitempty"`
-	Actors      []string  `json:"actors_id,omitempty"`
+	UserRating  *float64  `json:"user_rating,omitempty"`
+	VotesCount  int64     `json:"votes_count,omitempty"`
+	Actors      []string  `json:"actors_id"`
+	Genres      []string  `json:"genres,omitempty"`
+	MovieFacts
+	// Version grows with every edit, it is sent as the ETag of the movie.
+	Version int64 `json:"version,omitempty"`
 }
 
-type MoviesTo struct {
-	ActorID int64   `json:"id" binding:"required" example:"1"`
-	Movies  []int64 `json:"movies_id,omitempty" binding:"required" example:"123"`
-}
-
-type editMovie struct {
-	ID          int64     `json:"id" binding:"required" example:"1"`
-	Title       string    `json:"title,omitempty" example:"The Shawshank Redemption"`
-	Description string    `json:"description,omitempty" example:"Two"`
-	ReleaseDate time.Time `json:"release_date,omitempty" example:"1994-10-14" format:"date"`
-	Rating      *float64  `json:"rating,omitempty" example:"9.3"`
+// MovieFilter narrows down the movie listing, zero values are not applied.
+// The release filters match releases in Country and of ReleaseType when those are set.
+type MovieFilter struct {
+	Genre          string
+	Country        string
+	ReleaseType    string
+	ReleasedBefore time.Time
+	ReleasedAfter  time.Time
+	// SuitableForAge keeps movies certified for viewers of that age.
+	SuitableForAge *int
+	// WonAward keeps movies that won the award of that name in any category.
+	WonAward string
+	// MinRuntime and MaxRuntime bound the runtime in minutes.
+	MinRuntime int
+	MaxRuntime int
+	// MinGross and MaxGross bound the gross of movies grossed in GrossCurrency.
+	MinGross      int64
+	MaxGross      int64
+	GrossCurrency string
 }
 
-type addMovie struct {
-	Title       string    `json:"title,omitempty" binding:"required" example:"The Shawshank Redemption"`
-	Description string    `json:"description,omitempty" binding:"required" example:"Two"`
-	ReleaseDate time.Time `json:"release_date,omitempty" binding:"required" example:"1994-10-14" format:"date"`
-	Rating      *float64  `json:"rating,omitempty" binding:"required" example:"9.3"`
-	ActorsID    []int     `json:"actors_id,omitempty" binding:"required"`
+type MoviesTo struct {
+	ActorID int64   `json:"id" validate:"required" example:"1"`
+	Movies  []int64 `json:"movies_id,omitempty" validate:"required" example:"123"`
 }
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Field is a member of a JSON Merge Patch (RFC 7396). Set tells a member that is
+// present from an absent one, which is left unchanged, and Null tells a member that
+// is null, which clears the field.
+type Field[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// SetTo returns a member that sets the field to value.
+func SetTo[T any](value T) Field[T] {
+	return Field[T]{Set: true, Value: value}
+}
+
+// SetNull returns a member that clears the field.
+func SetNull[T any]() Field[T] {
+	return Field[T]{Set: true, Null: true}
+}
+
+// Changed reports whether the member sets the field to a value.
+func (f Field[T]) Changed() bool {
+	return f.Set && !f.Null
+}
+
+// Member returns the value of the member for validation, nil when it is null.
+func (f Field[T]) Member() (interface{}, bool) {
+	if f.Null {
+		return nil, f.Set
+	}
+	return f.Value, f.Set
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if string(data) == "null" {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// MoviePatch is a JSON Merge Patch of a movie. Money is replaced as a whole, external
+// IDs are merged one by one.
+type MoviePatch struct {
+	Title            Field[string]           `json:"title"`
+	Description      Field[string]           `json:"description"`
+	ReleaseDate      Field[time.Time]        `json:"release_date"`
+	Rating           Field[float64]          `json:"rating"`
+	Runtime          Field[int]              `json:"runtime"`
+	Budget           Field[Money]            `json:"budget"`
+	Gross            Field[Money]            `json:"gross"`
+	OriginalLanguage Field[string]           `json:"original_language"`
+	SpokenLanguages  Field[[]string]         `json:"spoken_languages"`
+	Countries        Field[[]string]         `json:"production_countries"`
+	ExternalIDs      Field[ExternalIDsPatch] `json:"external_ids"`
+}
+
+// ActorPatch is a JSON Merge Patch of an actor profile.
+type ActorPatch struct {
+	Name        Field[string]           `json:"name"`
+	Sex         Field[string]           `json:"sex"`
+	Birthday    Field[time.Time]        `json:"birthday"`
+	DeathDate   Field[time.Time]        `json:"death_date"`
+	Birthplace  Field[string]           `json:"birthplace"`
+	Biography   Field[string]           `json:"biography"`
+	Aliases     Field[[]string]         `json:"aliases"`
+	ExternalIDs Field[ExternalIDsPatch] `json:"external_ids"`
+}
+
+// ExternalIDsPatch merges external IDs, a null external_ids clears all of them.
+type ExternalIDsPatch struct {
+	IMDb      Field[string] `json:"imdb" validate:"max=12"`
+	Kinopoisk Field[string] `json:"kinopoisk" validate:"max=12"`
+}
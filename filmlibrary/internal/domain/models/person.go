@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+const (
+	RoleActor    = "actor"
+	RoleDirector = "director"
+	RoleWriter   = "writer"
+	RoleComposer = "composer"
+	RoleProducer = "producer"
+)
+
+// CrewRoles lists every role a person can have on a movie.
+var CrewRoles = []string{RoleActor, RoleDirector, RoleWriter, RoleComposer, RoleProducer}
+
+type Person struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name,omitempty" validate:"required,max=100"`
+	Sex       string    `json:"sex,omitempty" validate:"oneof=male female"`
+	Birthday  time.Time `json:"birthday,omitempty" validate:"notfuture"`
+	DeletedAt time.Time `json:"deleted_at,omitempty"`
+}
+
+type CrewMember struct {
+	PersonID int64  `json:"person_id"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+}
+
+type Credit struct {
+	MovieID     int64     `json:"movie_id"`
+	Title       string    `json:"title"`
+	ReleaseDate time.Time `json:"release_date"`
+	Role        string    `json:"role"`
+}
+
+type Filmography struct {
+	ID       int64     `json:"id"`
+	Name     string    `json:"name"`
+	Sex      string    `json:"sex,omitempty"`
+	Birthday time.Time `json:"birthday,omitempty"`
+	Credits  []*Credit `json:"credits"`
+}
+
+type CrewRole struct {
+	PersonID int64  `json:"person_id" validate:"required" example:"1"`
+	Role     string `json:"role" validate:"required,oneof=actor director writer composer producer" example:"director"`
+}
+
+type CrewTo struct {
+	MovieID int64      `json:"id" validate:"required" example:"2"`
+	Crew    []CrewRole `json:"crew" validate:"required"`
+}
+
+type addPerson struct {
+	Name     string    `json:"name" validate:"required" example:"Frank Darabont"`
+	Sex      string    `json:"sex,omitempty" example:"male"`
+	Birthday time.Time `json:"birthday,omitempty" example:"1959-01-28" format:"date"`
+}
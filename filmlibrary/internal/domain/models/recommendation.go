@@ -0,0 +1,24 @@
+package models
+
+// SimilarityCandidate describes how close a candidate movie is to a source movie.
+type SimilarityCandidate struct {
+	SourceID     int64
+	MovieID      int64
+	SharedActors int
+	SharedGenres int
+	DaysApart    int
+	Rating       float64
+	UserRating   *float64
+}
+
+// TasteSeed is a movie the user rated or watched.
+type TasteSeed struct {
+	MovieID int64
+	Rating  *int
+	Watched bool
+}
+
+type Recommendation struct {
+	Movie *MovieListing `json:"movie"`
+	Score float64       `json:"score"`
+}
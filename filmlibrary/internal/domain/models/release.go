@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+const (
+	ReleaseTheatrical = "theatrical"
+	ReleaseStreaming  = "streaming"
+	ReleaseFestival   = "festival"
+)
+
+// ReleaseTypes lists every way a movie can be released in a country.
+var ReleaseTypes = []string{ReleaseTheatrical, ReleaseStreaming, ReleaseFestival}
+
+type Release struct {
+	ID            int64     `json:"id"`
+	MovieID       int64     `json:"movie_id" validate:"required"`
+	Country       string    `json:"country" validate:"required,max=2"`
+	Type          string    `json:"release_type" validate:"required,oneof=theatrical streaming festival"`
+	ReleaseDate   time.Time `json:"release_date" validate:"required"`
+	Certification string    `json:"certification,omitempty" validate:"max=10"`
+	MinAge        *int      `json:"min_age,omitempty"`
+}
+
+type addRelease struct {
+	MovieID       int64     `json:"movie_id" validate:"required" example:"1"`
+	Country       string    `json:"country" validate:"required" example:"RU"`
+	Type          string    `json:"release_type" validate:"required" example:"theatrical"`
+	ReleaseDate   time.Time `json:"release_date" validate:"required" example:"1995-03-10" format:"date"`
+	Certification string    `json:"certification,omitempty" example:"16+"`
+}
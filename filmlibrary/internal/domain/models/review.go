@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type Review struct {
+	ID        int64     `json:"id"`
+	MovieID   int64     `json:"movie_id" validate:"required"`
+	UserID    int64     `json:"user_id"`
+	Rating    int       `json:"rating" validate:"min=0,max=10"`
+	Text      string    `json:"text,omitempty" validate:"max=5000"`
+	Hidden    bool      `json:"hidden,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ReviewsPage struct {
+	Reviews []*Review `json:"reviews"`
+	Total   int64     `json:"total"`
+	Page    int       `json:"page"`
+	Limit   int       `json:"limit"`
+}
+
+type ReviewModeration struct {
+	ID     int64 `json:"id" validate:"required" example:"1"`
+	Hidden bool  `json:"hidden" example:"true"`
+}
+
+type addReview struct {
+	MovieID int64  `json:"movie_id" validate:"required" example:"1"`
+	Rating  int    `json:"rating" validate:"required" example:"8"`
+	Text    string `json:"text,omitempty" example:"Hope is a good thing."`
+}
@@ -0,0 +1,14 @@
+package models
+
+type MovieTranslation struct {
+	MovieID     int64  `json:"movie_id" validate:"required" example:"1"`
+	Locale      string `json:"locale" validate:"required,max=3" example:"ru"`
+	Title       string `json:"title" validate:"required,max=150" example:"Побег из Шоушенка"`
+	Description string `json:"description,omitempty" validate:"max=1000" example:"Два заключённых"`
+}
+
+type ActorTranslation struct {
+	ActorID int64  `json:"actor_id" validate:"required" example:"1"`
+	Locale  string `json:"locale" validate:"required,max=3" example:"ru"`
+	Name    string `json:"name" validate:"required,max=100" example:"Владимир Путин"`
+}
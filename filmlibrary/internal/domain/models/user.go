@@ -8,12 +8,12 @@ type User struct {
 }
 
 type UserLogin struct {
-	Email    string `json:"email" binding:"required" example:"ivanov@mail.ru""`
-	Password string `json:"password" binding:"required" example:"123456ksksksksk"`
+	Email    string `json:"email" validate:"required" example:"ivanov@mail.ru"`
+	Password string `json:"password" validate:"required" example:"123456ksksksksk"`
 }
 
 type UserCreate struct {
-	Email    string `json:"email" binding:"required" example:"ivanov@mail.ru""`
-	Role     string `json:"role" binding:"required" example:"admin"`
-	Password string `json:"password" binding:"required" example:"123456ksksksksk"`
+	Email    string `json:"email" validate:"required,max=30" example:"ivanov@mail.ru"`
+	Role     string `json:"role" validate:"required,max=10" example:"admin"`
+	Password string `json:"password" validate:"required" example:"123456ksksksksk"`
 }
@@ -0,0 +1,14 @@
+package models
+
+// ValidationErrors is the body of the 422 response to a request whose fields break
+// their validation rules.
+type ValidationErrors struct {
+	Errors []FieldError `json:"errors"`
+}
+
+// FieldError is a rule a field of the request breaks, Field is its path in the JSON
+// of the request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
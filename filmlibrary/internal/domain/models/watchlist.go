@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+type WatchlistItem struct {
+	MovieID  int64     `json:"movie_id" validate:"required"`
+	Title    string    `json:"title,omitempty"`
+	Position int       `json:"position"`
+	Note     string    `json:"note,omitempty" validate:"max=500"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+type WatchedEntry struct {
+	ID        int64     `json:"id"`
+	MovieID   int64     `json:"movie_id" validate:"required"`
+	Title     string    `json:"title,omitempty"`
+	WatchedAt time.Time `json:"watched_at" validate:"notfuture"`
+}
+
+type WatchedPage struct {
+	Entries []*WatchedEntry `json:"entries"`
+	Total   int64           `json:"total"`
+	Page    int             `json:"page"`
+	Limit   int             `json:"limit"`
+}
+
+type WatchlistOrder struct {
+	MoviesID []int64 `json:"movies_id" validate:"required" example:"3,1,2"`
+}
+
+type addWatchlistItem struct {
+	MovieID int64  `json:"movie_id" validate:"required" example:"1"`
+	Note    string `json:"note,omitempty" example:"Recommended by Anna"`
+}
+
+type addWatchedEntry struct {
+	MovieID   int64     `json:"movie_id" validate:"required" example:"1"`
+	WatchedAt time.Time `json:"watched_at,omitempty" example:"2024-03-20T00:00:00Z"`
+}
@@ -2,8 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"filmlibrary/internal/domain/models"
 	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -11,43 +14,70 @@ import (
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name ActorProvider
 type ActorProvider interface {
-	EditActor(actor *models.Actor) error
+	EditActor(id int64, version int64, patch *models.ActorPatch) (int64, error)
 	AddActor(actor *models.Actor) error
 	AddMoviesToActor(actorID int64, movies []int64) error
-	GetActors() ([]*models.ActorListing, error)
-	DeleteActor(id int64) error
+	GetActors(langs []string) ([]*models.ActorListing, error)
+	GetActor(id int64, langs []string) (*models.Actor, error)
+	DeleteActor(id int64, version int64) error
+	BatchActors(items []*models.ActorBatchItem, size int, atomic bool) ([]*models.BatchResult, error)
 }
 
 // @Summary Edit actor's data
 // @Security ApiKeyAuth
-// @Description Edit actor's data.
+// @Description Edit actor's data with a JSON Merge Patch. Absent fields are left unchanged and null clears a field, the name cannot be cleared. If-Match must carry the ETag of the actor being edited.
 // @Tags Actors
 // @Accept json
+// @Accept application/merge-patch+json
 // @Produce json
-// @Param input body models.editActor true "Actor object to be edited"
+// @Param If-Match header string true "ETag of a read of the edited version, or * for any version"
+// @Param id path int true "Actor ID"
+// @Param input body editActorRequest true "Merge patch of the actor"
 // @Success 200 {string} string "Successfully edited an actor"
 // @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 412 {string} string "Version has changed"
+// @Failure 428 {string} string "If-Match is required"
 // @Failure 500 {string} string "Internal server error"
-// @Router /edit/actor [post]
+// @Router /actors/{id} [patch]
 func (h *Handler) editActor(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.editActor"
 
 	log := h.log.With(slog.String("op", op))
 
-	actor := &models.Actor{}
-	err := json.NewDecoder(r.Body).Decode(actor)
+	req := &editActorRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
 	if err != nil {
 		log.Error("failed to decode request body", sl.Err(err))
 		http.Error(w, "failed to decode request", http.StatusBadRequest)
 		return
 	}
 
+	err = bindPathID(r, "id", &req.ID)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		log.Error("If-Match header missing")
+		http.Error(w, "If-Match header with the ETag of the actor is required", http.StatusPreconditionRequired)
+		return
+	}
+
+	if !validRequest(w, log, req) {
+		return
+	}
+
 	log.Info("request body decoded")
 
-	err = h.actorProvider.EditActor(actor)
+	_, err = h.actorProvider.EditActor(req.ID, version, req.patch())
 	if err != nil {
-		log.Error("failed to edit an actor", sl.Err(err))
-		http.Error(w, "failed to edit an actor", http.StatusBadRequest)
+		actorError(w, log, err, "failed to edit an actor", http.StatusInternalServerError)
 		return
 	}
 
@@ -56,19 +86,20 @@ func (h *Handler) editActor(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Get list of actors
-// @Description Retrieves a list of actors.
+// @Description Retrieves a list of actors. Names and movie titles are translated to the languages of Accept-Language when available.
 // @Tags Actors
 // @Accept json
 // @Produce json
-// @Success 200 {array} models.getActor "Successfully fetched actors"
+// @Param Accept-Language header string false "Preferred languages"
+// @Success 200 {array} models.ActorListing "Successfully fetched actors"
 // @Failure 500 {string} string "Internal server error"
-// @Router /get/actors [get]
+// @Router /actors [get]
 func (h *Handler) getActors(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.getActors"
 
 	log := h.log.With(slog.String("op", op))
 
-	actors, err := h.actorProvider.GetActors()
+	actors, err := h.actorProvider.GetActors(preferredLanguages(r.Header.Get("Accept-Language")))
 	if err != nil {
 		log.Error("failed to fetch actors", sl.Err(err))
 		http.Error(w, "Failed to fetch actors", http.StatusInternalServerError)
@@ -84,27 +115,82 @@ func (h *Handler) getActors(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("Vary", "Accept-Language")
+	w.WriteHeader(http.StatusOK)
+	w.Write(actorJSON)
+}
+
+// @Summary Get actor
+// @Description Retrieves the profile of an actor with their age and the IDs of their movies. The name is translated to the languages of Accept-Language when available.
+// @Tags Actors
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Preferred languages"
+// @Param If-None-Match header string false "ETag of the cached version"
+// @Param id path int true "Actor ID"
+// @Success 200 {object} actorResponse
+// @Header 200 {string} ETag "Version of the actor and a hash of the response"
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /actors/{id} [get]
+func (h *Handler) getActor(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getActor"
+
+	log := h.log.With(slog.String("op", op))
+
+	actorID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	langs := preferredLanguages(r.Header.Get("Accept-Language"))
+	actor, err := h.actorProvider.GetActor(actorID, langs)
+	if err != nil {
+		actorError(w, log, err, "failed to fetch an actor", http.StatusInternalServerError)
+		return
+	}
+
+	actorJSON, err := json.Marshal(newActorResponse(actor))
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	if notModified(w, r, representationETag(actor.Version, actorJSON, langs)) {
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	w.Write(actorJSON)
 }
 
 // @Summary Delete actor by ID
 // @Security ApiKeyAuth
-// @Description Deletes an actor by its ID.
+// @Description Deletes an actor by its ID. If-Match must carry the ETag of the actor being deleted.
 // @Tags Actors
 // @Accept json
 // @Produce json
-// @Param id query int true "Actor ID to be deleted"
+// @Param If-Match header string true "ETag of a read of the deleted version, or * for any version"
+// @Param id path int true "Actor ID to be deleted"
 // @Success 200 {string} string "Successfully deleted an actor"
 // @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 412 {string} string "Version has changed"
+// @Failure 428 {string} string "If-Match is required"
 // @Failure 500 {string} string "Internal server error"
-// @Router /delete/actor [delete]
+// @Router /actors/{id} [delete]
 func (h *Handler) deleteActor(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.deleteActor"
 
 	log := h.log.With(slog.String("op", op))
 
-	actorIDStr := r.URL.Query().Get("id")
+	actorIDStr := requestParam(r, "id")
 	actorID, err := strconv.ParseInt(actorIDStr, 10, 64)
 	if err != nil {
 		log.Error("invalid actor ID", sl.Err(err))
@@ -114,10 +200,16 @@ func (h *Handler) deleteActor(w http.ResponseWriter, r *http.Request) {
 
 	log.Info("parsed ID")
 
-	err = h.actorProvider.DeleteActor(actorID)
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		log.Error("If-Match header missing")
+		http.Error(w, "If-Match header with the ETag of the actor is required", http.StatusPreconditionRequired)
+		return
+	}
+
+	err = h.actorProvider.DeleteActor(actorID, version)
 	if err != nil {
-		log.Error("failed to delete an actor", sl.Err(err))
-		http.Error(w, "failed to delete an actor", http.StatusBadRequest)
+		actorError(w, log, err, "failed to delete an actor", http.StatusBadRequest)
 		return
 	}
 
@@ -131,46 +223,36 @@ func (h *Handler) deleteActor(w http.ResponseWriter, r *http.Request) {
 // @Tags Actors
 // @Accept json
 // @Produce json
-// @Param input body models.addActor true "Actor object to be added"
+// @Param input body addActorRequest true "Actor object to be added"
 // @Success 201 {string} string "Successfully added an actor"
 // @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 409 {string} string "Conflict"
 // @Failure 500 {string} string "Internal server error"
-// @Router /add/actor [post]
+// @Router /actors [post]
 func (h *Handler) addActor(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.addActor"
 
 	log := h.log.With(slog.String("op", op))
 
-	actor := &models.Actor{}
-	err := json.NewDecoder(r.Body).Decode(actor)
+	req := &addActorRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
 	if err != nil {
 		log.Error("failed to decode request body", sl.Err(err))
 		http.Error(w, "failed to decode request", http.StatusBadRequest)
 		return
 	}
 
-	if actor.Name == "" {
-		log.Error("actor name is empty", sl.Err(err))
-		http.Error(w, "actor name is empty", http.StatusBadRequest)
-		return
-	}
-	if actor.Sex == "" {
-		log.Error("actor sex is empty", sl.Err(err))
-		http.Error(w, "actor sex is empty", http.StatusBadRequest)
-		return
-	}
-	if actor.Birthday.IsZero() {
-		log.Error("actor birthday is empty", sl.Err(err))
-		http.Error(w, "actor birthday is empty", http.StatusBadRequest)
+	if !validRequest(w, log, req) {
 		return
 	}
 
 	log.Info("request body decoded")
 
-	err = h.actorProvider.AddActor(actor)
+	err = h.actorProvider.AddActor(req.actor())
 	if err != nil {
-		log.Error("failed to add an actor", sl.Err(err))
-		http.Error(w, "failed to add an actor", http.StatusBadRequest)
+		actorError(w, log, err, "failed to add an actor", http.StatusBadRequest)
+		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
@@ -183,11 +265,13 @@ func (h *Handler) addActor(w http.ResponseWriter, r *http.Request) {
 // @Tags Actors
 // @Accept json
 // @Produce json
+// @Param id path int true "Actor ID"
 // @Param input body models.MoviesTo true "Actor ID and movie IDs to be added"
 // @Success 200 {string} string "Successfully added movie(s) to actor"
 // @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
 // @Failure 500 {string} string "Internal server error"
-// @Router /actor/add/movies [post]
+// @Router /actors/{id}/movies [post]
 func (h *Handler) addMoviesToActor(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.addMoviesToActor"
 
@@ -202,6 +286,17 @@ func (h *Handler) addMoviesToActor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = bindPathID(r, "id", &mtoa.ActorID)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, mtoa) {
+		return
+	}
+
 	log.Info("request body decoded")
 
 	err = h.actorProvider.AddMoviesToActor(mtoa.ActorID, mtoa.Movies)
@@ -214,3 +309,38 @@ func (h *Handler) addMoviesToActor(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("Successfully added movie(s) to actor"))
 }
+
+// actorError reports an error of managing actor profiles, other errors are
+// reported with the status.
+func actorError(w http.ResponseWriter, log *slog.Logger, err error, message string, status int) {
+	message, status = actorErrorStatus(err, message, status)
+	log.Error(message, sl.Err(err))
+	http.Error(w, message, status)
+}
+
+// actorErrorStatus returns the message and the status actorError reports the error with.
+func actorErrorStatus(err error, message string, status int) (string, int) {
+	for _, invalid := range []error{
+		service.ErrBirthdayInFuture,
+		service.ErrDeathInFuture,
+		service.ErrDeathBeforeBirth,
+		service.ErrInvalidIMDbID,
+		service.ErrInvalidKinopoiskID,
+		service.ErrInvalidActorName,
+	} {
+		if errors.Is(err, invalid) {
+			return invalid.Error(), http.StatusBadRequest
+		}
+	}
+
+	switch {
+	case errors.Is(err, storage.ErrExternalIDExists):
+		return storage.ErrExternalIDExists.Error(), http.StatusConflict
+	case errors.Is(err, storage.ErrPersonNotFound):
+		return "actor not found", http.StatusNotFound
+	case errors.Is(err, storage.ErrVersionMismatch):
+		return storage.ErrVersionMismatch.Error(), http.StatusPreconditionFailed
+	}
+
+	return message, status
+}
@@ -2,9 +2,11 @@ package handler
 
 import (
 	"bytes"
-	"encoding/json"
 	"filmlibrary/internal/domain/models"
 	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io"
@@ -19,19 +21,16 @@ import (
 
 func TestEditActor(t *testing.T) {
 	mockActorProvider := mocks.NewActorProvider(t)
-	mockUserProvider := mocks.NewUserProvider(t)
-	mockMovieProvider := mocks.NewMovieProvider(t)
-	mockAuthProvider := mocks.NewAuthProvider(t)
 
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	h := New(logger, mockUserProvider, mockActorProvider, mockMovieProvider, mockAuthProvider)
+	h := &Handler{log: logger, actorProvider: mockActorProvider}
 
-	actor := &models.Actor{ID: 1, Name: "John Doe"}
-	actorJSON, _ := json.Marshal(actor)
-	req, _ := http.NewRequest("POST", "/edit/actor", bytes.NewBuffer(actorJSON))
+	req, _ := http.NewRequest("POST", "/edit/actor", bytes.NewBufferString(`{"id":1,"name":"John Doe","biography":null}`))
+	req.Header.Set("If-Match", `"1"`)
 	rr := httptest.NewRecorder()
 
-	mockActorProvider.On("EditActor", actor).Return(nil)
+	patch := &models.ActorPatch{Name: models.SetTo("John Doe"), Biography: models.SetNull[string]()}
+	mockActorProvider.On("EditActor", int64(1), int64(1), patch).Return(int64(2), nil)
 
 	h.editActor(rr, req)
 
@@ -97,6 +96,25 @@ func TestHandler_addActor(t *testing.T) {
 	}
 }
 
+func TestHandler_addActor_ServerFields(t *testing.T) {
+	actorMock := &mocks.ActorProvider{}
+	actorMock.On("AddActor", mock.MatchedBy(func(actor *models.Actor) bool {
+		return actor.ID == 0 && actor.MoviesID == nil && actor.DeletedAt.IsZero() && actor.Name == "Actor 1"
+	})).Return(nil)
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		actorProvider: actorMock,
+	}
+
+	body := `{"id":5,"name":"Actor 1","sex":"male","birthday":"1951-10-05T00:00:00Z","movies_id":[3],"deleted_at":"2024-01-01T00:00:00Z"}`
+	w := httptest.NewRecorder()
+	h.addActor(w, httptest.NewRequest(http.MethodPost, "/create/actor", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	actorMock.AssertExpectations(t)
+}
+
 func TestHandler_addActor_BodyEmpty(t *testing.T) {
 	type fields struct {
 		log           *slog.Logger
@@ -282,14 +300,14 @@ func TestHandler_deleteActor(t *testing.T) {
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: httptest.NewRequest(http.MethodPost, "/delete/actor?id=123", nil),
+				r: withIfMatch(httptest.NewRequest(http.MethodPost, "/delete/actor?id=123", nil), 1),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			actorMock := &mocks.ActorProvider{}
-			actorMock.On("DeleteActor", mock.AnythingOfType("int64")).Return(nil) // Mock expectation
+			actorMock.On("DeleteActor", mock.AnythingOfType("int64"), mock.AnythingOfType("int64")).Return(nil) // Mock expectation
 
 			h := &Handler{
 				log:           tt.fields.log,
@@ -348,7 +366,7 @@ func TestHandler_deleteActor_Invalid(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			actorMock := &mocks.ActorProvider{}
-			actorMock.On("DeleteActor", mock.AnythingOfType("int64")).Return(nil) // Mock expectation
+			actorMock.On("DeleteActor", mock.AnythingOfType("int64"), mock.AnythingOfType("int64")).Return(nil) // Mock expectation
 
 			h := &Handler{
 				log:           tt.fields.log,
@@ -400,19 +418,19 @@ func TestHandler_editActor(t *testing.T) {
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: httptest.NewRequest(http.MethodPost, "/edit/actor", bytes.NewBuffer([]byte(`{
+				r: withIfMatch(httptest.NewRequest(http.MethodPost, "/edit/actor", bytes.NewBuffer([]byte(`{
 					"id": 123,
 					"name": "Updated Name",
 					"sex": "male",
 					"birthday": "2023-03-16T12:34:56Z"
-				}`))),
+				}`))), 1),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			actorMock := &mocks.ActorProvider{}
-			actorMock.On("EditActor", mock.AnythingOfType("*models.Actor")).Return(nil) // Mock expectation
+			actorMock.On("EditActor", mock.AnythingOfType("int64"), mock.AnythingOfType("int64"), mock.AnythingOfType("*models.ActorPatch")).Return(int64(2), nil) // Mock expectation
 
 			h := &Handler{
 				log:           tt.fields.log,
@@ -464,17 +482,17 @@ func TestHandler_editActor_NotFullBody(t *testing.T) {
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: httptest.NewRequest(http.MethodPost, "/edit/actor", bytes.NewBuffer([]byte(`{
+				r: withIfMatch(httptest.NewRequest(http.MethodPost, "/edit/actor", bytes.NewBuffer([]byte(`{
 					"sex": "male",
 					"birthday": "2023-03-16T12:34:56Z"
-				}`))),
+				}`))), 1),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			actorMock := &mocks.ActorProvider{}
-			actorMock.On("EditActor", mock.AnythingOfType("*models.Actor")).Return(nil) // Mock expectation
+			actorMock.On("EditActor", mock.AnythingOfType("int64"), mock.AnythingOfType("int64"), mock.AnythingOfType("*models.ActorPatch")).Return(int64(2), nil) // Mock expectation
 
 			h := &Handler{
 				log:           tt.fields.log,
@@ -534,7 +552,7 @@ func TestHandler_getActors(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			actorProviderMock := &mocks.ActorProvider{}
 			timing := time.Now()
-			actorProviderMock.On("GetActors").Return([]*models.ActorListing{
+			actorProviderMock.On("GetActors", []string(nil)).Return([]*models.ActorListing{
 				{ID: 1, Name: "Actor 1", Sex: "Male", Birthday: timing, Movies: nil},
 				{ID: 2, Name: "Actor 2", Sex: "Female", Birthday: timing, Movies: nil},
 			}, nil) // Mock expectation
@@ -555,9 +573,114 @@ func TestHandler_getActors(t *testing.T) {
 			}
 
 			// Assert response body
-			expectedBody := `[{"id":1,"name":"Actor 1","sex":"Male","birthday":"` + timing.Format("2006-01-02T15:04:05.999999999-07:00") + `"},{"id":2,"name":"Actor 2","sex":"Female","birthday":"` + timing.Format("2006-01-02T15:04:05.999999999-07:00") + `"}]`
+			expectedBody := `[{"id":1,"name":"Actor 1","sex":"Male","birthday":"` + timing.Format(time.RFC3339Nano) + `"},{"id":2,"name":"Actor 2","sex":"Female","birthday":"` + timing.Format(time.RFC3339Nano) + `"}]`
 			assert.Equal(t, expectedBody, resp.Body.String())
 
 		})
 	}
 }
+
+func TestHandler_editActor_Profile(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Profile edited",
+			body:        `{"id":1,"birthplace":"Leningrad","aliases":[],"external_ids":{"imdb":"nm0000158"}}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully edited an actor",
+		},
+		{
+			name:        "Name cleared",
+			body:        `{"id":1,"name":null}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"name","message":"must not be null"}]}`,
+		},
+		{
+			name:        "Death before birth",
+			body:        `{"id":1,"death_date":"1900-01-01T00:00:00Z"}`,
+			providerErr: fmt.Errorf("service.EditActor: %w", service.ErrDeathBeforeBirth),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrDeathBeforeBirth.Error(),
+		},
+		{
+			name:        "External ID taken",
+			body:        `{"id":1,"external_ids":{"kinopoisk":"9144"}}`,
+			providerErr: fmt.Errorf("service.EditActor: %w", storage.ErrExternalIDExists),
+			wantStatus:  http.StatusConflict,
+			wantMessage: storage.ErrExternalIDExists.Error(),
+		},
+		{
+			name:        "Actor not found",
+			body:        `{"id":99,"birthday":"1950-01-01T00:00:00Z"}`,
+			providerErr: fmt.Errorf("service.EditActor: %w", storage.ErrPersonNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "actor not found",
+		},
+		{
+			name:        "Storage failure",
+			body:        `{"id":1,"birthplace":"Leningrad"}`,
+			providerErr: fmt.Errorf("storage.postgresql.EditActorStorage: %w", io.ErrUnexpectedEOF),
+			wantStatus:  http.StatusInternalServerError,
+			wantMessage: "failed to edit an actor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actorMock := &mocks.ActorProvider{}
+			actorMock.On("EditActor", mock.AnythingOfType("int64"), mock.AnythingOfType("int64"), mock.AnythingOfType("*models.ActorPatch")).Return(int64(2), tt.providerErr)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				actorProvider: actorMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.editActor(w, withIfMatch(httptest.NewRequest(http.MethodPost, "/edit/actor", bytes.NewBufferString(tt.body)), 1))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getActor(t *testing.T) {
+	age := 45
+	died := time.Date(1997, 2, 20, 0, 0, 0, 0, time.UTC)
+
+	actorMock := &mocks.ActorProvider{}
+	actorMock.On("GetActor", int64(1), []string(nil)).Return(&models.Actor{
+		ID:          1,
+		Name:        "Actor 1",
+		Birthday:    time.Date(1951, 10, 5, 0, 0, 0, 0, time.UTC),
+		DeathDate:   &died,
+		Birthplace:  "Moscow",
+		Aliases:     []string{"A. One"},
+		ExternalIDs: &models.ExternalIDs{IMDb: "nm0000158"},
+		Age:         &age,
+		MoviesID:    []int{3},
+	}, nil)
+	actorMock.On("GetActor", int64(2), []string(nil)).Return(nil, fmt.Errorf("service.GetActor: %w", storage.ErrPersonNotFound))
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		actorProvider: actorMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.getActor(w, httptest.NewRequest(http.MethodGet, "/get/actor?id=1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"id":1,"name":"Actor 1","birthday":"1951-10-05T00:00:00Z","death_date":"1997-02-20T00:00:00Z","birthplace":"Moscow",`+
+		`"aliases":["A. One"],"external_ids":{"imdb":"nm0000158"},"age":45,"movies_id":[3]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	h.getActor(w, httptest.NewRequest(http.MethodGet, "/get/actor?id=2", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "actor not found", strings.TrimSpace(w.Body.String()))
+}
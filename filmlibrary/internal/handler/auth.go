@@ -23,6 +23,7 @@ type AuthProvider interface {
 // @Param input body models.UserLogin true "User credentials for login"
 // @Success 200 {string} string "Successfully logged in. Authentication token is included in the 'Authorization' header"
 // @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
 // @Failure 500 {string} string "Internal server error"
 // @Router /login [post]
 func (h *Handler) loginUser(w http.ResponseWriter, r *http.Request) {
@@ -30,7 +31,7 @@ func (h *Handler) loginUser(w http.ResponseWriter, r *http.Request) {
 
 	log := h.log.With(slog.String("op", op))
 
-	user := &models.User{}
+	user := &models.UserLogin{}
 	err := json.NewDecoder(r.Body).Decode(user)
 	if err != nil {
 		log.Error("failed to decode request body", sl.Err(err))
@@ -42,9 +43,8 @@ func (h *Handler) loginUser(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "empty request", http.StatusBadRequest)
 		return
 	}
-	if user.Email == "" || user.Password == "" {
-		log.Error("email or password is empty", sl.Err(err))
-		http.Error(w, "email or password is empty", http.StatusBadRequest)
+
+	if !validRequest(w, log, user) {
 		return
 	}
 
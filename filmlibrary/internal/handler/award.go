@@ -0,0 +1,440 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name AwardProvider
+type AwardProvider interface {
+	AddAward(award *models.Award) error
+	DeleteAward(id int64) error
+	GetAwards() ([]*models.Award, error)
+	AddCeremony(ceremony *models.Ceremony) error
+	AddAwardCategory(category *models.AwardCategory) error
+	AddNomination(nomination *models.Nomination) error
+	DeleteNomination(id int64) error
+	GetNominations(of string, id int64, langs []string) ([]*models.NominationListing, error)
+}
+
+// @Summary Create award
+// @Security ApiKeyAuth
+// @Description Creates an award, such as the Academy Awards or the Cannes Film Festival.
+// @Tags Awards
+// @Accept json
+// @Produce json
+// @Param input body models.addAward true "Award to be created"
+// @Success 201 {object} models.Award
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /awards [post]
+func (h *Handler) addAward(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addAward"
+
+	log := h.log.With(slog.String("op", op))
+
+	award := &models.Award{}
+	err := json.NewDecoder(r.Body).Decode(award)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, award) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.awardProvider.AddAward(award)
+	if err != nil {
+		awardError(w, log, err, "failed to create an award")
+		return
+	}
+
+	awardJSON, err := json.Marshal(award)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(awardJSON)
+}
+
+// @Summary Delete award
+// @Security ApiKeyAuth
+// @Description Deletes an award with all of its ceremonies, categories and nominations.
+// @Tags Awards
+// @Accept json
+// @Produce json
+// @Param id path int true "Award ID"
+// @Success 200 {string} string "Successfully deleted an award"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /awards/{id} [delete]
+func (h *Handler) deleteAward(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteAward"
+
+	log := h.log.With(slog.String("op", op))
+
+	id, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid award ID", sl.Err(err))
+		http.Error(w, "invalid award ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.awardProvider.DeleteAward(id)
+	if err != nil {
+		awardError(w, log, err, "failed to delete an award")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted an award"))
+}
+
+// @Summary Get awards
+// @Description Lists every award by name with its ceremonies, latest first, and its categories.
+// @Tags Awards
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Award
+// @Failure 500 {string} string "Internal server error"
+// @Router /awards [get]
+func (h *Handler) getAwards(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getAwards"
+
+	log := h.log.With(slog.String("op", op))
+
+	awards, err := h.awardProvider.GetAwards()
+	if err != nil {
+		log.Error("failed to fetch awards", sl.Err(err))
+		http.Error(w, "failed to fetch awards", http.StatusInternalServerError)
+		return
+	}
+
+	awardsJSON, err := json.Marshal(awards)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(awardsJSON)
+}
+
+// @Summary Add ceremony
+// @Security ApiKeyAuth
+// @Description Adds the ceremony of an award held in a year.
+// @Tags Awards
+// @Accept json
+// @Produce json
+// @Param id path int true "Award ID"
+// @Param input body models.addCeremony true "Ceremony to be added"
+// @Success 201 {object} models.Ceremony
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /awards/{id}/ceremonies [post]
+func (h *Handler) addCeremony(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addCeremony"
+
+	log := h.log.With(slog.String("op", op))
+
+	ceremony := &models.Ceremony{}
+	err := json.NewDecoder(r.Body).Decode(ceremony)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &ceremony.AwardID)
+	if err != nil {
+		log.Error("invalid award ID", sl.Err(err))
+		http.Error(w, "invalid award ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, ceremony) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.awardProvider.AddCeremony(ceremony)
+	if err != nil {
+		awardError(w, log, err, "failed to add a ceremony")
+		return
+	}
+
+	ceremonyJSON, err := json.Marshal(ceremony)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(ceremonyJSON)
+}
+
+// @Summary Add award category
+// @Security ApiKeyAuth
+// @Description Adds a category, such as Best Picture, to an award.
+// @Tags Awards
+// @Accept json
+// @Produce json
+// @Param id path int true "Award ID"
+// @Param input body models.addAwardCategory true "Category to be added"
+// @Success 201 {object} models.AwardCategory
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /awards/{id}/categories [post]
+func (h *Handler) addAwardCategory(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addAwardCategory"
+
+	log := h.log.With(slog.String("op", op))
+
+	category := &models.AwardCategory{}
+	err := json.NewDecoder(r.Body).Decode(category)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &category.AwardID)
+	if err != nil {
+		log.Error("invalid award ID", sl.Err(err))
+		http.Error(w, "invalid award ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, category) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.awardProvider.AddAwardCategory(category)
+	if err != nil {
+		awardError(w, log, err, "failed to add a category")
+		return
+	}
+
+	categoryJSON, err := json.Marshal(category)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(categoryJSON)
+}
+
+// @Summary Add nomination
+// @Security ApiKeyAuth
+// @Description Nominates a movie, and optionally a person for their work on it, in a category of a ceremony. Nominating them again updates whether they won.
+// @Tags Awards
+// @Accept json
+// @Produce json
+// @Param input body models.addNomination true "Nomination to be added"
+// @Success 201 {object} models.Nomination
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /nominations [post]
+func (h *Handler) addNomination(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addNomination"
+
+	log := h.log.With(slog.String("op", op))
+
+	nomination := &models.Nomination{}
+	err := json.NewDecoder(r.Body).Decode(nomination)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, nomination) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.awardProvider.AddNomination(nomination)
+	if err != nil {
+		awardError(w, log, err, "failed to add a nomination")
+		return
+	}
+
+	nominationJSON, err := json.Marshal(nomination)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(nominationJSON)
+}
+
+// @Summary Delete nomination
+// @Security ApiKeyAuth
+// @Description Deletes a nomination by its ID.
+// @Tags Awards
+// @Accept json
+// @Produce json
+// @Param id path int true "Nomination ID"
+// @Success 200 {string} string "Successfully deleted a nomination"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /nominations/{id} [delete]
+func (h *Handler) deleteNomination(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteNomination"
+
+	log := h.log.With(slog.String("op", op))
+
+	id, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid nomination ID", sl.Err(err))
+		http.Error(w, "invalid nomination ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.awardProvider.DeleteNomination(id)
+	if err != nil {
+		awardError(w, log, err, "failed to delete a nomination")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a nomination"))
+}
+
+// @Summary Get nominations
+// @Description Lists the nominations of a movie, an actor or a ceremony, latest ceremony first and winners first.
+// @Tags Awards
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Preferred languages"
+// @Param movie_id query int false "Movie ID"
+// @Param actor_id query int false "Actor ID"
+// @Param ceremony_id query int false "Ceremony ID"
+// @Success 200 {array} models.NominationListing
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /nominations [get]
+func (h *Handler) getNominations(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getNominations"
+
+	log := h.log.With(slog.String("op", op))
+
+	query := r.URL.Query()
+	of, idParam := models.NominationsOfMovie, query.Get("movie_id")
+	if idParam == "" {
+		of, idParam = models.NominationsOfPerson, query.Get("actor_id")
+	}
+	if idParam == "" {
+		of, idParam = models.NominationsOfCeremony, query.Get("ceremony_id")
+	}
+
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		log.Error("invalid nominee ID", sl.Err(err))
+		http.Error(w, "movie_id, actor_id or ceremony_id is required", http.StatusBadRequest)
+		return
+	}
+
+	nominations, err := h.awardProvider.GetNominations(of, id, preferredLanguages(r.Header.Get("Accept-Language")))
+	if err != nil {
+		log.Error("failed to fetch nominations", sl.Err(err))
+		http.Error(w, "failed to fetch nominations", http.StatusInternalServerError)
+		return
+	}
+
+	nominationsJSON, err := json.Marshal(nominations)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	w.WriteHeader(http.StatusOK)
+	w.Write(nominationsJSON)
+}
+
+// awardError reports an error of managing awards and nominations.
+func awardError(w http.ResponseWriter, log *slog.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidAwardName):
+		log.Error("invalid award name", sl.Err(err))
+		http.Error(w, service.ErrInvalidAwardName.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidCategoryName):
+		log.Error("invalid category name", sl.Err(err))
+		http.Error(w, service.ErrInvalidCategoryName.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidCeremonyYear):
+		log.Error("invalid ceremony year", sl.Err(err))
+		http.Error(w, service.ErrInvalidCeremonyYear.Error(), http.StatusBadRequest)
+	case errors.Is(err, storage.ErrCategoryOfOtherAward):
+		log.Error("category of another award", sl.Err(err))
+		http.Error(w, storage.ErrCategoryOfOtherAward.Error(), http.StatusBadRequest)
+	case errors.Is(err, storage.ErrAwardExists):
+		log.Error("award exists", sl.Err(err))
+		http.Error(w, "award with this name already exists", http.StatusConflict)
+	case errors.Is(err, storage.ErrCeremonyExists):
+		log.Error("ceremony exists", sl.Err(err))
+		http.Error(w, "ceremony of this year already exists", http.StatusConflict)
+	case errors.Is(err, storage.ErrCategoryExists):
+		log.Error("category exists", sl.Err(err))
+		http.Error(w, "category with this name already exists", http.StatusConflict)
+	case errors.Is(err, storage.ErrAwardNotFound):
+		log.Error("award not found", sl.Err(err))
+		http.Error(w, "award not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrCeremonyNotFound):
+		log.Error("ceremony not found", sl.Err(err))
+		http.Error(w, "ceremony not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrCategoryNotFound):
+		log.Error("category not found", sl.Err(err))
+		http.Error(w, "category not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrNominationNotFound):
+		log.Error("nomination not found", sl.Err(err))
+		http.Error(w, "nomination not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrMovieNotFound):
+		log.Error("movie not found", sl.Err(err))
+		http.Error(w, "movie not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrPersonNotFound):
+		log.Error("person not found", sl.Err(err))
+		http.Error(w, "person not found", http.StatusNotFound)
+	default:
+		log.Error(message, sl.Err(err))
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_addNomination(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Nomination added",
+			body:        `{"ceremony_id":1,"category_id":2,"movie_id":3,"person_id":4,"won":true}`,
+			wantStatus:  http.StatusCreated,
+			wantMessage: `{"id":7,"ceremony_id":1,"category_id":2,"movie_id":3,"person_id":4,"won":true}`,
+		},
+		{
+			name:        "Category of another award",
+			body:        `{"ceremony_id":1,"category_id":9,"movie_id":3}`,
+			providerErr: fmt.Errorf("service.AddNomination: %w", storage.ErrCategoryOfOtherAward),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: storage.ErrCategoryOfOtherAward.Error(),
+		},
+		{
+			name:        "Ceremony not found",
+			body:        `{"ceremony_id":99,"category_id":2,"movie_id":3}`,
+			providerErr: fmt.Errorf("service.AddNomination: %w", storage.ErrCeremonyNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "ceremony not found",
+		},
+		{
+			name:        "Person not found",
+			body:        `{"ceremony_id":1,"category_id":2,"movie_id":3,"person_id":99}`,
+			providerErr: fmt.Errorf("service.AddNomination: %w", storage.ErrPersonNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "person not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			awardMock := &mocks.AwardProvider{}
+			awardMock.On("AddNomination", mock.AnythingOfType("*models.Nomination")).
+				Run(func(args mock.Arguments) {
+					args.Get(0).(*models.Nomination).ID = 7
+				}).
+				Return(tt.providerErr)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				awardProvider: awardMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.addNomination(w, httptest.NewRequest(http.MethodPost, "/award/add/nomination", bytes.NewBufferString(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_addCeremony_InvalidYear(t *testing.T) {
+	awardMock := &mocks.AwardProvider{}
+	awardMock.On("AddCeremony", &models.Ceremony{AwardID: 1, Year: 1850}).
+		Return(fmt.Errorf("service.AddCeremony: %w", service.ErrInvalidCeremonyYear))
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		awardProvider: awardMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.addCeremony(w, httptest.NewRequest(http.MethodPost, "/award/add/ceremony", bytes.NewBufferString(`{"award_id":1,"year":1850}`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, service.ErrInvalidCeremonyYear.Error(), strings.TrimSpace(w.Body.String()))
+}
+
+func TestHandler_getNominations(t *testing.T) {
+	nominations := []*models.NominationListing{{
+		ID:       7,
+		Award:    "Academy Awards",
+		Year:     1995,
+		Category: "Best Actor",
+		Movie:    &models.MovieRef{ID: 3, Title: "Forrest Gump"},
+		Person:   &models.ActorRef{ID: 4, Name: "Tom Hanks"},
+		Won:      true,
+	}}
+
+	tests := []struct {
+		name        string
+		target      string
+		of          string
+		id          int64
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Nominations of actor",
+			target:      "/get/nominations?actor_id=4",
+			of:          models.NominationsOfPerson,
+			id:          4,
+			wantStatus:  http.StatusOK,
+			wantMessage: `[{"id":7,"award":"Academy Awards","year":1995,"category":"Best Actor","movie":{"id":3,"title":"Forrest Gump"},"person":{"id":4,"name":"Tom Hanks"},"won":true}]`,
+		},
+		{
+			name:        "Nominations of ceremony",
+			target:      "/get/nominations?ceremony_id=1",
+			of:          models.NominationsOfCeremony,
+			id:          1,
+			wantStatus:  http.StatusOK,
+			wantMessage: `[{"id":7,"award":"Academy Awards","year":1995,"category":"Best Actor","movie":{"id":3,"title":"Forrest Gump"},"person":{"id":4,"name":"Tom Hanks"},"won":true}]`,
+		},
+		{
+			name:        "Nominee missing",
+			target:      "/get/nominations",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "movie_id, actor_id or ceremony_id is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			awardMock := &mocks.AwardProvider{}
+			awardMock.On("GetNominations", tt.of, tt.id, []string(nil)).Return(nominations, nil)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				awardProvider: awardMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.getNominations(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
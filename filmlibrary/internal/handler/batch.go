@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/lib/validate"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// maxBatchBodySize caps the body of a batch request, a batch of the largest accepted
+// number of items fits well within it.
+const maxBatchBodySize = 10 << 20
+
+// @Summary Batch movies
+// @Security ApiKeyAuth
+// @Description Creates, updates and deletes movies in one transaction. An atomic batch is applied as a whole or not at all, a partial batch applies every item that succeeds. Updates and deletes carry the version the client has seen instead of If-Match.
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param mode query string false "atomic or partial" default(atomic)
+// @Param input body movieBatchRequest true "Operations applied in order"
+// @Success 200 {object} batchResponse "Every item succeeded"
+// @Success 207 {object} batchResponse "Some items failed"
+// @Failure 400 {string} string "Bad request"
+// @Failure 413 {string} string "Batch has too many items or its body is too large"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields of an atomic batch"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/batch [post]
+func (h *Handler) batchMovies(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.batchMovies"
+
+	log := h.log.With(slog.String("op", op))
+
+	atomic, err := batchMode(r)
+	if err != nil {
+		log.Error("invalid batch mode", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &movieBatchRequest{}
+	if !decodeBatch(w, r, log, req) {
+		return
+	}
+
+	results, items, positions, ok := batchItems(w, log, req.Items, atomic)
+	if !ok {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	// the size counts every item, the invalid ones of a partial batch included
+	stored, err := h.movieProvider.BatchMovies(items, len(req.Items), atomic)
+	if err != nil {
+		batchError(w, log, err)
+		return
+	}
+	fillBatchResults(results, positions, stored, "movie", movieErrorStatus)
+
+	writeBatchResults(w, log, results)
+}
+
+// @Summary Batch actors
+// @Security ApiKeyAuth
+// @Description Creates, updates and deletes actors in one transaction. An atomic batch is applied as a whole or not at all, a partial batch applies every item that succeeds. Updates and deletes carry the version the client has seen instead of If-Match.
+// @Tags Actors
+// @Accept json
+// @Produce json
+// @Param mode query string false "atomic or partial" default(atomic)
+// @Param input body actorBatchRequest true "Operations applied in order"
+// @Success 200 {object} batchResponse "Every item succeeded"
+// @Success 207 {object} batchResponse "Some items failed"
+// @Failure 400 {string} string "Bad request"
+// @Failure 413 {string} string "Batch has too many items or its body is too large"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields of an atomic batch"
+// @Failure 500 {string} string "Internal server error"
+// @Router /actors/batch [post]
+func (h *Handler) batchActors(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.batchActors"
+
+	log := h.log.With(slog.String("op", op))
+
+	atomic, err := batchMode(r)
+	if err != nil {
+		log.Error("invalid batch mode", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &actorBatchRequest{}
+	if !decodeBatch(w, r, log, req) {
+		return
+	}
+
+	results, items, positions, ok := batchItems(w, log, req.Items, atomic)
+	if !ok {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	// the size counts every item, the invalid ones of a partial batch included
+	stored, err := h.actorProvider.BatchActors(items, len(req.Items), atomic)
+	if err != nil {
+		batchError(w, log, err)
+		return
+	}
+	fillBatchResults(results, positions, stored, "actor", actorErrorStatus)
+
+	writeBatchResults(w, log, results)
+}
+
+// batchMode reads whether a batch is applied atomically, as it is unless the mode
+// query parameter is partial.
+func batchMode(r *http.Request) (bool, error) {
+	switch r.URL.Query().Get("mode") {
+	case "", "atomic":
+		return true, nil
+	case "partial":
+		return false, nil
+	}
+	return false, errors.New("mode must be atomic or partial")
+}
+
+// decodeBatch decodes a batch request of at most maxBatchBodySize bytes, a larger one
+// is answered with 413 Request Entity Too Large before it is read in full.
+func decodeBatch(w http.ResponseWriter, r *http.Request, log *slog.Logger, req interface{}) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodySize)).Decode(req)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Error("batch request is too large", sl.Err(err))
+			http.Error(w, "request body is too large", http.StatusRequestEntityTooLarge)
+			return false
+		}
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+type batchItemRequest[T any] interface {
+	operation() string
+	validate() error
+	item() T
+}
+
+// batchItems validates the items of a batch and returns the valid ones with their
+// positions in the request. The invalid items of a partial batch fail with 422 in
+// their results, an atomic batch with an invalid item is answered with 422 as a whole
+// and ok is false.
+func batchItems[R batchItemRequest[T], T any](w http.ResponseWriter, log *slog.Logger, requests []R, atomic bool) (results []*batchItemResponse, items []T, positions []int, ok bool) {
+	var errs validate.Errors
+	results = make([]*batchItemResponse, len(requests))
+	for i, req := range requests {
+		results[i] = &batchItemResponse{Index: i, Op: req.operation()}
+
+		err := req.validate()
+		if err == nil {
+			items = append(items, req.item())
+			positions = append(positions, i)
+			continue
+		}
+
+		results[i].Status = http.StatusUnprocessableEntity
+		results[i].Error = err.Error()
+		for _, fieldErr := range err.(validate.Errors) {
+			errs = append(errs, validate.FieldError{Field: fmt.Sprintf("items[%d].%s", i, fieldErr.Field), Message: fieldErr.Message})
+		}
+	}
+
+	if atomic && len(errs) > 0 {
+		invalidRequest(w, log, errs)
+		return nil, nil, nil, false
+	}
+
+	return results, items, positions, true
+}
+
+// fillBatchResults sets the results of the stored items. An item that failed on its
+// own is reported with the message and status errorStatus gives its error.
+func fillBatchResults(results []*batchItemResponse, positions []int, stored []*models.BatchResult, object string, errorStatus func(err error, message string, status int) (string, int)) {
+	for j, i := range positions {
+		result := results[i]
+		result.ID = stored[j].ID
+
+		switch err := stored[j].Err; {
+		case err == nil && result.Op == models.BatchCreate:
+			result.Status, result.Version = http.StatusCreated, stored[j].Version
+		case err == nil:
+			result.Status, result.Version = http.StatusOK, stored[j].Version
+		case errors.Is(err, storage.ErrBatchRolledBack):
+			result.Status, result.Error = http.StatusFailedDependency, storage.ErrBatchRolledBack.Error()
+		default:
+			result.Error, result.Status = errorStatus(err, fmt.Sprintf("failed to %s %s", result.Op, object), http.StatusBadRequest)
+		}
+	}
+}
+
+// writeBatchResults answers 200 when every item of the batch succeeded and 207
+// Multi-Status otherwise.
+func writeBatchResults(w http.ResponseWriter, log *slog.Logger, results []*batchItemResponse) {
+	status := http.StatusOK
+	for _, result := range results {
+		if result.Status >= http.StatusMultipleChoices {
+			status = http.StatusMultiStatus
+			break
+		}
+	}
+
+	body, err := json.Marshal(batchResponse{Results: results})
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func batchError(w http.ResponseWriter, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmptyBatch):
+		log.Error("empty batch", sl.Err(err))
+		http.Error(w, service.ErrEmptyBatch.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrBatchTooLarge):
+		log.Error("batch too large", sl.Err(err))
+		http.Error(w, service.ErrBatchTooLarge.Error(), http.StatusRequestEntityTooLarge)
+	default:
+		log.Error("failed to apply the batch", sl.Err(err))
+		http.Error(w, "failed to apply the batch", http.StatusInternalServerError)
+	}
+}
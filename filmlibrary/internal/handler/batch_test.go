@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_batchMovies(t *testing.T) {
+	const (
+		create  = `{"op":"create","movie":{"title":"Heat","release_date":"1995-12-15T00:00:00Z"}}`
+		update  = `{"op":"update","id":2,"version":3,"patch":{"runtime":170}}`
+		invalid = `{"op":"create","movie":{"release_date":"1995-12-15T00:00:00Z"}}`
+	)
+
+	tests := []struct {
+		name        string
+		target      string
+		body        string
+		wantItems   int
+		atomic      bool
+		stored      []*models.BatchResult
+		wantSize    int
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Atomic success",
+			target:      "/movies/batch",
+			body:        `{"items":[` + create + `,` + update + `]}`,
+			wantItems:   2,
+			wantSize:    2,
+			atomic:      true,
+			stored:      []*models.BatchResult{{ID: 1, Version: 1}, {ID: 2, Version: 4}},
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"results":[{"index":0,"op":"create","status":201,"id":1,"version":1},{"index":1,"op":"update","status":200,"id":2,"version":4}]}`,
+		},
+		{
+			name:        "Atomic with invalid item",
+			target:      "/movies/batch",
+			body:        `{"items":[` + create + `,` + invalid + `]}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"items[1].movie.title","message":"is required"}]}`,
+		},
+		{
+			name:        "Update without version",
+			target:      "/movies/batch",
+			body:        `{"items":[{"op":"update","id":2,"patch":{}},{"op":"remove"}]}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"items[0].version","message":"is required"},{"field":"items[1].op","message":"must be one of create, update, delete"}]}`,
+		},
+		{
+			name:        "Delete of a negative version",
+			target:      "/movies/batch",
+			body:        `{"items":[{"op":"delete","id":2,"version":-1}]}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"items[0].version","message":"must be at least 1"}]}`,
+		},
+		{
+			name:        "Partial with invalid item",
+			target:      "/movies/batch?mode=partial",
+			body:        `{"items":[` + invalid + `,` + update + `]}`,
+			wantItems:   1,
+			wantSize:    2,
+			stored:      []*models.BatchResult{{ID: 2, Version: 4}},
+			wantStatus:  http.StatusMultiStatus,
+			wantMessage: `{"results":[{"index":0,"op":"create","status":422,"error":"movie.title is required"},{"index":1,"op":"update","status":200,"id":2,"version":4}]}`,
+		},
+		{
+			name:        "Rolled back",
+			target:      "/movies/batch?mode=atomic",
+			body:        `{"items":[` + create + `,` + update + `]}`,
+			wantItems:   2,
+			wantSize:    2,
+			atomic:      true,
+			stored:      []*models.BatchResult{{Err: storage.ErrBatchRolledBack}, {ID: 2, Err: storage.ErrVersionMismatch}},
+			wantStatus:  http.StatusMultiStatus,
+			wantMessage: `{"results":[{"index":0,"op":"create","status":424,"error":"rolled back with the rest of the batch"},{"index":1,"op":"update","status":412,"id":2,"error":"version has changed"}]}`,
+		},
+		{
+			name:        "Too large",
+			target:      "/movies/batch",
+			body:        `{"items":[` + create + `,` + update + `]}`,
+			wantItems:   2,
+			wantSize:    2,
+			atomic:      true,
+			providerErr: fmt.Errorf("service.BatchMovies: %w", service.ErrBatchTooLarge),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantMessage: service.ErrBatchTooLarge.Error(),
+		},
+		{
+			name:        "Too large with invalid items",
+			target:      "/movies/batch?mode=partial",
+			body:        `{"items":[` + invalid + `,` + invalid + `,` + create + `]}`,
+			wantItems:   1,
+			wantSize:    3,
+			providerErr: fmt.Errorf("service.BatchMovies: %w", service.ErrBatchTooLarge),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantMessage: service.ErrBatchTooLarge.Error(),
+		},
+		{
+			name:        "Body too large",
+			target:      "/movies/batch",
+			body:        `{"items":[` + strings.Repeat(" ", maxBatchBodySize) + create + `]}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantMessage: "request body is too large",
+		},
+		{
+			name:        "Empty",
+			target:      "/movies/batch",
+			body:        `{"items":[]}`,
+			atomic:      true,
+			providerErr: fmt.Errorf("service.BatchMovies: %w", service.ErrEmptyBatch),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrEmptyBatch.Error(),
+		},
+		{
+			name:        "Invalid mode",
+			target:      "/movies/batch?mode=all",
+			body:        `{"items":[]}`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "mode must be atomic or partial",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieMock := &mocks.MovieProvider{}
+			movieMock.On("BatchMovies", mock.MatchedBy(func(items []*models.MovieBatchItem) bool {
+				return len(items) == tt.wantItems
+			}), tt.wantSize, tt.atomic).Return(tt.stored, tt.providerErr)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				movieProvider: movieMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.batchMovies(w, httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_batchActors(t *testing.T) {
+	actorMock := &mocks.ActorProvider{}
+	actorMock.On("BatchActors", mock.MatchedBy(func(items []*models.ActorBatchItem) bool {
+		return len(items) == 2 && items[0].Actor.Name == "Al Pacino" && items[1].ID == 3 && items[1].Version == 2
+	}), 2, false).Return([]*models.BatchResult{{ID: 9, Version: 1}, {ID: 3, Err: storage.ErrPersonNotFound}}, nil)
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		actorProvider: actorMock,
+	}
+
+	body := `{"items":[{"op":"create","actor":{"name":"Al Pacino","sex":"male","birthday":"1940-04-25T00:00:00Z"}},{"op":"delete","id":3,"version":2}]}`
+	w := httptest.NewRecorder()
+	h.batchActors(w, httptest.NewRequest(http.MethodPost, "/actors/batch?mode=partial", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Equal(t, `{"results":[{"index":0,"op":"create","status":201,"id":9,"version":1},{"index":1,"op":"delete","status":404,"id":3,"error":"actor not found"}]}`, strings.TrimSpace(w.Body.String()))
+	actorMock.AssertExpectations(t)
+}
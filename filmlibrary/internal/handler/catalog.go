@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// catalogMediaTypes are the formats of catalog dumps by their media types.
+var catalogMediaTypes = map[string]string{
+	"text/csv":         service.CatalogCSV,
+	"application/json": service.CatalogJSON,
+}
+
+// exportMediaTypes are the media types of catalog exports by their formats.
+var exportMediaTypes = map[string]string{
+	service.CatalogCSV:    "text/csv; charset=utf-8",
+	service.CatalogJSON:   "application/json",
+	service.CatalogNDJSON: "application/x-ndjson",
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name CatalogProvider
+type CatalogProvider interface {
+	ImportCatalog(r io.Reader, format string, dryRun bool) (*models.ImportReport, error)
+	ExportCatalog(w io.Writer, kind string, format string, sortBy string, sortDirection string, filter models.MovieFilter, langs []string) (int, error)
+}
+
+// @Summary Import catalog
+// @Security ApiKeyAuth
+// @Description Imports a catalog dump sent as the request body, a CSV file with a header or a JSON array of records. Every record is a movie or an actor, matched to a stored one by its IMDb or Kinopoisk ID or else by its title and release year, or name and year of birth. Matched records update the fields they give, others are created, and movies are linked to their cast listed by the IMDb IDs of the actors, separated by semicolons in CSV. Dates are written as 2006-01-02. Records that fail are reported by row and the rest are imported. A dry run reports the changes without making them.
+// @Tags Catalog
+// @Accept text/csv
+// @Accept json
+// @Produce json
+// @Param format query string false "csv or json, taken from Content-Type when left out"
+// @Param dry_run query bool false "Report the changes without making them"
+// @Param input body []models.ImportRecord true "Catalog dump"
+// @Success 200 {object} models.ImportReport "Import report"
+// @Failure 400 {string} string "Bad request"
+// @Failure 415 {string} string "Unsupported catalog format"
+// @Failure 500 {string} string "Internal server error"
+// @Router /catalog/import [post]
+func (h *Handler) importCatalog(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.importCatalog"
+
+	log := h.log.With(slog.String("op", op))
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		format = catalogMediaTypes[mediaType]
+	}
+
+	var dryRun bool
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Error("invalid dry_run", sl.Err(err))
+			http.Error(w, "invalid dry_run", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := h.catalogProvider.ImportCatalog(r.Body, format, dryRun)
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedCatalogFormat) {
+			log.Error("unsupported catalog format", sl.Err(err))
+			http.Error(w, service.ErrUnsupportedCatalogFormat.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
+		log.Error("failed to import the catalog", sl.Err(err))
+		http.Error(w, "failed to import the catalog", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("catalog imported", slog.Int("rows", report.Rows), slog.Int("failed", report.Failed), slog.Bool("dry_run", dryRun))
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(reportJSON)
+}
+
+// @Summary Export catalog
+// @Security ApiKeyAuth
+// @Description Exports the movies with their cast, or the actors with the movies they played in, as CSV with a header, a JSON array or NDJSON, one record per line. Movies are narrowed down and sorted like in the movie listing and actors are ordered by ID, and both are translated like in the listings. Lists are separated by semicolons in CSV and dates are written as 2006-01-02. Records are streamed as they are read, so an export failing midway is cut off.
+// @Tags Catalog
+// @Produce text/csv
+// @Produce json
+// @Produce application/x-ndjson
+// @Param Accept-Language header string false "Preferred languages"
+// @Param type query string true "movie or actor"
+// @Param format query string true "csv, json or ndjson"
+// @Param sortBy query string false "Sort movies by rating, title, release_date or gross"
+// @Param sortDir query string false "ASC or DESC"
+// @Param genre query string false "Keep movies of the genre"
+// @Param country query string false "Keep movies released in the country"
+// @Param release_type query string false "Keep movies with a release of the type"
+// @Param released_before query string false "Keep movies released before the date"
+// @Param released_after query string false "Keep movies released after the date"
+// @Param suitable_for query string false "Keep movies certified for viewers of the age"
+// @Param won_award query string false "Keep movies that won the award"
+// @Param min_runtime query int false "Minimum runtime in minutes"
+// @Param max_runtime query int false "Maximum runtime in minutes"
+// @Param min_gross query int false "Minimum gross in currency"
+// @Param max_gross query int false "Maximum gross in currency"
+// @Param currency query string false "Currency of the gross filters, USD by default"
+// @Success 200 {array} models.MovieListing "Movies, or actors as in the actor listing"
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /catalog/export [get]
+func (h *Handler) exportCatalog(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.exportCatalog"
+
+	log := h.log.With(slog.String("op", op))
+
+	kind := r.URL.Query().Get("type")
+	format := r.URL.Query().Get("format")
+	filter, err := parseMovieFilter(r)
+	if err != nil {
+		log.Error("invalid movie filter", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if mediaType, ok := exportMediaTypes[format]; ok {
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Content-Disposition", `attachment; filename="catalog.`+format+`"`)
+	}
+	w.Header().Set("Vary", "Accept-Language")
+
+	n, err := h.catalogProvider.ExportCatalog(w, kind, format, r.URL.Query().Get("sortBy"), r.URL.Query().Get("sortDir"), filter, preferredLanguages(r.Header.Get("Accept-Language")))
+	if err != nil && n > 0 {
+		// the status is sent with the first record, abort the response so that
+		// the client does not take the records sent so far for the whole catalog
+		log.Error("failed to export the catalog", slog.Int("records", n), sl.Err(err))
+		panic(http.ErrAbortHandler)
+	}
+	if err != nil {
+		w.Header().Del("Content-Disposition")
+		for _, invalid := range []error{
+			service.ErrUnsupportedExportType,
+			service.ErrUnsupportedExportFormat,
+			service.ErrInvalidCountry,
+			service.ErrInvalidReleaseType,
+			service.ErrInvalidFactsRange,
+			service.ErrInvalidMoney,
+		} {
+			if errors.Is(err, invalid) {
+				log.Error("invalid export", sl.Err(err))
+				http.Error(w, invalid.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		log.Error("failed to export the catalog", sl.Err(err))
+		http.Error(w, "failed to export the catalog", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("catalog exported", slog.String("type", kind), slog.String("format", format), slog.Int("records", n))
+}
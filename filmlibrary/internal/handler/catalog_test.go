@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_importCatalog(t *testing.T) {
+	report := &models.ImportReport{
+		DryRun:  true,
+		Rows:    2,
+		Created: 1,
+		Failed:  1,
+		Changes: []*models.ImportChange{{Row: 2, Type: models.CatalogMovie, Action: models.ImportCreate, Name: "Heat"}},
+		Errors:  []*models.ImportError{{Row: 3, Message: "birthday is required"}},
+	}
+
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		format      string
+		dryRun      bool
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "CSV dry run",
+			target:      "/catalog/import?dry_run=true",
+			contentType: "text/csv; charset=utf-8",
+			format:      service.CatalogCSV,
+			dryRun:      true,
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"dry_run":true,"rows":2,"created":1,"updated":0,"unchanged":0,"failed":1,"changes":[{"row":2,"type":"movie","action":"create","name":"Heat"}],"errors":[{"row":3,"message":"birthday is required"}]}`,
+		},
+		{
+			name:        "Format parameter",
+			target:      "/catalog/import?format=json",
+			contentType: "application/octet-stream",
+			format:      service.CatalogJSON,
+			providerErr: fmt.Errorf("service.ImportCatalog: %w", service.ErrUnsupportedCatalogFormat),
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantMessage: service.ErrUnsupportedCatalogFormat.Error(),
+		},
+		{
+			name:        "Invalid dry run",
+			target:      "/catalog/import?dry_run=maybe",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "invalid dry_run",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalogMock := &mocks.CatalogProvider{}
+			catalogMock.On("ImportCatalog", mock.Anything, tt.format, tt.dryRun).Return(report, tt.providerErr)
+
+			h := &Handler{
+				log:             slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				catalogProvider: catalogMock,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader("type,title\n"))
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			h.importCatalog(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_exportCatalog(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          string
+		kind            string
+		format          string
+		filter          models.MovieFilter
+		written         int
+		providerErr     error
+		wantStatus      int
+		wantContentType string
+		wantMessage     string
+	}{
+		{
+			name:            "Movies CSV",
+			target:          "/catalog/export?type=movie&format=csv&genre=drama&sortBy=title",
+			kind:            models.CatalogMovie,
+			format:          service.CatalogCSV,
+			filter:          models.MovieFilter{Genre: "drama"},
+			written:         1,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantMessage:     "id,title\n1,Heat",
+		},
+		{
+			name:            "Actors NDJSON",
+			target:          "/catalog/export?type=actor&format=ndjson",
+			kind:            models.CatalogActor,
+			format:          service.CatalogNDJSON,
+			written:         1,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantMessage:     "id,title\n1,Heat",
+		},
+		{
+			name:            "Unsupported format",
+			target:          "/catalog/export?type=movie&format=xml",
+			kind:            models.CatalogMovie,
+			format:          "xml",
+			providerErr:     fmt.Errorf("service.ExportCatalog: %w", service.ErrUnsupportedExportFormat),
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "text/plain; charset=utf-8",
+			wantMessage:     service.ErrUnsupportedExportFormat.Error(),
+		},
+		{
+			name:            "Invalid range",
+			target:          "/catalog/export?type=movie&format=json&min_runtime=120&max_runtime=90",
+			kind:            models.CatalogMovie,
+			format:          service.CatalogJSON,
+			filter:          models.MovieFilter{MinRuntime: 120, MaxRuntime: 90},
+			providerErr:     fmt.Errorf("service.ExportCatalog: %w", service.ErrInvalidFactsRange),
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "text/plain; charset=utf-8",
+			wantMessage:     service.ErrInvalidFactsRange.Error(),
+		},
+		{
+			name:            "Storage error",
+			target:          "/catalog/export?type=movie&format=json",
+			kind:            models.CatalogMovie,
+			format:          service.CatalogJSON,
+			providerErr:     errors.New("connection refused"),
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: "text/plain; charset=utf-8",
+			wantMessage:     "failed to export the catalog",
+		},
+		{
+			name:        "Invalid filter",
+			target:      "/catalog/export?type=movie&format=csv&min_runtime=long",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: `invalid min_runtime "long"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalogMock := &mocks.CatalogProvider{}
+			catalogMock.On("ExportCatalog", mock.Anything, tt.kind, tt.format, mock.Anything, mock.Anything, tt.filter, mock.Anything).
+				Run(func(args mock.Arguments) {
+					if tt.written > 0 {
+						io.WriteString(args.Get(0).(io.Writer), "id,title\n1,Heat\n")
+					}
+				}).
+				Return(tt.written, tt.providerErr)
+
+			h := &Handler{
+				log:             slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				catalogProvider: catalogMock,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+			h.exportCatalog(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestHandler_exportCatalog_FailsMidway(t *testing.T) {
+	catalogMock := &mocks.CatalogProvider{}
+	catalogMock.On("ExportCatalog", mock.Anything, models.CatalogMovie, service.CatalogNDJSON, "", "", models.MovieFilter{}, []string(nil)).
+		Return(1, errors.New("connection reset"))
+
+	h := &Handler{
+		log:             slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		catalogProvider: catalogMock,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/catalog/export?type=movie&format=ndjson", nil)
+	w := httptest.NewRecorder()
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { h.exportCatalog(w, r) })
+}
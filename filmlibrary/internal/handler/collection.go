@@ -0,0 +1,533 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name CollectionProvider
+type CollectionProvider interface {
+	AddCollection(collection *models.Collection) error
+	EditCollection(collection *models.Collection) error
+	DeleteCollection(ownerID, id int64) error
+	AddMoviesToCollection(ownerID, collectionID int64, movieIDs []int64) error
+	DeleteMovieFromCollection(ownerID, collectionID, movieID int64) error
+	ReorderCollection(ownerID, collectionID int64, movieIDs []int64) error
+	GetPublicCollections(page, limit int) (*models.CollectionsPage, error)
+	GetUserCollections(ownerID int64) ([]*models.Collection, error)
+	GetCollection(slug string, viewerID int64) (*models.Collection, error)
+}
+
+// @Summary Create collection
+// @Security ApiKeyAuth
+// @Description Creates a collection owned by the current user. The slug is made from the title unless one is given.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param input body models.addCollection true "Collection to be created"
+// @Success 201 {object} models.Collection
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /collections [post]
+func (h *Handler) addCollection(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addCollection"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	collection := &models.Collection{}
+	err := json.NewDecoder(r.Body).Decode(collection)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, collection) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	collection.OwnerID = userID
+
+	err = h.collectionProvider.AddCollection(collection)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCollectionTitle):
+			log.Error("invalid collection title", sl.Err(err))
+			http.Error(w, service.ErrInvalidCollectionTitle.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrInvalidSlug):
+			log.Error("invalid slug", sl.Err(err))
+			http.Error(w, service.ErrInvalidSlug.Error(), http.StatusBadRequest)
+		case errors.Is(err, storage.ErrCollectionExists):
+			log.Error("collection exists", sl.Err(err))
+			http.Error(w, "collection with this slug already exists", http.StatusConflict)
+		default:
+			log.Error("failed to create a collection", sl.Err(err))
+			http.Error(w, "failed to create a collection", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	collectionJSON, err := json.Marshal(collection)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(collectionJSON)
+}
+
+// @Summary Edit collection
+// @Security ApiKeyAuth
+// @Description Changes the title, description and visibility of the current user's collection. The slug does not change.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param input body models.editCollection true "Collection to be edited"
+// @Success 200 {string} string "Successfully edited a collection"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /collections/{id} [patch]
+func (h *Handler) editCollection(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.editCollection"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	collection := &models.Collection{}
+	err := json.NewDecoder(r.Body).Decode(collection)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &collection.ID)
+	if err != nil {
+		log.Error("invalid collection ID", sl.Err(err))
+		http.Error(w, "invalid collection ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, collection) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	collection.OwnerID = userID
+
+	err = h.collectionProvider.EditCollection(collection)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCollectionTitle):
+			log.Error("invalid collection title", sl.Err(err))
+			http.Error(w, service.ErrInvalidCollectionTitle.Error(), http.StatusBadRequest)
+		case errors.Is(err, storage.ErrCollectionNotFound):
+			log.Error("collection not found", sl.Err(err))
+			http.Error(w, "collection not found", http.StatusNotFound)
+		default:
+			log.Error("failed to edit a collection", sl.Err(err))
+			http.Error(w, "failed to edit a collection", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully edited a collection"))
+}
+
+// @Summary Delete collection
+// @Security ApiKeyAuth
+// @Description Deletes the current user's collection.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID to be deleted"
+// @Success 200 {string} string "Successfully deleted a collection"
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /collections/{id} [delete]
+func (h *Handler) deleteCollection(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteCollection"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid collection ID", sl.Err(err))
+		http.Error(w, "invalid collection ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.collectionProvider.DeleteCollection(userID, collectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionNotFound) {
+			log.Error("collection not found", sl.Err(err))
+			http.Error(w, "collection not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to delete a collection", sl.Err(err))
+		http.Error(w, "failed to delete a collection", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a collection"))
+}
+
+// @Summary Add movies to collection
+// @Security ApiKeyAuth
+// @Description Appends movies to the end of the current user's collection in the given order. Movies already in the collection are skipped.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param input body models.CollectionMovies true "Collection ID and movie IDs"
+// @Success 200 {string} string "Successfully added movies to a collection"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /collections/{id}/movies [post]
+func (h *Handler) addMoviesToCollection(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addMoviesToCollection"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	movies := &models.CollectionMovies{}
+	err := json.NewDecoder(r.Body).Decode(movies)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &movies.CollectionID)
+	if err != nil {
+		log.Error("invalid collection ID", sl.Err(err))
+		http.Error(w, "invalid collection ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, movies) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.collectionProvider.AddMoviesToCollection(userID, movies.CollectionID, movies.MoviesID)
+	if err != nil {
+		collectionItemsError(w, log, err, "failed to add movies to a collection")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully added movies to a collection"))
+}
+
+// @Summary Reorder collection
+// @Security ApiKeyAuth
+// @Description Moves the listed movies to the top of the current user's collection in the given order. Movies that are not listed keep their order after them.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param input body models.CollectionMovies true "Collection ID and movie IDs in the new order"
+// @Success 200 {string} string "Successfully reordered a collection"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /collections/{id}/movies [put]
+func (h *Handler) reorderCollection(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.reorderCollection"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	movies := &models.CollectionMovies{}
+	err := json.NewDecoder(r.Body).Decode(movies)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &movies.CollectionID)
+	if err != nil {
+		log.Error("invalid collection ID", sl.Err(err))
+		http.Error(w, "invalid collection ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, movies) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.collectionProvider.ReorderCollection(userID, movies.CollectionID, movies.MoviesID)
+	if err != nil {
+		collectionItemsError(w, log, err, "failed to reorder a collection")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully reordered a collection"))
+}
+
+// @Summary Remove movie from collection
+// @Security ApiKeyAuth
+// @Description Removes a movie from the current user's collection.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param movie_id path int true "Movie ID to be removed"
+// @Success 200 {string} string "Successfully removed a movie from a collection"
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /collections/{id}/movies/{movie_id} [delete]
+func (h *Handler) deleteMovieFromCollection(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteMovieFromCollection"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid collection ID", sl.Err(err))
+		http.Error(w, "invalid collection ID", http.StatusBadRequest)
+		return
+	}
+
+	movieID, err := strconv.ParseInt(requestParam(r, "movie_id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.collectionProvider.DeleteMovieFromCollection(userID, collectionID, movieID)
+	if err != nil {
+		collectionItemsError(w, log, err, "failed to remove a movie from a collection")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully removed a movie from a collection"))
+}
+
+// @Summary Get public collections
+// @Description Retrieves public collections, most recently updated first, without their items.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number, starting from 1"
+// @Param limit query int false "Collections per page, up to 100"
+// @Success 200 {object} models.CollectionsPage
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /collections [get]
+func (h *Handler) getPublicCollections(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getPublicCollections"
+
+	log := h.log.With(slog.String("op", op))
+
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		log.Error("invalid pagination", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	collections, err := h.collectionProvider.GetPublicCollections(page, limit)
+	if err != nil {
+		log.Error("failed to fetch collections", sl.Err(err))
+		http.Error(w, "failed to fetch collections", http.StatusInternalServerError)
+		return
+	}
+
+	collectionsJSON, err := json.Marshal(collections)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(collectionsJSON)
+}
+
+// @Summary Get own collections
+// @Security ApiKeyAuth
+// @Description Retrieves the current user's public and private collections without their items.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Collection
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/collections [get]
+func (h *Handler) getUserCollections(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getUserCollections"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	collections, err := h.collectionProvider.GetUserCollections(userID)
+	if err != nil {
+		log.Error("failed to fetch collections", sl.Err(err))
+		http.Error(w, "failed to fetch collections", http.StatusInternalServerError)
+		return
+	}
+
+	collectionsJSON, err := json.Marshal(collections)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(collectionsJSON)
+}
+
+// @Summary Get collection
+// @Description Retrieves a collection with its movies in order. Private collections are only shown to their owner.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param slug path string true "Collection slug"
+// @Success 200 {object} models.Collection
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /collections/{slug} [get]
+func (h *Handler) getCollection(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getCollection"
+
+	log := h.log.With(slog.String("op", op))
+
+	slug := requestParam(r, "slug")
+	if slug == "" {
+		log.Error("slug is empty")
+		http.Error(w, "slug is empty", http.StatusBadRequest)
+		return
+	}
+
+	// anonymous users get 0, which matches no owner
+	userID, _ := userIDFromContext(r.Context())
+
+	collection, err := h.collectionProvider.GetCollection(slug, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionNotFound) {
+			log.Error("collection not found", sl.Err(err))
+			http.Error(w, "collection not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to fetch a collection", sl.Err(err))
+		http.Error(w, "failed to fetch a collection", http.StatusInternalServerError)
+		return
+	}
+
+	collectionJSON, err := json.Marshal(collection)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(collectionJSON)
+}
+
+// collectionItemsError reports a failed change to the items of a collection.
+func collectionItemsError(w http.ResponseWriter, log *slog.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCollectionMovies):
+		log.Error("invalid collection movies", sl.Err(err))
+		http.Error(w, service.ErrInvalidCollectionMovies.Error(), http.StatusBadRequest)
+	case errors.Is(err, storage.ErrCollectionNotFound):
+		log.Error("collection not found", sl.Err(err))
+		http.Error(w, "collection not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrCollectionItemNotFound):
+		log.Error("movie is not in the collection", sl.Err(err))
+		http.Error(w, "movie is not in the collection", http.StatusNotFound)
+	case errors.Is(err, storage.ErrMovieNotFound):
+		log.Error("movie not found", sl.Err(err))
+		http.Error(w, "movie not found", http.StatusNotFound)
+	default:
+		log.Error(message, sl.Err(err))
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_addCollection(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Create collection success",
+			body:        `{"title":"Best of 1994","slug":"best-of-1994","public":true}`,
+			wantStatus:  http.StatusCreated,
+			wantMessage: `{"id":0,"owner_id":7,"title":"Best of 1994","slug":"best-of-1994","public":true,"items_count":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:        "Invalid slug",
+			body:        `{"title":"Best of 1994","slug":"Best of 1994"}`,
+			providerErr: fmt.Errorf("service.AddCollection: %w", service.ErrInvalidSlug),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrInvalidSlug.Error(),
+		},
+		{
+			name:        "Slug taken",
+			body:        `{"title":"Best of 1994"}`,
+			providerErr: fmt.Errorf("service.AddCollection: %w", storage.ErrCollectionExists),
+			wantStatus:  http.StatusConflict,
+			wantMessage: "collection with this slug already exists",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := &mocks.CollectionProvider{}
+			collectionMock.On("AddCollection", mock.MatchedBy(func(collection *models.Collection) bool {
+				return collection.OwnerID == 7
+			})).Return(tt.providerErr)
+
+			h := &Handler{
+				log:                slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				collectionProvider: collectionMock,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/create/collection", bytes.NewBufferString(tt.body))
+			r = r.WithContext(context.WithValue(r.Context(), userIDKey, int64(7)))
+			w := httptest.NewRecorder()
+			h.addCollection(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_addMoviesToCollection(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Add movies success",
+			body:        `{"collection_id":1,"movies_id":[3,1]}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully added movies to a collection",
+		},
+		{
+			name:        "Not the owner",
+			body:        `{"collection_id":1,"movies_id":[3,1]}`,
+			providerErr: fmt.Errorf("service.AddMoviesToCollection: %w", storage.ErrCollectionNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "collection not found",
+		},
+		{
+			name:        "Unknown movie",
+			body:        `{"collection_id":1,"movies_id":[3,1]}`,
+			providerErr: fmt.Errorf("service.AddMoviesToCollection: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+		{
+			name:        "Duplicate movies",
+			body:        `{"collection_id":1,"movies_id":[3,1]}`,
+			providerErr: fmt.Errorf("service.AddMoviesToCollection: %w", service.ErrInvalidCollectionMovies),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrInvalidCollectionMovies.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := &mocks.CollectionProvider{}
+			collectionMock.On("AddMoviesToCollection", int64(7), int64(1), []int64{3, 1}).Return(tt.providerErr)
+
+			h := &Handler{
+				log:                slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				collectionProvider: collectionMock,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/collection/add/movies", bytes.NewBufferString(tt.body))
+			r = r.WithContext(context.WithValue(r.Context(), userIDKey, int64(7)))
+			w := httptest.NewRecorder()
+			h.addMoviesToCollection(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getCollection(t *testing.T) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &models.Token{
+		UserID:         7,
+		Role:           "user",
+		StandardClaims: &jwt.StandardClaims{ExpiresAt: time.Now().Add(time.Hour).Unix()},
+	})
+	tokenString, err := token.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		target       string
+		header       string
+		wantViewerID int64
+		providerErr  error
+		wantStatus   int
+	}{
+		{name: "Anonymous", target: "/get/collection?slug=best-of-1994", wantStatus: http.StatusOK},
+		{name: "Owner", target: "/get/collection?slug=best-of-1994", header: "Bearer " + tokenString, wantViewerID: 7, wantStatus: http.StatusOK},
+		{name: "Invalid token", target: "/get/collection?slug=best-of-1994", header: "Bearer garbage", wantStatus: http.StatusUnauthorized},
+		{name: "Missing slug", target: "/get/collection", wantStatus: http.StatusBadRequest},
+		{
+			name:        "Private collection",
+			target:      "/get/collection?slug=best-of-1994",
+			providerErr: fmt.Errorf("service.GetCollection: %w", storage.ErrCollectionNotFound),
+			wantStatus:  http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionMock := &mocks.CollectionProvider{}
+			collectionMock.On("GetCollection", "best-of-1994", tt.wantViewerID).
+				Return(&models.Collection{ID: 1, Slug: "best-of-1994"}, tt.providerErr)
+
+			h := &Handler{
+				log:                slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				collectionProvider: collectionMock,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			optionalUserAuthMiddleware(http.HandlerFunc(h.getCollection))(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name CostarProvider
+type CostarProvider interface {
+	GetSeparation(fromID, toID int64, maxDepth int) (*models.Separation, error)
+	GetCostars(actorID int64, page, limit int) ([]*models.Costar, error)
+}
+
+// @Summary Get degrees of separation
+// @Description Finds the shortest chain of movies connecting two actors.
+// @Tags Actors
+// @Accept json
+// @Produce json
+// @Param from query int true "ID of the first actor"
+// @Param to query int true "ID of the second actor"
+// @Param max_depth query int false "Longest chain of movies to look for, from 1 to 6"
+// @Success 200 {object} models.Separation
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 503 {string} string "Search timed out"
+// @Router /actors/separation [get]
+func (h *Handler) getSeparation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getSeparation"
+
+	log := h.log.With(slog.String("op", op))
+
+	fromID, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	toID, err := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	maxDepth := service.MaxSeparationDepth
+	if v := r.URL.Query().Get("max_depth"); v != "" {
+		maxDepth, err = strconv.Atoi(v)
+		if err != nil {
+			log.Error("invalid depth", sl.Err(err))
+			http.Error(w, service.ErrInvalidDepth.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	separation, err := h.costarProvider.GetSeparation(fromID, toID, maxDepth)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidDepth):
+			log.Error("invalid depth", sl.Err(err))
+			http.Error(w, service.ErrInvalidDepth.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrActorNotFound):
+			log.Error("actor not found", sl.Err(err))
+			http.Error(w, "actor not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrActorsNotConnected):
+			log.Info("actors are not connected", sl.Err(err))
+			http.Error(w, service.ErrActorsNotConnected.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrSeparationTimeout):
+			log.Error("separation search timed out", sl.Err(err))
+			http.Error(w, service.ErrSeparationTimeout.Error(), http.StatusServiceUnavailable)
+		default:
+			log.Error("failed to find separation", sl.Err(err))
+			http.Error(w, "failed to find separation", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	separationJSON, err := json.Marshal(separation)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(separationJSON)
+}
+
+// @Summary Get co-stars
+// @Description Lists the actors who played with the given one, most shared movies first.
+// @Tags Actors
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Param page query int false "Page number, starting from 1"
+// @Param limit query int false "Co-stars per page, up to 100"
+// @Success 200 {array} models.Costar
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /actors/{id}/costars [get]
+func (h *Handler) getCostars(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getCostars"
+
+	log := h.log.With(slog.String("op", op))
+
+	actorID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		log.Error("invalid pagination", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	costars, err := h.costarProvider.GetCostars(actorID, page, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrActorNotFound) {
+			log.Error("actor not found", sl.Err(err))
+			http.Error(w, "actor not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to fetch co-stars", sl.Err(err))
+		http.Error(w, "failed to fetch co-stars", http.StatusInternalServerError)
+		return
+	}
+
+	costarsJSON, err := json.Marshal(costars)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(costarsJSON)
+}
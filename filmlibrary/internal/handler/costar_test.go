@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_getSeparation(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Separation success",
+			target:      "/get/actors/separation?from=1&to=2",
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"degrees":1,"actors":[{"id":1,"name":"Tim Robbins"},{"id":2,"name":"Morgan Freeman"}],"movies":[{"id":1,"title":"The Shawshank Redemption"}]}`,
+		},
+		{
+			name:        "Not connected",
+			target:      "/get/actors/separation?from=1&to=2&max_depth=2",
+			providerErr: fmt.Errorf("service.GetSeparation: %w", service.ErrActorsNotConnected),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: service.ErrActorsNotConnected.Error(),
+		},
+		{
+			name:        "Timed out",
+			target:      "/get/actors/separation?from=1&to=2",
+			providerErr: fmt.Errorf("service.GetSeparation: %w", service.ErrSeparationTimeout),
+			wantStatus:  http.StatusServiceUnavailable,
+			wantMessage: service.ErrSeparationTimeout.Error(),
+		},
+		{
+			name:        "Invalid depth",
+			target:      "/get/actors/separation?from=1&to=2&max_depth=deep",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrInvalidDepth.Error(),
+		},
+		{
+			name:        "Missing actor",
+			target:      "/get/actors/separation?from=1",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "invalid actor ID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			costarMock := &mocks.CostarProvider{}
+			costarMock.On("GetSeparation", int64(1), int64(2), mock.AnythingOfType("int")).Return(&models.Separation{
+				Degrees: 1,
+				Actors:  []*models.ActorRef{{ID: 1, Name: "Tim Robbins"}, {ID: 2, Name: "Morgan Freeman"}},
+				Movies:  []*models.MovieRef{{ID: 1, Title: "The Shawshank Redemption"}},
+			}, tt.providerErr)
+
+			h := &Handler{
+				log:            slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				costarProvider: costarMock,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+			h.getSeparation(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getCostars(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Co-stars success",
+			target:      "/get/actor/costars?id=1",
+			wantStatus:  http.StatusOK,
+			wantMessage: `[{"id":2,"name":"Morgan Freeman","shared_movies":3}]`,
+		},
+		{
+			name:        "Actor not found",
+			target:      "/get/actor/costars?id=1",
+			providerErr: fmt.Errorf("service.GetCostars: %w", service.ErrActorNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "actor not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			costarMock := &mocks.CostarProvider{}
+			costarMock.On("GetCostars", int64(1), 1, defaultPageLimit).Return([]*models.Costar{
+				{ID: 2, Name: "Morgan Freeman", SharedMovies: 3},
+			}, tt.providerErr)
+
+			h := &Handler{
+				log:            slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				costarProvider: costarMock,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+			h.getCostars(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name CrewProvider
+type CrewProvider interface {
+	AddPerson(person *models.Person) error
+	GetFilmography(personID int64) (*models.Filmography, error)
+	GetMovieCrew(movieID int64) ([]*models.CrewMember, error)
+	AddCrewToMovie(movieID int64, crew []models.CrewRole) error
+	DeleteCrewFromMovie(movieID, personID int64, role string) error
+}
+
+// @Summary Add person
+// @Security ApiKeyAuth
+// @Description Adds a person who can then be credited on movies in any role.
+// @Tags Crew
+// @Accept json
+// @Produce json
+// @Param input body models.addPerson true "Person object to be added"
+// @Success 201 {string} string "Successfully added a person"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 500 {string} string "Internal server error"
+// @Router /people [post]
+func (h *Handler) addPerson(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addPerson"
+
+	log := h.log.With(slog.String("op", op))
+
+	person := &models.Person{}
+	err := json.NewDecoder(r.Body).Decode(person)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, person) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.crewProvider.AddPerson(person)
+	if err != nil {
+		log.Error("failed to add a person", sl.Err(err))
+		http.Error(w, "failed to add a person", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("Successfully added a person"))
+}
+
+// @Summary Get filmography
+// @Description Retrieves a person with all of their credits across roles.
+// @Tags Crew
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {object} models.Filmography
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /people/{id}/filmography [get]
+func (h *Handler) getFilmography(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getFilmography"
+
+	log := h.log.With(slog.String("op", op))
+
+	personID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid person ID", sl.Err(err))
+		http.Error(w, "invalid person ID", http.StatusBadRequest)
+		return
+	}
+
+	filmography, err := h.crewProvider.GetFilmography(personID)
+	if err != nil {
+		if errors.Is(err, storage.ErrPersonNotFound) {
+			log.Error("person not found", sl.Err(err))
+			http.Error(w, "person not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to fetch filmography", sl.Err(err))
+		http.Error(w, "failed to fetch filmography", http.StatusInternalServerError)
+		return
+	}
+
+	filmographyJSON, err := json.Marshal(filmography)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(filmographyJSON)
+}
+
+// @Summary Get movie crew
+// @Description Retrieves everyone credited on a movie with their roles.
+// @Tags Crew
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Success 200 {array} models.CrewMember
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/crew [get]
+func (h *Handler) getMovieCrew(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getMovieCrew"
+
+	log := h.log.With(slog.String("op", op))
+
+	movieID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	crew, err := h.crewProvider.GetMovieCrew(movieID)
+	if err != nil {
+		log.Error("failed to fetch movie crew", sl.Err(err))
+		http.Error(w, "failed to fetch movie crew", http.StatusInternalServerError)
+		return
+	}
+
+	crewJSON, err := json.Marshal(crew)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(crewJSON)
+}
+
+// @Summary Add crew to movie
+// @Security ApiKeyAuth
+// @Description Credits people on a movie. Role is one of actor, director, writer, composer, producer.
+// @Tags Crew
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param input body models.CrewTo true "Movie ID and credited people with their roles"
+// @Success 200 {string} string "Successfully added crew to movie"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/crew [post]
+func (h *Handler) addCrewToMovie(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addCrewToMovie"
+
+	log := h.log.With(slog.String("op", op))
+
+	ctom := &models.CrewTo{}
+
+	err := json.NewDecoder(r.Body).Decode(ctom)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &ctom.MovieID)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, ctom) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.crewProvider.AddCrewToMovie(ctom.MovieID, ctom.Crew)
+	if err != nil {
+		log.Error("failed to add crew to movie", sl.Err(err))
+		http.Error(w, "failed to add crew to movie", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully added crew to movie"))
+}
+
+// @Summary Remove crew member from movie
+// @Security ApiKeyAuth
+// @Description Removes a single credit of a person on a movie.
+// @Tags Crew
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param person_id path int true "Person ID"
+// @Param role query string true "Credited role"
+// @Success 200 {string} string "Successfully removed crew member from movie"
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/crew/{person_id} [delete]
+func (h *Handler) deleteCrewFromMovie(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteCrewFromMovie"
+
+	log := h.log.With(slog.String("op", op))
+
+	movieID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	personID, err := strconv.ParseInt(requestParam(r, "person_id"), 10, 64)
+	if err != nil {
+		log.Error("invalid person ID", sl.Err(err))
+		http.Error(w, "invalid person ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.crewProvider.DeleteCrewFromMovie(movieID, personID, r.URL.Query().Get("role"))
+	if err != nil {
+		log.Error("failed to remove crew member from movie", sl.Err(err))
+		http.Error(w, "failed to remove crew member from movie", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully removed crew member from movie"))
+}
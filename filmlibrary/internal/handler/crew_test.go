@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"bytes"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_addPerson(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Add person success",
+			body:        `{"name":"Frank Darabont"}`,
+			wantStatus:  http.StatusCreated,
+			wantMessage: "Successfully added a person",
+		},
+		{
+			name:        "Empty person name",
+			body:        `{"sex":"male"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"name","message":"is required"}]}`,
+		},
+		{
+			name:        "Empty request body",
+			body:        ``,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "failed to decode request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crewMock := &mocks.CrewProvider{}
+			crewMock.On("AddPerson", mock.AnythingOfType("*models.Person")).Return(nil)
+
+			h := &Handler{
+				log:          slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				crewProvider: crewMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.addPerson(w, httptest.NewRequest(http.MethodPost, "/create/person", bytes.NewBufferString(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getFilmography(t *testing.T) {
+	releaseDate := time.Date(1994, 9, 23, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		target      string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Get filmography success",
+			target:      "/get/person/filmography?id=1",
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"id":1,"name":"Frank Darabont","birthday":"0001-01-01T00:00:00Z","credits":[{"movie_id":2,"title":"The Shawshank Redemption","release_date":"1994-09-23T00:00:00Z","role":"director"}]}`,
+		},
+		{
+			name:        "Invalid person ID",
+			target:      "/get/person/filmography?id=invalid",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "invalid person ID",
+		},
+		{
+			name:        "Person not found",
+			target:      "/get/person/filmography?id=1",
+			providerErr: fmt.Errorf("service.GetFilmography: %w", storage.ErrPersonNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "person not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crewMock := &mocks.CrewProvider{}
+			if tt.providerErr != nil {
+				crewMock.On("GetFilmography", int64(1)).Return(nil, tt.providerErr)
+			} else {
+				crewMock.On("GetFilmography", int64(1)).Return(&models.Filmography{
+					ID:   1,
+					Name: "Frank Darabont",
+					Credits: []*models.Credit{
+						{MovieID: 2, Title: "The Shawshank Redemption", ReleaseDate: releaseDate, Role: models.RoleDirector},
+					},
+				}, nil)
+			}
+
+			h := &Handler{
+				log:          slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				crewProvider: crewMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.getFilmography(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getMovieCrew(t *testing.T) {
+	crewMock := &mocks.CrewProvider{}
+	crewMock.On("GetMovieCrew", int64(2)).Return([]*models.CrewMember{
+		{PersonID: 1, Name: "Tim Robbins", Role: models.RoleActor},
+		{PersonID: 3, Name: "Frank Darabont", Role: models.RoleDirector},
+	}, nil)
+
+	h := &Handler{
+		log:          slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		crewProvider: crewMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.getMovieCrew(w, httptest.NewRequest(http.MethodGet, "/get/movie/crew?id=2", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `[{"person_id":1,"name":"Tim Robbins","role":"actor"},{"person_id":3,"name":"Frank Darabont","role":"director"}]`, w.Body.String())
+}
+
+func TestHandler_addCrewToMovie(t *testing.T) {
+	crewMock := &mocks.CrewProvider{}
+	crewMock.On("AddCrewToMovie", int64(2), []models.CrewRole{{PersonID: 3, Role: models.RoleDirector}}).Return(nil)
+
+	h := &Handler{
+		log:          slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		crewProvider: crewMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.addCrewToMovie(w, httptest.NewRequest(http.MethodPost, "/movie/add/crew", bytes.NewBufferString(`{"id":2,"crew":[{"person_id":3,"role":"director"}]}`)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Successfully added crew to movie", w.Body.String())
+	crewMock.AssertExpectations(t)
+}
+
+func TestHandler_deleteCrewFromMovie(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Delete crew member success",
+			target:      "/movie/delete/crew?id=2&person_id=3&role=director",
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully removed crew member from movie",
+		},
+		{
+			name:        "Invalid person ID",
+			target:      "/movie/delete/crew?id=2&role=director",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "invalid person ID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crewMock := &mocks.CrewProvider{}
+			crewMock.On("DeleteCrewFromMovie", int64(2), int64(3), models.RoleDirector).Return(nil)
+
+			h := &Handler{
+				log:          slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				crewProvider: crewMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.deleteCrewFromMovie(w, httptest.NewRequest(http.MethodDelete, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
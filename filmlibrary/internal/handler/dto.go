@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/validate"
+	"time"
+)
+
+// addMovieRequest is the body of a request adding a movie.
+type addMovieRequest struct {
+	Title       string    `json:"title" validate:"required,max=150" example:"The Shawshank Redemption"`
+	Description string    `json:"description,omitempty" validate:"max=1000" example:"Two imprisoned men bond over a number of years"`
+	ReleaseDate time.Time `json:"release_date" validate:"required" example:"1994-10-14" format:"date"`
+	Rating      *float64  `json:"rating,omitempty" validate:"min=0,max=10" example:"9.3"`
+	ActorsID    []int     `json:"actors_id,omitempty" example:"1"`
+	GenresID    []int     `json:"genres_id,omitempty" example:"1"`
+	movieFactsRequest
+}
+
+// movieFactsRequest holds the production facts of a movie being added.
+type movieFactsRequest struct {
+	Runtime          int                 `json:"runtime,omitempty" validate:"min=1" example:"142"`
+	Budget           *models.Money       `json:"budget,omitempty"`
+	Gross            *models.Money       `json:"gross,omitempty"`
+	OriginalLanguage string              `json:"original_language,omitempty" validate:"max=3" example:"en"`
+	SpokenLanguages  []string            `json:"spoken_languages,omitempty" example:"en"`
+	Countries        []string            `json:"production_countries,omitempty" example:"US"`
+	ExternalIDs      *models.ExternalIDs `json:"external_ids,omitempty"`
+}
+
+func (req *addMovieRequest) movie() *models.Movie {
+	return &models.Movie{
+		Title:       req.Title,
+		Description: req.Description,
+		ReleaseDate: req.ReleaseDate,
+		Rating:      req.Rating,
+		ActorsID:    req.ActorsID,
+		GenresID:    req.GenresID,
+		MovieFacts: models.MovieFacts{
+			Runtime:          req.Runtime,
+			Budget:           req.Budget,
+			Gross:            req.Gross,
+			OriginalLanguage: req.OriginalLanguage,
+			SpokenLanguages:  req.SpokenLanguages,
+			Countries:        req.Countries,
+			ExternalIDs:      req.ExternalIDs,
+		},
+	}
+}
+
+// editMovieRequest is a merge patch of a movie, absent members are left unchanged and
+// null members are cleared. The ID is only read by the deprecated edit route.
+type editMovieRequest struct {
+	ID               int64                                 `json:"id,omitempty" example:"1"`
+	Title            models.Field[string]                  `json:"title" validate:"notnull,max=150" swaggertype:"string" example:"The Shawshank Redemption"`
+	Description      models.Field[string]                  `json:"description" validate:"max=1000" swaggertype:"string" example:"Two imprisoned men bond over a number of years"`
+	ReleaseDate      models.Field[time.Time]               `json:"release_date" validate:"notnull" swaggertype:"string" format:"date" example:"1994-10-14"`
+	Rating           models.Field[float64]                 `json:"rating" validate:"min=0,max=10" swaggertype:"number" example:"9.3"`
+	Runtime          models.Field[int]                     `json:"runtime" validate:"min=1" swaggertype:"integer" example:"142"`
+	Budget           models.Field[models.Money]            `json:"budget" swaggertype:"object"`
+	Gross            models.Field[models.Money]            `json:"gross" swaggertype:"object"`
+	OriginalLanguage models.Field[string]                  `json:"original_language" validate:"max=3" swaggertype:"string" example:"en"`
+	SpokenLanguages  models.Field[[]string]                `json:"spoken_languages" swaggertype:"array,string" example:"en"`
+	Countries        models.Field[[]string]                `json:"production_countries" swaggertype:"array,string" example:"US"`
+	ExternalIDs      models.Field[models.ExternalIDsPatch] `json:"external_ids" swaggertype:"object"`
+}
+
+func (req *editMovieRequest) patch() *models.MoviePatch {
+	return &models.MoviePatch{
+		Title:            req.Title,
+		Description:      req.Description,
+		ReleaseDate:      req.ReleaseDate,
+		Rating:           req.Rating,
+		Runtime:          req.Runtime,
+		Budget:           req.Budget,
+		Gross:            req.Gross,
+		OriginalLanguage: req.OriginalLanguage,
+		SpokenLanguages:  req.SpokenLanguages,
+		Countries:        req.Countries,
+		ExternalIDs:      req.ExternalIDs,
+	}
+}
+
+// addActorRequest is the body of a request adding an actor.
+type addActorRequest struct {
+	Name        string              `json:"name" validate:"required,max=100" example:"Morgan Freeman"`
+	Sex         string              `json:"sex" validate:"required,oneof=male female" example:"male"`
+	Birthday    time.Time           `json:"birthday" validate:"required,notfuture" example:"1937-06-01" format:"date"`
+	DeathDate   *time.Time          `json:"death_date,omitempty" validate:"notfuture" example:"2020-01-01" format:"date"`
+	Birthplace  string              `json:"birthplace,omitempty" validate:"max=150" example:"Memphis, Tennessee, USA"`
+	Biography   string              `json:"biography,omitempty" validate:"max=5000" example:"Born in Memphis"`
+	Aliases     []string            `json:"aliases,omitempty" example:"Morgan Porterfield Freeman"`
+	ExternalIDs *models.ExternalIDs `json:"external_ids,omitempty"`
+}
+
+func (req *addActorRequest) actor() *models.Actor {
+	return &models.Actor{
+		Name:        req.Name,
+		Sex:         req.Sex,
+		Birthday:    req.Birthday,
+		DeathDate:   req.DeathDate,
+		Birthplace:  req.Birthplace,
+		Biography:   req.Biography,
+		Aliases:     req.Aliases,
+		ExternalIDs: req.ExternalIDs,
+	}
+}
+
+// editActorRequest is a merge patch of an actor, absent members are left unchanged and
+// null members are cleared. The ID is only read by the deprecated edit route.
+type editActorRequest struct {
+	ID          int64                                 `json:"id,omitempty" example:"1"`
+	Name        models.Field[string]                  `json:"name" validate:"notnull,max=100" swaggertype:"string" example:"Morgan Freeman"`
+	Sex         models.Field[string]                  `json:"sex" validate:"oneof=male female" swaggertype:"string" example:"male"`
+	Birthday    models.Field[time.Time]               `json:"birthday" validate:"notnull,notfuture" swaggertype:"string" format:"date" example:"1937-06-01"`
+	DeathDate   models.Field[time.Time]               `json:"death_date" validate:"notfuture" swaggertype:"string" format:"date" example:"2020-01-01"`
+	Birthplace  models.Field[string]                  `json:"birthplace" validate:"max=150" swaggertype:"string" example:"Memphis, Tennessee, USA"`
+	Biography   models.Field[string]                  `json:"biography" validate:"max=5000" swaggertype:"string" example:"Born in Memphis"`
+	Aliases     models.Field[[]string]                `json:"aliases" swaggertype:"array,string" example:"Morgan Porterfield Freeman"`
+	ExternalIDs models.Field[models.ExternalIDsPatch] `json:"external_ids" swaggertype:"object"`
+}
+
+func (req *editActorRequest) patch() *models.ActorPatch {
+	return &models.ActorPatch{
+		Name:        req.Name,
+		Sex:         req.Sex,
+		Birthday:    req.Birthday,
+		DeathDate:   req.DeathDate,
+		Birthplace:  req.Birthplace,
+		Biography:   req.Biography,
+		Aliases:     req.Aliases,
+		ExternalIDs: req.ExternalIDs,
+	}
+}
+
+// actorResponse is the profile of an actor as it is read.
+type actorResponse struct {
+	ID          int64               `json:"id" example:"1"`
+	Name        string              `json:"name" example:"Morgan Freeman"`
+	Sex         string              `json:"sex,omitempty" example:"male"`
+	Birthday    time.Time           `json:"birthday" example:"1937-06-01" format:"date"`
+	DeathDate   *time.Time          `json:"death_date,omitempty" example:"2020-01-01" format:"date"`
+	Birthplace  string              `json:"birthplace,omitempty" example:"Memphis, Tennessee, USA"`
+	Biography   string              `json:"biography,omitempty" example:"Born in Memphis"`
+	Aliases     []string            `json:"aliases,omitempty" example:"Morgan Porterfield Freeman"`
+	ExternalIDs *models.ExternalIDs `json:"external_ids,omitempty"`
+	Age         *int                `json:"age,omitempty" example:"87"`
+	MoviesID    []int               `json:"movies_id,omitempty" example:"1"`
+	Version     int64               `json:"version,omitempty" example:"3"`
+}
+
+func newActorResponse(actor *models.Actor) *actorResponse {
+	return &actorResponse{
+		ID:          actor.ID,
+		Name:        actor.Name,
+		Sex:         actor.Sex,
+		Birthday:    actor.Birthday,
+		DeathDate:   actor.DeathDate,
+		Birthplace:  actor.Birthplace,
+		Biography:   actor.Biography,
+		Aliases:     actor.Aliases,
+		ExternalIDs: actor.ExternalIDs,
+		Age:         actor.Age,
+		MoviesID:    actor.MoviesID,
+		Version:     actor.Version,
+	}
+}
+
+// movieResponse is a movie as it is listed, with the titles, the description and the
+// actor names in the language of the request.
+type movieResponse struct {
+	ID          int64     `json:"id" example:"1"`
+	Title       string    `json:"title,omitempty" example:"The Shawshank Redemption"`
+	Description string    `json:"description,omitempty" example:"Two imprisoned men bond over a number of years"`
+	ReleaseDate time.Time `json:"release_date,omitempty" example:"1994-10-14" format:"date"`
+	Rating      *float64  `json:"rating,omitempty" example:"9.3"`
+	UserRating  *float64  `json:"user_rating,omitempty" example:"8.7"`
+	VotesCount  int64     `json:"votes_count,omitempty" example:"120"`
+	Actors      []string  `json:"actors_id" example:"Tim Robbins"`
+	Genres      []string  `json:"genres,omitempty" example:"Drama"`
+	models.MovieFacts
+	Version int64 `json:"version,omitempty" example:"3"`
+}
+
+func newMovieResponse(movie *models.MovieListing) *movieResponse {
+	return &movieResponse{
+		ID:          movie.ID,
+		Title:       movie.Title,
+		Description: movie.Description,
+		ReleaseDate: movie.ReleaseDate,
+		Rating:      movie.Rating,
+		UserRating:  movie.UserRating,
+		VotesCount:  movie.VotesCount,
+		Actors:      movie.Actors,
+		Genres:      movie.Genres,
+		MovieFacts:  movie.MovieFacts,
+		Version:     movie.Version,
+	}
+}
+
+func newMovieResponses(movies []*models.MovieListing) []*movieResponse {
+	responses := make([]*movieResponse, 0, len(movies))
+	for _, movie := range movies {
+		responses = append(responses, newMovieResponse(movie))
+	}
+	return responses
+}
+
+// movieDetailResponse is a movie as it is read on its own, with its place in the
+// franchise and the movies related to it.
+type movieDetailResponse struct {
+	movieResponse
+	Franchise *models.MovieFranchise `json:"franchise,omitempty"`
+	Related   []*models.RelatedMovie `json:"related"`
+}
+
+func newMovieDetailResponse(movie *models.MovieDetail) *movieDetailResponse {
+	return &movieDetailResponse{
+		movieResponse: *newMovieResponse(movie.MovieListing),
+		Franchise:     movie.Franchise,
+		Related:       movie.Related,
+	}
+}
+
+// movieListResponse is a page of the movie listing of v2 with the facets of the
+// movies the filter keeps.
+type movieListResponse struct {
+	Movies []*movieResponse    `json:"movies"`
+	Facets *models.MovieFacets `json:"facets"`
+}
+
+// movieBatchRequest is the body of a batch of movie operations, applied in order.
+type movieBatchRequest struct {
+	Items []movieBatchItemRequest `json:"items"`
+}
+
+// movieBatchItemRequest is one operation of a movie batch. A create carries the movie,
+// an update the patch, and both an update and a delete the ID and the version the
+// client has seen.
+type movieBatchItemRequest struct {
+	Op      string            `json:"op" validate:"required,oneof=create update delete" example:"update"`
+	ID      int64             `json:"id,omitempty" example:"1"`
+	Version int64             `json:"version,omitempty" validate:"min=1" example:"3"`
+	Movie   *addMovieRequest  `json:"movie,omitempty"`
+	Patch   *editMovieRequest `json:"patch,omitempty"`
+}
+
+func (req movieBatchItemRequest) operation() string {
+	return req.Op
+}
+
+func (req movieBatchItemRequest) validate() error {
+	return validateBatchItem(req, req.Op, req.ID, req.Version, "movie", req.Movie != nil, req.Patch != nil)
+}
+
+func (req movieBatchItemRequest) item() *models.MovieBatchItem {
+	item := &models.MovieBatchItem{Op: req.Op, ID: req.ID, Version: req.Version}
+	if req.Movie != nil {
+		item.Movie = req.Movie.movie()
+	}
+	if req.Patch != nil {
+		item.Patch = req.Patch.patch()
+	}
+	return item
+}
+
+// actorBatchRequest is the body of a batch of actor operations, applied in order.
+type actorBatchRequest struct {
+	Items []actorBatchItemRequest `json:"items"`
+}
+
+// actorBatchItemRequest is one operation of an actor batch like movieBatchItemRequest.
+type actorBatchItemRequest struct {
+	Op      string            `json:"op" validate:"required,oneof=create update delete" example:"update"`
+	ID      int64             `json:"id,omitempty" example:"1"`
+	Version int64             `json:"version,omitempty" validate:"min=1" example:"3"`
+	Actor   *addActorRequest  `json:"actor,omitempty"`
+	Patch   *editActorRequest `json:"patch,omitempty"`
+}
+
+func (req actorBatchItemRequest) operation() string {
+	return req.Op
+}
+
+func (req actorBatchItemRequest) validate() error {
+	return validateBatchItem(req, req.Op, req.ID, req.Version, "actor", req.Actor != nil, req.Patch != nil)
+}
+
+func (req actorBatchItemRequest) item() *models.ActorBatchItem {
+	item := &models.ActorBatchItem{Op: req.Op, ID: req.ID, Version: req.Version}
+	if req.Actor != nil {
+		item.Actor = req.Actor.actor()
+	}
+	if req.Patch != nil {
+		item.Patch = req.Patch.patch()
+	}
+	return item
+}
+
+// validateBatchItem checks a batch item against its validate tags and against what its
+// operation needs, which the tags cannot tell: the created object for a create, the
+// patch for an update, and the ID and the version for an update or a delete.
+func validateBatchItem(req interface{}, op string, id, version int64, object string, hasObject, hasPatch bool) error {
+	var errs validate.Errors
+	if err := validate.Struct(req); err != nil {
+		errs = err.(validate.Errors)
+	}
+
+	var required []string
+	switch op {
+	case models.BatchCreate:
+		if !hasObject {
+			required = append(required, object)
+		}
+	case models.BatchUpdate, models.BatchDelete:
+		if id == 0 {
+			required = append(required, "id")
+		}
+		if version == 0 {
+			required = append(required, "version")
+		}
+		if op == models.BatchUpdate && !hasPatch {
+			required = append(required, "patch")
+		}
+	}
+	for _, field := range required {
+		errs = append(errs, validate.FieldError{Field: field, Message: "is required"})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// batchResponse lists the outcome of every item of a batch in the order of the request.
+type batchResponse struct {
+	Results []*batchItemResponse `json:"results"`
+}
+
+// batchItemResponse is the outcome of one item of a batch, Status is the one the item
+// would have been answered with on its own. Items rolled back along with a failed item
+// of an atomic batch fail with 424 Failed Dependency.
+type batchItemResponse struct {
+	Index   int    `json:"index" example:"0"`
+	Op      string `json:"op" example:"update"`
+	Status  int    `json:"status" example:"200"`
+	ID      int64  `json:"id,omitempty" example:"1"`
+	Version int64  `json:"version,omitempty" example:"4"`
+	Error   string `json:"error,omitempty" example:"version has changed"`
+}
@@ -0,0 +1,413 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name FranchiseProvider
+type FranchiseProvider interface {
+	AddFranchise(franchise *models.Franchise) error
+	EditFranchise(franchise *models.Franchise) error
+	DeleteFranchise(id int64) error
+	GetFranchises() ([]*models.Franchise, error)
+	GetFranchise(id int64) (*models.Franchise, error)
+	SetFranchiseMovies(franchiseID int64, movieIDs []int64) error
+	RelateMovies(relation *models.MovieRelation) error
+	DeleteMovieRelation(movieID, relatedMovieID int64) error
+}
+
+// @Summary Create franchise
+// @Security ApiKeyAuth
+// @Description Creates a franchise, such as The Godfather, to put movies into in order.
+// @Tags Franchises
+// @Accept json
+// @Produce json
+// @Param input body models.addFranchise true "Franchise to be created"
+// @Success 201 {object} models.Franchise
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /franchises [post]
+func (h *Handler) addFranchise(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addFranchise"
+
+	log := h.log.With(slog.String("op", op))
+
+	franchise := &models.Franchise{}
+	err := json.NewDecoder(r.Body).Decode(franchise)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, franchise) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.franchiseProvider.AddFranchise(franchise)
+	if err != nil {
+		franchiseError(w, log, err, "failed to create a franchise")
+		return
+	}
+
+	franchiseJSON, err := json.Marshal(franchise)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(franchiseJSON)
+}
+
+// @Summary Edit franchise
+// @Security ApiKeyAuth
+// @Description Renames a franchise and changes its description.
+// @Tags Franchises
+// @Accept json
+// @Produce json
+// @Param id path int true "Franchise ID"
+// @Param input body models.editFranchise true "Franchise to be edited"
+// @Success 200 {string} string "Successfully edited a franchise"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /franchises/{id} [patch]
+func (h *Handler) editFranchise(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.editFranchise"
+
+	log := h.log.With(slog.String("op", op))
+
+	franchise := &models.Franchise{}
+	err := json.NewDecoder(r.Body).Decode(franchise)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &franchise.ID)
+	if err != nil {
+		log.Error("invalid franchise ID", sl.Err(err))
+		http.Error(w, "invalid franchise ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, franchise) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.franchiseProvider.EditFranchise(franchise)
+	if err != nil {
+		franchiseError(w, log, err, "failed to edit a franchise")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully edited a franchise"))
+}
+
+// @Summary Delete franchise
+// @Security ApiKeyAuth
+// @Description Deletes a franchise by its ID, its movies are kept.
+// @Tags Franchises
+// @Accept json
+// @Produce json
+// @Param id path int true "Franchise ID"
+// @Success 200 {string} string "Successfully deleted a franchise"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /franchises/{id} [delete]
+func (h *Handler) deleteFranchise(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteFranchise"
+
+	log := h.log.With(slog.String("op", op))
+
+	id, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid franchise ID", sl.Err(err))
+		http.Error(w, "invalid franchise ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.franchiseProvider.DeleteFranchise(id)
+	if err != nil {
+		franchiseError(w, log, err, "failed to delete a franchise")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a franchise"))
+}
+
+// @Summary Get franchises
+// @Description Lists all franchises by name with the number of movies in each of them.
+// @Tags Franchises
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Franchise
+// @Failure 500 {string} string "Internal server error"
+// @Router /franchises [get]
+func (h *Handler) getFranchises(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getFranchises"
+
+	log := h.log.With(slog.String("op", op))
+
+	franchises, err := h.franchiseProvider.GetFranchises()
+	if err != nil {
+		log.Error("failed to fetch franchises", sl.Err(err))
+		http.Error(w, "failed to fetch franchises", http.StatusInternalServerError)
+		return
+	}
+
+	franchisesJSON, err := json.Marshal(franchises)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(franchisesJSON)
+}
+
+// @Summary Get franchise
+// @Description Retrieves a franchise with its movies in order.
+// @Tags Franchises
+// @Accept json
+// @Produce json
+// @Param id path int true "Franchise ID"
+// @Success 200 {object} models.Franchise
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /franchises/{id} [get]
+func (h *Handler) getFranchise(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getFranchise"
+
+	log := h.log.With(slog.String("op", op))
+
+	id, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid franchise ID", sl.Err(err))
+		http.Error(w, "invalid franchise ID", http.StatusBadRequest)
+		return
+	}
+
+	franchise, err := h.franchiseProvider.GetFranchise(id)
+	if err != nil {
+		franchiseError(w, log, err, "failed to fetch a franchise")
+		return
+	}
+
+	franchiseJSON, err := json.Marshal(franchise)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(franchiseJSON)
+}
+
+// @Summary Set franchise movies
+// @Security ApiKeyAuth
+// @Description Replaces the movies of a franchise, they are ordered as listed. A movie belongs to one franchise at most.
+// @Tags Franchises
+// @Accept json
+// @Produce json
+// @Param id path int true "Franchise ID"
+// @Param input body models.FranchiseMovies true "Franchise ID and movie IDs in order"
+// @Success 200 {string} string "Successfully set franchise movies"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /franchises/{id}/movies [put]
+func (h *Handler) setFranchiseMovies(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.setFranchiseMovies"
+
+	log := h.log.With(slog.String("op", op))
+
+	movies := &models.FranchiseMovies{}
+	err := json.NewDecoder(r.Body).Decode(movies)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &movies.FranchiseID)
+	if err != nil {
+		log.Error("invalid franchise ID", sl.Err(err))
+		http.Error(w, "invalid franchise ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, movies) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.franchiseProvider.SetFranchiseMovies(movies.FranchiseID, movies.MoviesID)
+	if err != nil {
+		franchiseError(w, log, err, "failed to set franchise movies")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully set franchise movies"))
+}
+
+// @Summary Relate movies
+// @Security ApiKeyAuth
+// @Description Sets the related movie as the sequel, prequel, remake or spin-off of the movie. Sequels and prequels cannot form a cycle.
+// @Tags Franchises
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param input body models.MovieRelation true "Relation to be set"
+// @Success 200 {string} string "Successfully related movies"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/relations [post]
+func (h *Handler) relateMovies(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.relateMovies"
+
+	log := h.log.With(slog.String("op", op))
+
+	relation := &models.MovieRelation{}
+	err := json.NewDecoder(r.Body).Decode(relation)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &relation.MovieID)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, relation) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.franchiseProvider.RelateMovies(relation)
+	if err != nil {
+		franchiseError(w, log, err, "failed to relate movies")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully related movies"))
+}
+
+// @Summary Delete movie relation
+// @Security ApiKeyAuth
+// @Description Deletes the relation set between two movies.
+// @Tags Franchises
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param related_id path int true "Related movie ID"
+// @Success 200 {string} string "Successfully deleted a relation"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/relations/{related_id} [delete]
+func (h *Handler) deleteMovieRelation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteMovieRelation"
+
+	log := h.log.With(slog.String("op", op))
+
+	movieID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	relatedMovieID, err := strconv.ParseInt(requestParam(r, "related_id"), 10, 64)
+	if err != nil {
+		log.Error("invalid related movie ID", sl.Err(err))
+		http.Error(w, "invalid related movie ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.franchiseProvider.DeleteMovieRelation(movieID, relatedMovieID)
+	if err != nil {
+		franchiseError(w, log, err, "failed to delete a relation")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a relation"))
+}
+
+// franchiseError reports an error of managing franchises and movie relations.
+func franchiseError(w http.ResponseWriter, log *slog.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidFranchiseName):
+		log.Error("invalid franchise name", sl.Err(err))
+		http.Error(w, service.ErrInvalidFranchiseName.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidFranchiseMovies):
+		log.Error("invalid franchise movies", sl.Err(err))
+		http.Error(w, service.ErrInvalidFranchiseMovies.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidRelationType):
+		log.Error("invalid relation type", sl.Err(err))
+		http.Error(w, service.ErrInvalidRelationType.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrSelfRelation):
+		log.Error("movie related to itself", sl.Err(err))
+		http.Error(w, service.ErrSelfRelation.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrRelationCycle):
+		log.Error("relation makes a cycle", sl.Err(err))
+		http.Error(w, service.ErrRelationCycle.Error(), http.StatusConflict)
+	case errors.Is(err, storage.ErrFranchiseExists):
+		log.Error("franchise exists", sl.Err(err))
+		http.Error(w, "franchise with this name already exists", http.StatusConflict)
+	case errors.Is(err, storage.ErrMovieInOtherFranchise):
+		log.Error("movie is in another franchise", sl.Err(err))
+		http.Error(w, storage.ErrMovieInOtherFranchise.Error(), http.StatusConflict)
+	case errors.Is(err, storage.ErrFranchiseNotFound):
+		log.Error("franchise not found", sl.Err(err))
+		http.Error(w, "franchise not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrMovieNotFound):
+		log.Error("movie not found", sl.Err(err))
+		http.Error(w, "movie not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrRelationNotFound):
+		log.Error("relation not found", sl.Err(err))
+		http.Error(w, "relation not found", http.StatusNotFound)
+	default:
+		log.Error(message, sl.Err(err))
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_relateMovies(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Movies related",
+			body:        `{"movie_id":1,"related_movie_id":2,"type":"sequel"}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully related movies",
+		},
+		{
+			name:        "Sequel cycle",
+			body:        `{"movie_id":3,"related_movie_id":1,"type":"sequel"}`,
+			providerErr: fmt.Errorf("service.RelateMovies: %w", service.ErrRelationCycle),
+			wantStatus:  http.StatusConflict,
+			wantMessage: service.ErrRelationCycle.Error(),
+		},
+		{
+			name:        "Invalid relation type",
+			body:        `{"movie_id":1,"related_movie_id":2,"type":"reboot"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"type","message":"must be one of sequel, prequel, remake, spin_off"}]}`,
+		},
+		{
+			name:        "Movie not found",
+			body:        `{"movie_id":1,"related_movie_id":99,"type":"remake"}`,
+			providerErr: fmt.Errorf("service.RelateMovies: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			franchiseMock := &mocks.FranchiseProvider{}
+			franchiseMock.On("RelateMovies", mock.AnythingOfType("*models.MovieRelation")).Return(tt.providerErr)
+
+			h := &Handler{
+				log:               slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				franchiseProvider: franchiseMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.relateMovies(w, httptest.NewRequest(http.MethodPost, "/movie/add/relation", bytes.NewBufferString(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_setFranchiseMovies(t *testing.T) {
+	franchiseMock := &mocks.FranchiseProvider{}
+	franchiseMock.On("SetFranchiseMovies", int64(1), []int64{3, 2}).
+		Return(fmt.Errorf("service.SetFranchiseMovies: %w", storage.ErrMovieInOtherFranchise))
+
+	h := &Handler{
+		log:               slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		franchiseProvider: franchiseMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.setFranchiseMovies(w, httptest.NewRequest(http.MethodPost, "/franchise/set/movies", bytes.NewBufferString(`{"franchise_id":1,"movies_id":[3,2]}`)))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, storage.ErrMovieInOtherFranchise.Error(), strings.TrimSpace(w.Body.String()))
+}
+
+func TestHandler_getMovieDetail(t *testing.T) {
+	movieMock := &mocks.MovieProvider{}
+	movieMock.On("GetMovieDetail", int64(2), []string{"ru"}).Return(&models.MovieDetail{
+		MovieListing: &models.MovieListing{ID: 2, Title: "Крёстный отец 2", Actors: []string{}},
+		Franchise: &models.MovieFranchise{ID: 1, Name: "The Godfather", Position: 2,
+			Previous: &models.MovieRef{ID: 1, Title: "Крёстный отец"}},
+		Related: []*models.RelatedMovie{{Type: models.RelationPrequel, Movie: &models.MovieRef{ID: 1, Title: "Крёстный отец"}}},
+	}, nil)
+	movieMock.On("GetMovieDetail", int64(9), []string(nil)).Return(nil, fmt.Errorf("service.GetMovieDetail: %w", storage.ErrMovieNotFound))
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		movieProvider: movieMock,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/get/movie?id=2", nil)
+	r.Header.Set("Accept-Language", "ru")
+	w := httptest.NewRecorder()
+	h.getMovieDetail(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	assert.Equal(t, `{"id":2,"title":"Крёстный отец 2","release_date":"0001-01-01T00:00:00Z","actors_id":[],`+
+		`"franchise":{"id":1,"name":"The Godfather","position":2,"previous":{"id":1,"title":"Крёстный отец"}},`+
+		`"related":[{"type":"prequel","movie":{"id":1,"title":"Крёстный отец"}}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	h.getMovieDetail(w, httptest.NewRequest(http.MethodGet, "/get/movie?id=9", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "movie not found", strings.TrimSpace(w.Body.String()))
+}
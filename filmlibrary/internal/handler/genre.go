@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"encoding/json"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name GenreProvider
+type GenreProvider interface {
+	AddGenre(genre *models.Genre) error
+	EditGenre(genre *models.Genre) error
+	DeleteGenre(id int64) error
+	GetGenres() ([]*models.GenreListing, error)
+	GetMovieFacets(filter models.MovieFilter) (*models.MovieFacets, error)
+	AddGenresToMovie(movieID int64, genres []int64) error
+}
+
+// @Summary Add genre
+// @Security ApiKeyAuth
+// @Description Adds a new genre.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param input body models.addGenre true "Genre object to be added"
+// @Success 201 {string} string "Successfully added a genre"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 500 {string} string "Internal server error"
+// @Router /genres [post]
+func (h *Handler) addGenre(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addGenre"
+
+	log := h.log.With(slog.String("op", op))
+
+	genre := &models.Genre{}
+	err := json.NewDecoder(r.Body).Decode(genre)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, genre) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.genreProvider.AddGenre(genre)
+	if err != nil {
+		log.Error("failed to add a genre", sl.Err(err))
+		http.Error(w, "failed to add a genre", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("Successfully added a genre"))
+}
+
+// @Summary Edit genre
+// @Security ApiKeyAuth
+// @Description Renames a genre.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param input body models.editGenre true "Genre object to be edited"
+// @Success 200 {string} string "Successfully edited a genre"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 500 {string} string "Internal server error"
+// @Router /genres/{id} [patch]
+func (h *Handler) editGenre(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.editGenre"
+
+	log := h.log.With(slog.String("op", op))
+
+	genre := &models.Genre{}
+	err := json.NewDecoder(r.Body).Decode(genre)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &genre.ID)
+	if err != nil {
+		log.Error("invalid genre ID", sl.Err(err))
+		http.Error(w, "invalid genre ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, genre) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.genreProvider.EditGenre(genre)
+	if err != nil {
+		log.Error("failed to edit a genre", sl.Err(err))
+		http.Error(w, "failed to edit a genre", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully edited a genre"))
+}
+
+// @Summary Delete genre
+// @Security ApiKeyAuth
+// @Description Deletes a genre by its ID and removes it from all movies.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID to be deleted"
+// @Success 200 {string} string "Successfully deleted a genre"
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /genres/{id} [delete]
+func (h *Handler) deleteGenre(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteGenre"
+
+	log := h.log.With(slog.String("op", op))
+
+	genreIDStr := requestParam(r, "id")
+	genreID, err := strconv.ParseInt(genreIDStr, 10, 64)
+	if err != nil {
+		log.Error("invalid genre ID", sl.Err(err))
+		http.Error(w, "invalid genre ID", http.StatusBadRequest)
+		return
+	}
+
+	log.Info("parsed genre ID")
+
+	err = h.genreProvider.DeleteGenre(genreID)
+	if err != nil {
+		log.Error("failed to delete a genre", sl.Err(err))
+		http.Error(w, "failed to delete a genre", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a genre"))
+}
+
+// @Summary Get list of genres
+// @Description Retrieves all genres with the number of movies in each of them.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.GenreListing "Successfully fetched genres"
+// @Failure 500 {string} string "Internal server error"
+// @Router /genres [get]
+func (h *Handler) getGenres(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getGenres"
+
+	log := h.log.With(slog.String("op", op))
+
+	genres, err := h.genreProvider.GetGenres()
+	if err != nil {
+		log.Error("failed to fetch genres", sl.Err(err))
+		http.Error(w, "failed to fetch genres", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("fetched genres")
+
+	genresJSON, err := json.Marshal(genres)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(genresJSON)
+}
+
+// @Summary Add genres to movie
+// @Security ApiKeyAuth
+// @Description Adds genres to a movie based on the provided movie ID and genre IDs.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param input body models.GenresTo true "Movie ID and genre IDs to be added"
+// @Success 200 {string} string "Successfully added genre(s) to movie"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/genres [post]
+func (h *Handler) addGenresToMovie(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addGenresToMovie"
+
+	log := h.log.With(slog.String("op", op))
+
+	gtom := &models.GenresTo{}
+
+	err := json.NewDecoder(r.Body).Decode(gtom)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &gtom.MovieID)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, gtom) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.genreProvider.AddGenresToMovie(gtom.MovieID, gtom.Genres)
+	if err != nil {
+		log.Error("failed to add genre(s) to movie", sl.Err(err))
+		http.Error(w, "failed to add genre(s) to movie", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully added genre(s) to movie"))
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"bytes"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_addGenre(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Add genre success",
+			body:        `{"name":"Comedy"}`,
+			wantStatus:  http.StatusCreated,
+			wantMessage: "Successfully added a genre",
+		},
+		{
+			name:        "Empty request body",
+			body:        ``,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "failed to decode request",
+		},
+		{
+			name:        "Empty genre name",
+			body:        `{"name":""}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"name","message":"is required"}]}`,
+		},
+		{
+			name:        "Add genre failed",
+			body:        `{"name":"Comedy"}`,
+			providerErr: fmt.Errorf("genre exists"),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "failed to add a genre",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genreMock := &mocks.GenreProvider{}
+			genreMock.On("AddGenre", mock.AnythingOfType("*models.Genre")).Return(tt.providerErr)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				genreProvider: genreMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.addGenre(w, httptest.NewRequest(http.MethodPost, "/create/genre", bytes.NewBufferString(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_editGenre(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Edit genre success",
+			body:        `{"id":1,"name":"Drama"}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully edited a genre",
+		},
+		{
+			name:        "Empty genre name",
+			body:        `{"id":1}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"name","message":"is required"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genreMock := &mocks.GenreProvider{}
+			genreMock.On("EditGenre", &models.Genre{ID: 1, Name: "Drama"}).Return(nil)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				genreProvider: genreMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.editGenre(w, httptest.NewRequest(http.MethodPost, "/edit/genre", bytes.NewBufferString(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_deleteGenre(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Delete genre success",
+			target:      "/delete/genre?id=1",
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully deleted a genre",
+		},
+		{
+			name:        "Invalid genre ID",
+			target:      "/delete/genre?id=invalid",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "invalid genre ID",
+		},
+		{
+			name:        "Delete genre failed",
+			target:      "/delete/genre?id=1",
+			providerErr: fmt.Errorf("genre not found"),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "failed to delete a genre",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genreMock := &mocks.GenreProvider{}
+			genreMock.On("DeleteGenre", int64(1)).Return(tt.providerErr)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				genreProvider: genreMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.deleteGenre(w, httptest.NewRequest(http.MethodDelete, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getGenres(t *testing.T) {
+	genreMock := &mocks.GenreProvider{}
+	genreMock.On("GetGenres").Return([]*models.GenreListing{
+		{ID: 1, Name: "Comedy", MoviesCount: 3},
+		{ID: 2, Name: "Drama", MoviesCount: 0},
+	}, nil)
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		genreProvider: genreMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.getGenres(w, httptest.NewRequest(http.MethodGet, "/get/genres", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `[{"id":1,"name":"Comedy","movies_count":3},{"id":2,"name":"Drama","movies_count":0}]`, w.Body.String())
+}
+
+func TestHandler_addGenresToMovie(t *testing.T) {
+	genreMock := &mocks.GenreProvider{}
+	genreMock.On("AddGenresToMovie", int64(2), []int64{1, 3}).Return(nil)
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		genreProvider: genreMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.addGenresToMovie(w, httptest.NewRequest(http.MethodPost, "/movie/add/genres", bytes.NewBufferString(`{"id":2,"genres_id":[1,3]}`)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Successfully added genre(s) to movie", w.Body.String())
+	genreMock.AssertExpectations(t)
+}
@@ -2,97 +2,394 @@ package handler
 
 import (
 	"context"
-	_ "filmlibrary/docs"
+	"encoding/json"
+	"errors"
+	_ "filmlibrary/docs/v1"
+	_ "filmlibrary/docs/v2"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/lib/validate"
+	"filmlibrary/internal/storage"
 	"fmt"
 	"github.com/dgrijalva/jwt-go"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
+	"hash/fnv"
 	"log/slog"
+	"maps"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
+type contextKey string
+
+const userIDKey contextKey = "userID"
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
 type Handler struct {
-	log           *slog.Logger
-	userProvider  UserProvider
-	actorProvider ActorProvider
-	movieProvider MovieProvider
-	authProvider  AuthProvider
-}
-
-func New(log *slog.Logger,
-	userProvider UserProvider,
-	actorProvider ActorProvider,
-	movieProvider MovieProvider,
-	authProvider AuthProvider,
-) *Handler {
+	log                    *slog.Logger
+	userProvider           UserProvider
+	actorProvider          ActorProvider
+	movieProvider          MovieProvider
+	authProvider           AuthProvider
+	genreProvider          GenreProvider
+	crewProvider           CrewProvider
+	reviewProvider         ReviewProvider
+	watchlistProvider      WatchlistProvider
+	collectionProvider     CollectionProvider
+	recommendationProvider RecommendationProvider
+	costarProvider         CostarProvider
+	imageProvider          ImageProvider
+	translationProvider    TranslationProvider
+	releaseProvider        ReleaseProvider
+	franchiseProvider      FranchiseProvider
+	awardProvider          AwardProvider
+	catalogProvider        CatalogProvider
+}
+
+// Provider is everything the handlers ask of the service layer, service.Service
+// provides all of it.
+type Provider interface {
+	UserProvider
+	ActorProvider
+	MovieProvider
+	AuthProvider
+	GenreProvider
+	CrewProvider
+	ReviewProvider
+	WatchlistProvider
+	CollectionProvider
+	RecommendationProvider
+	CostarProvider
+	ImageProvider
+	TranslationProvider
+	ReleaseProvider
+	FranchiseProvider
+	AwardProvider
+	CatalogProvider
+}
+
+func New(log *slog.Logger, provider Provider) *Handler {
 	return &Handler{
-		log:           log,
-		userProvider:  userProvider,
-		actorProvider: actorProvider,
-		movieProvider: movieProvider,
-		authProvider:  authProvider,
+		log:                    log,
+		userProvider:           provider,
+		actorProvider:          provider,
+		movieProvider:          provider,
+		authProvider:           provider,
+		genreProvider:          provider,
+		crewProvider:           provider,
+		reviewProvider:         provider,
+		watchlistProvider:      provider,
+		collectionProvider:     provider,
+		recommendationProvider: provider,
+		costarProvider:         provider,
+		imageProvider:          provider,
+		translationProvider:    provider,
+		releaseProvider:        provider,
+		franchiseProvider:      provider,
+		awardProvider:          provider,
+		catalogProvider:        provider,
+	}
+}
+
+// apiVersion is a set of routes served under /api/{name}, the patterns are written
+// without the prefix.
+type apiVersion struct {
+	name   string
+	routes map[string]http.HandlerFunc
+}
+
+// apiVersions lists the API versions served side by side, each with its own swagger
+// docs. A version starts from a clone of the routes of the previous one and only
+// replaces the handlers of the routes it changes in a breaking way.
+func (h *Handler) apiVersions() []apiVersion {
+	v1 := h.routesV1()
+
+	// the movie listing is a JSON object with the facets of the filtered movies
+	v2 := maps.Clone(v1)
+	v2["GET /movies"] = h.getMovies
+
+	return []apiVersion{
+		{name: "v1", routes: v1},
+		{name: "v2", routes: v2},
 	}
 }
 
+// InitRoutes mounts every API version under its prefix with its own swagger docs. The
+// routes of the API from before versioning keep serving v1 at their old paths and are
+// deprecated, every other route is only served under a version.
 func (h *Handler) InitRoutes() *http.ServeMux {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/swagger/", httpSwagger.WrapHandler)
+	for _, version := range h.apiVersions() {
+		prefix := "/api/" + version.name
 
-	mux.HandleFunc("/edit/actor", authMiddleware(onlyPostMiddleware(h.editActor)))
-	mux.HandleFunc("/edit/movie", authMiddleware(onlyPostMiddleware(h.editMovie)))
+		mux.HandleFunc("GET "+prefix+"/swagger/", httpSwagger.Handler(httpSwagger.InstanceName(version.name)))
+		for pattern, handler := range version.routes {
+			method, path, _ := strings.Cut(pattern, " ")
+			mux.HandleFunc(method+" "+prefix+path, handler)
+		}
+	}
+
+	mux.HandleFunc("GET /swagger/", httpSwagger.Handler(httpSwagger.InstanceName("v1")))
+	h.initLegacyRoutes(mux)
+
+	return mux
+}
 
-	mux.HandleFunc("/create/actor", authMiddleware(onlyPostMiddleware(h.addActor)))
-	mux.HandleFunc("/create/movie", authMiddleware(onlyPostMiddleware(h.addMovie)))
+// routesV1 are the resource routes of v1. Path wildcards are named after the query
+// parameters of the deprecated verb-prefixed routes, so handlers read either of them
+// with requestParam.
+func (h *Handler) routesV1() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"GET /movies":                                h.getMoviesSorted,
+		"POST /movies":                               authMiddleware(h.addMovie),
+		"POST /movies/batch":                         authMiddleware(h.batchMovies),
+		"GET /movies/search":                         h.getMovie,
+		"GET /movies/{id}":                           h.getMovieDetail,
+		"PATCH /movies/{id}":                         authMiddleware(h.editMovie),
+		"DELETE /movies/{id}":                        authMiddleware(h.deleteMovie),
+		"POST /movies/{id}/actors":                   authMiddleware(h.addActorsToMovie),
+		"POST /movies/{id}/genres":                   authMiddleware(h.addGenresToMovie),
+		"GET /movies/{id}/crew":                      h.getMovieCrew,
+		"POST /movies/{id}/crew":                     authMiddleware(h.addCrewToMovie),
+		"DELETE /movies/{id}/crew/{person_id}":       authMiddleware(h.deleteCrewFromMovie),
+		"GET /movies/{id}/releases":                  h.getReleases,
+		"POST /movies/{id}/releases":                 authMiddleware(h.addRelease),
+		"GET /movies/{id}/translations":              h.getMovieTranslations,
+		"POST /movies/{id}/translations":             authMiddleware(h.translateMovie),
+		"DELETE /movies/{id}/translations/{locale}":  authMiddleware(h.deleteMovieTranslation),
+		"POST /movies/{id}/relations":                authMiddleware(h.relateMovies),
+		"DELETE /movies/{id}/relations/{related_id}": authMiddleware(h.deleteMovieRelation),
+		"GET /movies/{id}/similar":                   h.getSimilarMovies,
+		"GET /movies/{id}/images":                    h.getMovieImages,
+		"POST /movies/{id}/images":                   authMiddleware(h.uploadMovieImage),
+		"GET /movies/{id}/reviews":                   h.getReviews,
+		"POST /movies/{id}/reviews":                  userAuthMiddleware(h.submitReview),
+		"DELETE /releases/{id}":                      authMiddleware(h.deleteRelease),
 
-	mux.HandleFunc("/delete/actor", authMiddleware(onlyDeleteMiddleware(h.deleteActor)))
-	mux.HandleFunc("/delete/movie", authMiddleware(onlyDeleteMiddleware(h.deleteMovie)))
+		"GET /actors":                               h.getActors,
+		"POST /actors":                              authMiddleware(h.addActor),
+		"POST /actors/batch":                        authMiddleware(h.batchActors),
+		"GET /actors/separation":                    h.getSeparation,
+		"GET /actors/{id}":                          h.getActor,
+		"PATCH /actors/{id}":                        authMiddleware(h.editActor),
+		"DELETE /actors/{id}":                       authMiddleware(h.deleteActor),
+		"POST /actors/{id}/movies":                  authMiddleware(h.addMoviesToActor),
+		"GET /actors/{id}/costars":                  h.getCostars,
+		"GET /actors/{id}/translations":             h.getActorTranslations,
+		"POST /actors/{id}/translations":            authMiddleware(h.translateActor),
+		"DELETE /actors/{id}/translations/{locale}": authMiddleware(h.deleteActorTranslation),
+		"GET /actors/{id}/images":                   h.getActorImages,
+		"POST /actors/{id}/images":                  authMiddleware(h.uploadActorImage),
 
-	mux.HandleFunc("/actor/add/movies", authMiddleware(onlyPostMiddleware(h.addMoviesToActor)))
-	mux.HandleFunc("/movie/add/actors", authMiddleware(onlyPostMiddleware(h.addActorsToMovie)))
+		"POST /people":                 authMiddleware(h.addPerson),
+		"GET /people/{id}/filmography": h.getFilmography,
 
-	mux.HandleFunc("/get/actors", onlyGetMiddleware(h.getActors))
-	mux.HandleFunc("/get/movies", onlyGetMiddleware(h.getMoviesSorted))
+		"GET /genres":         h.getGenres,
+		"POST /genres":        authMiddleware(h.addGenre),
+		"PATCH /genres/{id}":  authMiddleware(h.editGenre),
+		"DELETE /genres/{id}": authMiddleware(h.deleteGenre),
 
-	mux.HandleFunc("/find/movie", onlyPostMiddleware(h.getMovie))
+		"GET /franchises":             h.getFranchises,
+		"POST /franchises":            authMiddleware(h.addFranchise),
+		"GET /franchises/{id}":        h.getFranchise,
+		"PATCH /franchises/{id}":      authMiddleware(h.editFranchise),
+		"DELETE /franchises/{id}":     authMiddleware(h.deleteFranchise),
+		"PUT /franchises/{id}/movies": authMiddleware(h.setFranchiseMovies),
 
-	mux.HandleFunc("/login", onlyPostMiddleware(h.loginUser))
-	mux.HandleFunc("/create/user", onlyPostMiddleware(h.createUser))
+		"GET /awards":                  h.getAwards,
+		"POST /awards":                 authMiddleware(h.addAward),
+		"DELETE /awards/{id}":          authMiddleware(h.deleteAward),
+		"POST /awards/{id}/ceremonies": authMiddleware(h.addCeremony),
+		"POST /awards/{id}/categories": authMiddleware(h.addAwardCategory),
+		"GET /nominations":             h.getNominations,
+		"POST /nominations":            authMiddleware(h.addNomination),
+		"DELETE /nominations/{id}":     authMiddleware(h.deleteNomination),
 
-	return mux
+		"GET /images/{id}":    h.getImage,
+		"DELETE /images/{id}": authMiddleware(h.deleteImage),
+
+		"PATCH /reviews/{id}":  authMiddleware(h.moderateReview),
+		"DELETE /reviews/{id}": authMiddleware(h.deleteReview),
+
+		"GET /collections":                           h.getPublicCollections,
+		"POST /collections":                          userAuthMiddleware(h.addCollection),
+		"GET /collections/{slug}":                    optionalUserAuthMiddleware(h.getCollection),
+		"PATCH /collections/{id}":                    userAuthMiddleware(h.editCollection),
+		"DELETE /collections/{id}":                   userAuthMiddleware(h.deleteCollection),
+		"POST /collections/{id}/movies":              userAuthMiddleware(h.addMoviesToCollection),
+		"PUT /collections/{id}/movies":               userAuthMiddleware(h.reorderCollection),
+		"DELETE /collections/{id}/movies/{movie_id}": userAuthMiddleware(h.deleteMovieFromCollection),
+
+		"GET /me/watchlist":               userAuthMiddleware(h.getWatchlist),
+		"POST /me/watchlist":              userAuthMiddleware(h.addToWatchlist),
+		"PUT /me/watchlist":               userAuthMiddleware(h.reorderWatchlist),
+		"DELETE /me/watchlist/{movie_id}": userAuthMiddleware(h.deleteFromWatchlist),
+		"GET /me/watched":                 userAuthMiddleware(h.getWatched),
+		"POST /me/watched":                userAuthMiddleware(h.markWatched),
+		"DELETE /me/watched/{id}":         userAuthMiddleware(h.deleteWatched),
+		"GET /me/collections":             userAuthMiddleware(h.getUserCollections),
+		"GET /me/recommendations":         userAuthMiddleware(h.getUserRecommendations),
+
+		"GET /catalog/export":  authMiddleware(h.exportCatalog),
+		"POST /catalog/import": authMiddleware(h.importCatalog),
+
+		"POST /users": h.createUser,
+		"POST /login": h.loginUser,
+	}
+}
+
+// initLegacyRoutes keeps the verb-prefixed routes working for existing clients, their
+// responses point to the resource route that replaces them.
+func (h *Handler) initLegacyRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /edit/actor", deprecated("/api/v1/actors/{id}", authMiddleware(h.editActor)))
+	mux.HandleFunc("POST /edit/movie", deprecated("/api/v1/movies/{id}", authMiddleware(h.editMovie)))
+
+	mux.HandleFunc("POST /create/actor", deprecated("/api/v1/actors", authMiddleware(h.addActor)))
+	mux.HandleFunc("POST /create/movie", deprecated("/api/v1/movies", authMiddleware(h.addMovie)))
+
+	mux.HandleFunc("DELETE /delete/actor", deprecated("/api/v1/actors/{id}", authMiddleware(h.deleteActor)))
+	mux.HandleFunc("DELETE /delete/movie", deprecated("/api/v1/movies/{id}", authMiddleware(h.deleteMovie)))
+
+	mux.HandleFunc("POST /actor/add/movies", deprecated("/api/v1/actors/{id}/movies", authMiddleware(h.addMoviesToActor)))
+	mux.HandleFunc("POST /movie/add/actors", deprecated("/api/v1/movies/{id}/actors", authMiddleware(h.addActorsToMovie)))
+
+	mux.HandleFunc("GET /get/actors", deprecated("/api/v1/actors", h.getActors))
+	mux.HandleFunc("GET /get/movies", deprecated("/api/v1/movies", h.getMoviesSorted))
+
+	mux.HandleFunc("POST /find/movie", deprecated("/api/v1/movies/search", h.getMovie))
+
+	mux.HandleFunc("POST /create/user", deprecated("/api/v1/users", h.createUser))
+	mux.HandleFunc("POST /login", deprecated("/api/v1/login", h.loginUser))
 }
 
-func onlyGetMiddleware(next http.HandlerFunc) http.HandlerFunc {
+// deprecated marks the response of a legacy route as deprecated and links the route
+// that replaces it.
+func deprecated(successor string, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "only GET method is allowed", http.StatusMethodNotAllowed)
-			return
-		}
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
 		next.ServeHTTP(w, r)
 	}
 }
 
-func onlyPostMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "only POST method is allowed", http.StatusMethodNotAllowed)
-			return
-		}
-		next.ServeHTTP(w, r)
+// requestParam reads the path wildcard of a resource route, the deprecated routes carry
+// the same value in the query parameter of the same name.
+func requestParam(r *http.Request, name string) string {
+	if v := r.PathValue(name); v != "" {
+		return v
 	}
+	return r.URL.Query().Get(name)
 }
 
-func onlyDeleteMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodDelete {
-			http.Error(w, "only POST method is allowed", http.StatusMethodNotAllowed)
-			return
+// bindPathID overwrites the ID decoded from the request body with the path wildcard of
+// a resource route, the deprecated routes only carry it in the body.
+func bindPathID(r *http.Request, name string, id *int64) error {
+	v := r.PathValue(name)
+	if v == "" {
+		return nil
+	}
+
+	pathID, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", name, v)
+	}
+	*id = pathID
+
+	return nil
+}
+
+// representationETag tags a rendered movie or actor with its version followed by a
+// hash of the body and the languages it was rendered in. The cast, the ratings, the
+// translations and the relations change the body without moving the version, so a
+// tag of the version alone would keep stale copies fresh.
+func representationETag(version int64, body []byte, langs []string) string {
+	hash := fnv.New64a()
+	hash.Write(body)
+	hash.Write([]byte("\n" + strings.Join(langs, ",")))
+
+	return fmt.Sprintf(`"%d-%x"`, version, hash.Sum64())
+}
+
+// notModified sets the ETag and reports whether If-None-Match already names it, the
+// response is then answered with 304 Not Modified.
+func notModified(w http.ResponseWriter, r *http.Request, tag string) bool {
+	w.Header().Set("ETag", tag)
+
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == tag || candidate == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return true
 		}
-		next.ServeHTTP(w, r)
 	}
+
+	return false
 }
 
-func authMiddleware(next http.Handler) http.HandlerFunc {
+// ifMatchVersion reads the version an update or a delete is based on from If-Match,
+// ok is false when the header is missing. The tag is the ETag of a read, only the
+// version is read from it and the hash after it is left out, or the version alone. The tag * matches any version,
+// it reads as storage.AnyVersion. Any other tag that is not a version reads as 0,
+// which no movie or actor is at, so the request fails as if the version had moved.
+func ifMatchVersion(r *http.Request) (version int64, ok bool) {
+	tag := strings.TrimSpace(r.Header.Get("If-Match"))
+	if tag == "" {
+		return 0, false
+	}
+	if tag == "*" {
+		return storage.AnyVersion, true
+	}
+
+	tag, _, _ = strings.Cut(strings.Trim(tag, `"`), "-")
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return 0, true
+	}
+
+	return version, true
+}
+
+// validRequest checks the decoded request against the validate tags of its fields and,
+// when it breaks any, answers 422 Unprocessable Entity with the broken rules by field.
+func validRequest(w http.ResponseWriter, log *slog.Logger, request interface{}) bool {
+	err := validate.Struct(request)
+	if err == nil {
+		return true
+	}
+
+	invalidRequest(w, log, err.(validate.Errors))
+
+	return false
+}
+
+// invalidRequest answers 422 Unprocessable Entity with the broken rules by field.
+func invalidRequest(w http.ResponseWriter, log *slog.Logger, errs validate.Errors) {
+	log.Error("invalid request", sl.Err(errs))
+
+	fieldErrs := make([]models.FieldError, len(errs))
+	for i, fieldErr := range errs {
+		fieldErrs[i] = models.FieldError{Field: fieldErr.Field, Message: fieldErr.Message}
+	}
+
+	body, err := json.Marshal(models.ValidationErrors{Errors: fieldErrs})
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	w.Write(body)
+}
+
+func authMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
@@ -143,3 +440,90 @@ func authMiddleware(next http.Handler) http.HandlerFunc {
 		}
 	}
 }
+
+// userAuthMiddleware lets through any user with a valid token and puts their ID into the request context.
+func userAuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			http.Error(w, "Authorization header missing", http.StatusUnauthorized)
+			return
+		}
+
+		userID, err := parseUserToken(authHeader)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userIDKey, userID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
+
+// optionalUserAuthMiddleware puts the user ID into the request context when the request
+// carries a valid token and lets anonymous requests through unchanged.
+func optionalUserAuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID, err := parseUserToken(authHeader)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userIDKey, userID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
+
+func parseUserToken(authHeader string) (int64, error) {
+	tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
+
+	claims := &models.Token{StandardClaims: &jwt.StandardClaims{}}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte("secret"), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !token.Valid || claims.UserID == 0 {
+		return 0, errors.New("invalid token")
+	}
+
+	return claims.UserID, nil
+}
+
+func userIDFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDKey).(int64)
+	return userID, ok
+}
+
+// parsePagination reads the page and limit query parameters, page numbers start at 1.
+func parsePagination(r *http.Request) (page int, limit int, err error) {
+	page, limit = 1, defaultPageLimit
+
+	if v := r.URL.Query().Get("page"); v != "" {
+		page, err = strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("invalid page %q", v)
+		}
+	}
+
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return 0, 0, fmt.Errorf("invalid limit %q", v)
+		}
+	}
+
+	return page, limit, nil
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"encoding/json"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_InitRoutes(t *testing.T) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"Role": "admin"}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		admin          bool
+		wantStatus     int
+		wantDeprecated string
+	}{
+		{name: "Version 1", method: http.MethodGet, target: "/api/v1/movies/2", wantStatus: http.StatusOK},
+		{name: "Version 2", method: http.MethodGet, target: "/api/v2/movies/2", wantStatus: http.StatusOK},
+		{name: "Unknown version", method: http.MethodGet, target: "/api/v3/movies/2", wantStatus: http.StatusNotFound},
+		{name: "Resource route without version", method: http.MethodGet, target: "/movies/2", wantStatus: http.StatusNotFound},
+		{name: "New route without version", method: http.MethodGet, target: "/genres", wantStatus: http.StatusNotFound},
+		{name: "Legacy route", method: http.MethodGet, target: "/get/movies", wantStatus: http.StatusOK, wantDeprecated: "</api/v1/movies>; rel=\"successor-version\""},
+		{name: "Resource route without legacy path", method: http.MethodGet, target: "/get/movie?id=2", wantStatus: http.StatusNotFound},
+		{name: "Method not allowed", method: http.MethodPut, target: "/api/v1/movies/2", wantStatus: http.StatusMethodNotAllowed},
+		{name: "Admin route without token", method: http.MethodDelete, target: "/api/v1/movies/2", wantStatus: http.StatusUnauthorized},
+		{name: "ID from path", method: http.MethodPatch, target: "/api/v1/movies/2", body: `{"id":7,"title":"The Godfather Part II"}`, admin: true, wantStatus: http.StatusOK},
+		{name: "Invalid ID in path", method: http.MethodPatch, target: "/api/v1/movies/two", body: `{"title":"The Godfather Part II"}`, admin: true, wantStatus: http.StatusBadRequest},
+		{name: "Legacy ID from body", method: http.MethodPost, target: "/edit/movie", body: `{"id":2,"title":"The Godfather Part II"}`, admin: true, wantStatus: http.StatusOK, wantDeprecated: "</api/v1/movies/{id}>; rel=\"successor-version\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieMock := &mocks.MovieProvider{}
+			movieMock.On("GetMovieDetail", int64(2), []string(nil)).Return(&models.MovieDetail{
+				MovieListing: &models.MovieListing{ID: 2, Title: "The Godfather Part II", Actors: []string{}},
+			}, nil)
+			movieMock.On("GetMoviesSorted", "", "", models.MovieFilter{}, []string(nil)).Return([]*models.MovieListing{}, nil)
+			movieMock.On("EditMovie", int64(2), int64(1), mock.AnythingOfType("*models.MoviePatch")).Return(int64(2), nil)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				movieProvider: movieMock,
+			}
+
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.admin {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+			r.Header.Set("If-Match", `"1"`)
+			w := httptest.NewRecorder()
+			h.InitRoutes().ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantDeprecated != "" {
+				assert.Equal(t, "true", w.Header().Get("Deprecation"))
+				assert.Equal(t, tt.wantDeprecated, w.Header().Get("Link"))
+			} else {
+				assert.Empty(t, w.Header().Get("Deprecation"))
+			}
+		})
+	}
+}
+
+func TestHandler_ConditionalRequests(t *testing.T) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"Role": "admin"}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	detail := &models.MovieDetail{
+		MovieListing: &models.MovieListing{ID: 2, Title: "The Godfather Part II", Actors: []string{}, Version: 3},
+	}
+	body, err := json.Marshal(newMovieDetailResponse(detail))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag := representationETag(3, body, nil)
+
+	tests := []struct {
+		name        string
+		method      string
+		ifMatch     string
+		ifNoneMatch string
+		wantStatus  int
+		wantETag    string
+	}{
+		{name: "ETag on read", method: http.MethodGet, wantStatus: http.StatusOK, wantETag: tag},
+		{name: "Cached version", method: http.MethodGet, ifNoneMatch: tag, wantStatus: http.StatusNotModified, wantETag: tag},
+		{name: "Cached weak version", method: http.MethodGet, ifNoneMatch: `"1", W/` + tag, wantStatus: http.StatusNotModified, wantETag: tag},
+		{name: "Outdated cache", method: http.MethodGet, ifNoneMatch: `"3"`, wantStatus: http.StatusOK, wantETag: tag},
+		{name: "Edit without If-Match", method: http.MethodPatch, wantStatus: http.StatusPreconditionRequired},
+		{name: "Edit of the read version", method: http.MethodPatch, ifMatch: tag, wantStatus: http.StatusOK},
+		{name: "Edit of the current version", method: http.MethodPatch, ifMatch: `"3"`, wantStatus: http.StatusOK},
+		{name: "Edit of a stale version", method: http.MethodPatch, ifMatch: `"2"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "Edit of any version", method: http.MethodPatch, ifMatch: "*", wantStatus: http.StatusOK},
+		{name: "Edit with a malformed tag", method: http.MethodPatch, ifMatch: "three", wantStatus: http.StatusPreconditionFailed},
+		{name: "Delete without If-Match", method: http.MethodDelete, wantStatus: http.StatusPreconditionRequired},
+		{name: "Delete of a stale version", method: http.MethodDelete, ifMatch: `"2"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "Delete of the current version", method: http.MethodDelete, ifMatch: `"3"`, wantStatus: http.StatusOK},
+		{name: "Delete of any version", method: http.MethodDelete, ifMatch: "*", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieMock := &mocks.MovieProvider{}
+			movieMock.On("GetMovieDetail", int64(2), []string(nil)).Return(detail, nil)
+			movieMock.On("EditMovie", int64(2), int64(3), mock.AnythingOfType("*models.MoviePatch")).Return(int64(4), nil)
+			movieMock.On("EditMovie", int64(2), storage.AnyVersion, mock.AnythingOfType("*models.MoviePatch")).Return(int64(4), nil)
+			movieMock.On("EditMovie", int64(2), mock.AnythingOfType("int64"), mock.AnythingOfType("*models.MoviePatch")).
+				Return(int64(0), fmt.Errorf("service.EditMovie: %w", storage.ErrVersionMismatch))
+			movieMock.On("DeleteMovie", int64(2), int64(3)).Return(nil)
+			movieMock.On("DeleteMovie", int64(2), storage.AnyVersion).Return(nil)
+			movieMock.On("DeleteMovie", int64(2), mock.AnythingOfType("int64")).
+				Return(fmt.Errorf("service.DeleteMovie: %w", storage.ErrVersionMismatch))
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				movieProvider: movieMock,
+			}
+
+			r := httptest.NewRequest(tt.method, "/api/v1/movies/2", strings.NewReader(`{"title":"The Godfather Part II"}`))
+			r.Header.Set("Authorization", "Bearer "+token)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			h.InitRoutes().ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantETag, w.Header().Get("ETag"))
+			if tt.wantStatus == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestHandler_ConditionalRequests_Representation checks that the ETag of a movie moves
+// with what is rendered, not only with the version of the movie.
+func TestHandler_ConditionalRequests_Representation(t *testing.T) {
+	votes := func(count int64) *models.MovieDetail {
+		return &models.MovieDetail{
+			MovieListing: &models.MovieListing{ID: 2, Title: "The Godfather Part II", Actors: []string{}, VotesCount: count, Version: 3},
+		}
+	}
+
+	tags := map[string]string{}
+	for _, tt := range []struct {
+		name           string
+		acceptLanguage string
+		langs          []string
+		detail         *models.MovieDetail
+	}{
+		{name: "Read", detail: votes(10)},
+		{name: "New review", detail: votes(11)},
+		{name: "Other language", acceptLanguage: "de", langs: []string{"de"}, detail: votes(10)},
+	} {
+		movieMock := &mocks.MovieProvider{}
+		movieMock.On("GetMovieDetail", int64(2), tt.langs).Return(tt.detail, nil)
+		h := &Handler{
+			log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+			movieProvider: movieMock,
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/movies/2", nil)
+		if tt.acceptLanguage != "" {
+			r.Header.Set("Accept-Language", tt.acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		h.InitRoutes().ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+		tags[tt.name] = w.Header().Get("ETag")
+	}
+
+	assert.NotEqual(t, tags["Read"], tags["New review"])
+	assert.NotEqual(t, tags["Read"], tags["Other language"])
+}
+
+func TestHandler_InitRoutes_Swagger(t *testing.T) {
+	h := &Handler{log: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))}
+	mux := h.InitRoutes()
+
+	for _, version := range h.apiVersions() {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/"+version.name+"/swagger/doc.json", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"basePath": "/api/`+version.name+`"`)
+	}
+}
+
+// withIfMatch sets If-Match to the version, as a client editing that version does.
+func withIfMatch(r *http.Request, version int64) *http.Request {
+	r.Header.Set("If-Match", fmt.Sprintf(`"%d"`, version))
+	return r
+}
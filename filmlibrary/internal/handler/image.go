@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"filmlibrary/internal/storage/blob"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// imageFormField is the multipart form field images are uploaded in.
+const imageFormField = "image"
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name ImageProvider
+type ImageProvider interface {
+	UploadImage(ownerType string, ownerID int64, r io.Reader) (*models.Image, error)
+	GetImages(ownerType string, ownerID int64) ([]*models.Image, error)
+	GetImage(id int64, size string) (io.ReadCloser, string, error)
+	DeleteImage(id int64) error
+}
+
+// @Summary Upload movie image
+// @Security ApiKeyAuth
+// @Description Attaches a JPEG or PNG image, such as a poster, to a movie. Small, medium and large thumbnails are generated from it.
+// @Tags Images
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param image formData file true "JPEG or PNG image"
+// @Success 201 {object} models.Image
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 413 {string} string "Image is too large"
+// @Failure 415 {string} string "Unsupported image type"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/images [post]
+func (h *Handler) uploadMovieImage(w http.ResponseWriter, r *http.Request) {
+	h.uploadImage(w, r, "handler.uploadMovieImage", models.ImageOwnerMovie)
+}
+
+// @Summary Upload actor image
+// @Security ApiKeyAuth
+// @Description Attaches a JPEG or PNG image, such as a headshot, to an actor. Small, medium and large thumbnails are generated from it.
+// @Tags Images
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Param image formData file true "JPEG or PNG image"
+// @Success 201 {object} models.Image
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 413 {string} string "Image is too large"
+// @Failure 415 {string} string "Unsupported image type"
+// @Failure 500 {string} string "Internal server error"
+// @Router /actors/{id}/images [post]
+func (h *Handler) uploadActorImage(w http.ResponseWriter, r *http.Request) {
+	h.uploadImage(w, r, "handler.uploadActorImage", models.ImageOwnerActor)
+}
+
+func (h *Handler) uploadImage(w http.ResponseWriter, r *http.Request, op string, ownerType string) {
+	log := h.log.With(slog.String("op", op))
+
+	ownerID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid "+ownerType+" ID", sl.Err(err))
+		http.Error(w, "invalid "+ownerType+" ID", http.StatusBadRequest)
+		return
+	}
+
+	parts, err := r.MultipartReader()
+	if err != nil {
+		log.Error("request is not multipart", sl.Err(err))
+		http.Error(w, "image must be sent as multipart/form-data", http.StatusBadRequest)
+		return
+	}
+
+	// stream the image part to the service instead of buffering the whole form
+	var image *models.Image
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			log.Error("image is missing in the form")
+			http.Error(w, "image is missing in the form", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			log.Error("failed to read multipart form", sl.Err(err))
+			http.Error(w, "failed to read multipart form", http.StatusBadRequest)
+			return
+		}
+		if part.FormName() != imageFormField {
+			part.Close()
+			continue
+		}
+
+		image, err = h.imageProvider.UploadImage(ownerType, ownerID, part)
+		part.Close()
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrImageTooLarge):
+				log.Error("image is too large", sl.Err(err))
+				http.Error(w, service.ErrImageTooLarge.Error(), http.StatusRequestEntityTooLarge)
+			case errors.Is(err, service.ErrImageTooManyPixels):
+				log.Error("image dimensions are too large", sl.Err(err))
+				http.Error(w, service.ErrImageTooManyPixels.Error(), http.StatusRequestEntityTooLarge)
+			case errors.Is(err, service.ErrUnsupportedImageType):
+				log.Error("unsupported image type", sl.Err(err))
+				http.Error(w, service.ErrUnsupportedImageType.Error(), http.StatusUnsupportedMediaType)
+			case errors.Is(err, service.ErrInvalidImage):
+				log.Error("invalid image", sl.Err(err))
+				http.Error(w, service.ErrInvalidImage.Error(), http.StatusBadRequest)
+			case errors.Is(err, storage.ErrMovieNotFound), errors.Is(err, storage.ErrPersonNotFound):
+				log.Error(ownerType+" not found", sl.Err(err))
+				http.Error(w, ownerType+" not found", http.StatusNotFound)
+			default:
+				log.Error("failed to upload an image", sl.Err(err))
+				http.Error(w, "failed to upload an image", http.StatusInternalServerError)
+			}
+			return
+		}
+		break
+	}
+
+	imageJSON, err := json.Marshal(image)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(imageJSON)
+}
+
+// @Summary Get movie images
+// @Description Lists the images of a movie, oldest first.
+// @Tags Images
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Success 200 {array} models.Image
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/images [get]
+func (h *Handler) getMovieImages(w http.ResponseWriter, r *http.Request) {
+	h.listImages(w, r, "handler.getMovieImages", models.ImageOwnerMovie, r.PathValue("id"))
+}
+
+// @Summary Get actor images
+// @Description Lists the images of an actor, oldest first.
+// @Tags Images
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Success 200 {array} models.Image
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /actors/{id}/images [get]
+func (h *Handler) getActorImages(w http.ResponseWriter, r *http.Request) {
+	h.listImages(w, r, "handler.getActorImages", models.ImageOwnerActor, r.PathValue("id"))
+}
+
+func (h *Handler) listImages(w http.ResponseWriter, r *http.Request, op string, ownerType string, idParam string) {
+	log := h.log.With(slog.String("op", op))
+
+	ownerID, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		log.Error("invalid owner ID", sl.Err(err))
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	images, err := h.imageProvider.GetImages(ownerType, ownerID)
+	if err != nil {
+		log.Error("failed to fetch images", sl.Err(err))
+		http.Error(w, "failed to fetch images", http.StatusInternalServerError)
+		return
+	}
+
+	imagesJSON, err := json.Marshal(images)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(imagesJSON)
+}
+
+// @Summary Download image
+// @Description Downloads an image in its original size or as a thumbnail.
+// @Tags Images
+// @Produce image/jpeg
+// @Produce image/png
+// @Param id path int true "Image ID"
+// @Param size query string false "original, small, medium or large" default(original)
+// @Success 200 {file} file "Image"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /images/{id} [get]
+func (h *Handler) getImage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getImage"
+
+	log := h.log.With(slog.String("op", op))
+
+	imageID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid image ID", sl.Err(err))
+		http.Error(w, "invalid image ID", http.StatusBadRequest)
+		return
+	}
+
+	size := r.URL.Query().Get("size")
+	if size == "" {
+		size = service.ImageSizeOriginal
+	}
+
+	image, contentType, err := h.imageProvider.GetImage(imageID, size)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidImageSize):
+			log.Error("invalid image size", sl.Err(err))
+			http.Error(w, service.ErrInvalidImageSize.Error(), http.StatusBadRequest)
+		case errors.Is(err, storage.ErrImageNotFound), errors.Is(err, blob.ErrNotFound):
+			log.Error("image not found", sl.Err(err))
+			http.Error(w, "image not found", http.StatusNotFound)
+		default:
+			log.Error("failed to fetch an image", sl.Err(err))
+			http.Error(w, "failed to fetch an image", http.StatusInternalServerError)
+		}
+		return
+	}
+	defer image.Close()
+
+	// an image never changes once uploaded, a new upload gets a new ID
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, image); err != nil {
+		log.Error("failed to send an image", sl.Err(err))
+	}
+}
+
+// @Summary Delete image
+// @Security ApiKeyAuth
+// @Description Deletes an image with all of its thumbnails.
+// @Tags Images
+// @Accept json
+// @Produce json
+// @Param id path int true "Image ID to be deleted"
+// @Success 200 {string} string "Successfully deleted an image"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /images/{id} [delete]
+func (h *Handler) deleteImage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteImage"
+
+	log := h.log.With(slog.String("op", op))
+
+	imageID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid image ID", sl.Err(err))
+		http.Error(w, "invalid image ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.imageProvider.DeleteImage(imageID)
+	if err != nil {
+		if errors.Is(err, storage.ErrImageNotFound) {
+			log.Error("image not found", sl.Err(err))
+			http.Error(w, "image not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to delete an image", sl.Err(err))
+		http.Error(w, "failed to delete an image", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted an image"))
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func multipartImage(t *testing.T, field string, data []byte) (*bytes.Buffer, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile(field, "poster.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	form.Close()
+
+	return body, form.FormDataContentType()
+}
+
+func TestHandler_uploadMovieImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Upload success",
+			field:       "image",
+			wantStatus:  http.StatusCreated,
+			wantMessage: `{"id":3,"owner_type":"movie","owner_id":1,"content_type":"image/png","size":4,"width":0,"height":0,"sizes":null,"created_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:        "Too large",
+			field:       "image",
+			providerErr: fmt.Errorf("service.UploadImage: %w", service.ErrImageTooLarge),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantMessage: service.ErrImageTooLarge.Error(),
+		},
+		{
+			name:        "Too many pixels",
+			field:       "image",
+			providerErr: fmt.Errorf("service.UploadImage: %w", service.ErrImageTooManyPixels),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantMessage: service.ErrImageTooManyPixels.Error(),
+		},
+		{
+			name:        "Unsupported type",
+			field:       "image",
+			providerErr: fmt.Errorf("service.UploadImage: %w", service.ErrUnsupportedImageType),
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantMessage: service.ErrUnsupportedImageType.Error(),
+		},
+		{
+			name:        "Movie not found",
+			field:       "image",
+			providerErr: fmt.Errorf("service.UploadImage: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+		{
+			name:        "Image is missing",
+			field:       "poster",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "image is missing in the form",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imageMock := &mocks.ImageProvider{}
+			imageMock.On("UploadImage", models.ImageOwnerMovie, int64(1), mock.Anything).
+				Run(func(args mock.Arguments) {
+					data, _ := io.ReadAll(args.Get(2).(io.Reader))
+					assert.Equal(t, "\x89PNG", string(data))
+				}).
+				Return(&models.Image{ID: 3, OwnerType: models.ImageOwnerMovie, OwnerID: 1, ContentType: "image/png", Size: 4}, tt.providerErr)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				imageProvider: imageMock,
+			}
+
+			body, contentType := multipartImage(t, tt.field, []byte("\x89PNG"))
+			r := httptest.NewRequest(http.MethodPost, "/movie/add/image?id=1", body)
+			r.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+			h.uploadMovieImage(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getImage(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          string
+		wantSize        string
+		providerErr     error
+		wantStatus      int
+		wantContentType string
+	}{
+		{name: "Original by default", target: "/get/image?id=3", wantSize: "original", wantStatus: http.StatusOK, wantContentType: "image/png"},
+		{name: "Thumbnail", target: "/get/image?id=3&size=small", wantSize: "small", wantStatus: http.StatusOK, wantContentType: "image/png"},
+		{
+			name:        "Unknown size",
+			target:      "/get/image?id=3&size=huge",
+			wantSize:    "huge",
+			providerErr: fmt.Errorf("service.GetImage: %w", service.ErrInvalidImageSize),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "Image not found",
+			target:      "/get/image?id=3",
+			wantSize:    "original",
+			providerErr: fmt.Errorf("service.GetImage: %w", storage.ErrImageNotFound),
+			wantStatus:  http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imageMock := &mocks.ImageProvider{}
+			imageMock.On("GetImage", int64(3), tt.wantSize).Return(io.NopCloser(strings.NewReader("\x89PNG")), "image/png", tt.providerErr)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				imageProvider: imageMock,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+			h.getImage(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+				assert.Equal(t, "\x89PNG", w.Body.String())
+			}
+		})
+	}
+}
@@ -49,17 +49,47 @@ func (_m *ActorProvider) AddMoviesToActor(actorID int64, movies []int64) error {
 	return r0
 }
 
-// DeleteActor provides a mock function with given fields: id
-func (_m *ActorProvider) DeleteActor(id int64) error {
-	ret := _m.Called(id)
+// BatchActors provides a mock function with given fields: items, size, atomic
+func (_m *ActorProvider) BatchActors(items []*models.ActorBatchItem, size int, atomic bool) ([]*models.BatchResult, error) {
+	ret := _m.Called(items, size, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchActors")
+	}
+
+	var r0 []*models.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*models.ActorBatchItem, int, bool) ([]*models.BatchResult, error)); ok {
+		return rf(items, size, atomic)
+	}
+	if rf, ok := ret.Get(0).(func([]*models.ActorBatchItem, int, bool) []*models.BatchResult); ok {
+		r0 = rf(items, size, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*models.ActorBatchItem, int, bool) error); ok {
+		r1 = rf(items, size, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteActor provides a mock function with given fields: id, version
+func (_m *ActorProvider) DeleteActor(id int64, version int64) error {
+	ret := _m.Called(id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteActor")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -67,27 +97,67 @@ func (_m *ActorProvider) DeleteActor(id int64) error {
 	return r0
 }
 
-// EditActor provides a mock function with given fields: actor
-func (_m *ActorProvider) EditActor(actor *models.Actor) error {
-	ret := _m.Called(actor)
+// EditActor provides a mock function with given fields: id, version, patch
+func (_m *ActorProvider) EditActor(id int64, version int64, patch *models.ActorPatch) (int64, error) {
+	ret := _m.Called(id, version, patch)
 
 	if len(ret) == 0 {
 		panic("no return value specified for EditActor")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Actor) error); ok {
-		r0 = rf(actor)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, *models.ActorPatch) (int64, error)); ok {
+		return rf(id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, *models.ActorPatch) int64); ok {
+		r0 = rf(id, version, patch)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(int64, int64, *models.ActorPatch) error); ok {
+		r1 = rf(id, version, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActor provides a mock function with given fields: id, langs
+func (_m *ActorProvider) GetActor(id int64, langs []string) (*models.Actor, error) {
+	ret := _m.Called(id, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActor")
+	}
+
+	var r0 *models.Actor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, []string) (*models.Actor, error)); ok {
+		return rf(id, langs)
+	}
+	if rf, ok := ret.Get(0).(func(int64, []string) *models.Actor); ok {
+		r0 = rf(id, langs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Actor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, []string) error); ok {
+		r1 = rf(id, langs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetActors provides a mock function with given fields:
-func (_m *ActorProvider) GetActors() ([]*models.ActorListing, error) {
-	ret := _m.Called()
+// GetActors provides a mock function with given fields: langs
+func (_m *ActorProvider) GetActors(langs []string) ([]*models.ActorListing, error) {
+	ret := _m.Called(langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetActors")
@@ -95,19 +165,19 @@ func (_m *ActorProvider) GetActors() ([]*models.ActorListing, error) {
 
 	var r0 []*models.ActorListing
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*models.ActorListing, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func([]string) ([]*models.ActorListing, error)); ok {
+		return rf(langs)
 	}
-	if rf, ok := ret.Get(0).(func() []*models.ActorListing); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func([]string) []*models.ActorListing); ok {
+		r0 = rf(langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.ActorListing)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(langs)
 	} else {
 		r1 = ret.Error(1)
 	}
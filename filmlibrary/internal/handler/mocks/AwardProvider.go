@@ -0,0 +1,196 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AwardProvider is an autogenerated mock type for the AwardProvider type
+type AwardProvider struct {
+	mock.Mock
+}
+
+// AddAward provides a mock function with given fields: award
+func (_m *AwardProvider) AddAward(award *models.Award) error {
+	ret := _m.Called(award)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAward")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Award) error); ok {
+		r0 = rf(award)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddAwardCategory provides a mock function with given fields: category
+func (_m *AwardProvider) AddAwardCategory(category *models.AwardCategory) error {
+	ret := _m.Called(category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAwardCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AwardCategory) error); ok {
+		r0 = rf(category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddCeremony provides a mock function with given fields: ceremony
+func (_m *AwardProvider) AddCeremony(ceremony *models.Ceremony) error {
+	ret := _m.Called(ceremony)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCeremony")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Ceremony) error); ok {
+		r0 = rf(ceremony)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddNomination provides a mock function with given fields: nomination
+func (_m *AwardProvider) AddNomination(nomination *models.Nomination) error {
+	ret := _m.Called(nomination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddNomination")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Nomination) error); ok {
+		r0 = rf(nomination)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAward provides a mock function with given fields: id
+func (_m *AwardProvider) DeleteAward(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAward")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNomination provides a mock function with given fields: id
+func (_m *AwardProvider) DeleteNomination(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNomination")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAwards provides a mock function with given fields:
+func (_m *AwardProvider) GetAwards() ([]*models.Award, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAwards")
+	}
+
+	var r0 []*models.Award
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*models.Award, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*models.Award); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Award)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNominations provides a mock function with given fields: of, id, langs
+func (_m *AwardProvider) GetNominations(of string, id int64, langs []string) ([]*models.NominationListing, error) {
+	ret := _m.Called(of, id, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNominations")
+	}
+
+	var r0 []*models.NominationListing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, []string) ([]*models.NominationListing, error)); ok {
+		return rf(of, id, langs)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, []string) []*models.NominationListing); ok {
+		r0 = rf(of, id, langs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.NominationListing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, []string) error); ok {
+		r1 = rf(of, id, langs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAwardProvider creates a new instance of AwardProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAwardProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AwardProvider {
+	mock := &AwardProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
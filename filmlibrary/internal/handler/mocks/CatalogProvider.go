@@ -0,0 +1,87 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CatalogProvider is an autogenerated mock type for the CatalogProvider type
+type CatalogProvider struct {
+	mock.Mock
+}
+
+// ExportCatalog provides a mock function with given fields: w, kind, format, sortBy, sortDirection, filter, langs
+func (_m *CatalogProvider) ExportCatalog(w io.Writer, kind string, format string, sortBy string, sortDirection string, filter models.MovieFilter, langs []string) (int, error) {
+	ret := _m.Called(w, kind, format, sortBy, sortDirection, filter, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportCatalog")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(io.Writer, string, string, string, string, models.MovieFilter, []string) (int, error)); ok {
+		return rf(w, kind, format, sortBy, sortDirection, filter, langs)
+	}
+	if rf, ok := ret.Get(0).(func(io.Writer, string, string, string, string, models.MovieFilter, []string) int); ok {
+		r0 = rf(w, kind, format, sortBy, sortDirection, filter, langs)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(io.Writer, string, string, string, string, models.MovieFilter, []string) error); ok {
+		r1 = rf(w, kind, format, sortBy, sortDirection, filter, langs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportCatalog provides a mock function with given fields: r, format, dryRun
+func (_m *CatalogProvider) ImportCatalog(r io.Reader, format string, dryRun bool) (*models.ImportReport, error) {
+	ret := _m.Called(r, format, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportCatalog")
+	}
+
+	var r0 *models.ImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(io.Reader, string, bool) (*models.ImportReport, error)); ok {
+		return rf(r, format, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(io.Reader, string, bool) *models.ImportReport); ok {
+		r0 = rf(r, format, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ImportReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(io.Reader, string, bool) error); ok {
+		r1 = rf(r, format, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCatalogProvider creates a new instance of CatalogProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCatalogProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CatalogProvider {
+	mock := &CatalogProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,226 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CollectionProvider is an autogenerated mock type for the CollectionProvider type
+type CollectionProvider struct {
+	mock.Mock
+}
+
+// AddCollection provides a mock function with given fields: collection
+func (_m *CollectionProvider) AddCollection(collection *models.Collection) error {
+	ret := _m.Called(collection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Collection) error); ok {
+		r0 = rf(collection)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddMoviesToCollection provides a mock function with given fields: ownerID, collectionID, movieIDs
+func (_m *CollectionProvider) AddMoviesToCollection(ownerID int64, collectionID int64, movieIDs []int64) error {
+	ret := _m.Called(ownerID, collectionID, movieIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMoviesToCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, []int64) error); ok {
+		r0 = rf(ownerID, collectionID, movieIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCollection provides a mock function with given fields: ownerID, id
+func (_m *CollectionProvider) DeleteCollection(ownerID int64, id int64) error {
+	ret := _m.Called(ownerID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(ownerID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMovieFromCollection provides a mock function with given fields: ownerID, collectionID, movieID
+func (_m *CollectionProvider) DeleteMovieFromCollection(ownerID int64, collectionID int64, movieID int64) error {
+	ret := _m.Called(ownerID, collectionID, movieID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMovieFromCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, int64) error); ok {
+		r0 = rf(ownerID, collectionID, movieID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditCollection provides a mock function with given fields: collection
+func (_m *CollectionProvider) EditCollection(collection *models.Collection) error {
+	ret := _m.Called(collection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Collection) error); ok {
+		r0 = rf(collection)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCollection provides a mock function with given fields: slug, viewerID
+func (_m *CollectionProvider) GetCollection(slug string, viewerID int64) (*models.Collection, error) {
+	ret := _m.Called(slug, viewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollection")
+	}
+
+	var r0 *models.Collection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) (*models.Collection, error)); ok {
+		return rf(slug, viewerID)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) *models.Collection); ok {
+		r0 = rf(slug, viewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Collection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(slug, viewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicCollections provides a mock function with given fields: page, limit
+func (_m *CollectionProvider) GetPublicCollections(page int, limit int) (*models.CollectionsPage, error) {
+	ret := _m.Called(page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicCollections")
+	}
+
+	var r0 *models.CollectionsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*models.CollectionsPage, error)); ok {
+		return rf(page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) *models.CollectionsPage); ok {
+		r0 = rf(page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CollectionsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserCollections provides a mock function with given fields: ownerID
+func (_m *CollectionProvider) GetUserCollections(ownerID int64) ([]*models.Collection, error) {
+	ret := _m.Called(ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserCollections")
+	}
+
+	var r0 []*models.Collection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]*models.Collection, error)); ok {
+		return rf(ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []*models.Collection); ok {
+		r0 = rf(ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Collection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReorderCollection provides a mock function with given fields: ownerID, collectionID, movieIDs
+func (_m *CollectionProvider) ReorderCollection(ownerID int64, collectionID int64, movieIDs []int64) error {
+	ret := _m.Called(ownerID, collectionID, movieIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, []int64) error); ok {
+		r0 = rf(ownerID, collectionID, movieIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCollectionProvider creates a new instance of CollectionProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCollectionProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CollectionProvider {
+	mock := &CollectionProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
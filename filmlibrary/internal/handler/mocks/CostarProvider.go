@@ -0,0 +1,88 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CostarProvider is an autogenerated mock type for the CostarProvider type
+type CostarProvider struct {
+	mock.Mock
+}
+
+// GetCostars provides a mock function with given fields: actorID, page, limit
+func (_m *CostarProvider) GetCostars(actorID int64, page int, limit int) ([]*models.Costar, error) {
+	ret := _m.Called(actorID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCostars")
+	}
+
+	var r0 []*models.Costar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) ([]*models.Costar, error)); ok {
+		return rf(actorID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*models.Costar); ok {
+		r0 = rf(actorID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Costar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(actorID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSeparation provides a mock function with given fields: fromID, toID, maxDepth
+func (_m *CostarProvider) GetSeparation(fromID int64, toID int64, maxDepth int) (*models.Separation, error) {
+	ret := _m.Called(fromID, toID, maxDepth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeparation")
+	}
+
+	var r0 *models.Separation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, int) (*models.Separation, error)); ok {
+		return rf(fromID, toID, maxDepth)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, int) *models.Separation); ok {
+		r0 = rf(fromID, toID, maxDepth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Separation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, int) error); ok {
+		r1 = rf(fromID, toID, maxDepth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCostarProvider creates a new instance of CostarProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCostarProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CostarProvider {
+	mock := &CostarProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,142 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CrewProvider is an autogenerated mock type for the CrewProvider type
+type CrewProvider struct {
+	mock.Mock
+}
+
+// AddCrewToMovie provides a mock function with given fields: movieID, crew
+func (_m *CrewProvider) AddCrewToMovie(movieID int64, crew []models.CrewRole) error {
+	ret := _m.Called(movieID, crew)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCrewToMovie")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, []models.CrewRole) error); ok {
+		r0 = rf(movieID, crew)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddPerson provides a mock function with given fields: person
+func (_m *CrewProvider) AddPerson(person *models.Person) error {
+	ret := _m.Called(person)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPerson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Person) error); ok {
+		r0 = rf(person)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCrewFromMovie provides a mock function with given fields: movieID, personID, role
+func (_m *CrewProvider) DeleteCrewFromMovie(movieID int64, personID int64, role string) error {
+	ret := _m.Called(movieID, personID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCrewFromMovie")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, string) error); ok {
+		r0 = rf(movieID, personID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetFilmography provides a mock function with given fields: personID
+func (_m *CrewProvider) GetFilmography(personID int64) (*models.Filmography, error) {
+	ret := _m.Called(personID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmography")
+	}
+
+	var r0 *models.Filmography
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*models.Filmography, error)); ok {
+		return rf(personID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *models.Filmography); ok {
+		r0 = rf(personID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Filmography)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(personID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovieCrew provides a mock function with given fields: movieID
+func (_m *CrewProvider) GetMovieCrew(movieID int64) ([]*models.CrewMember, error) {
+	ret := _m.Called(movieID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovieCrew")
+	}
+
+	var r0 []*models.CrewMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]*models.CrewMember, error)); ok {
+		return rf(movieID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []*models.CrewMember); ok {
+		r0 = rf(movieID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CrewMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(movieID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCrewProvider creates a new instance of CrewProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCrewProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CrewProvider {
+	mock := &CrewProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
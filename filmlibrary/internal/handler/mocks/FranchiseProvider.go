@@ -0,0 +1,196 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FranchiseProvider is an autogenerated mock type for the FranchiseProvider type
+type FranchiseProvider struct {
+	mock.Mock
+}
+
+// AddFranchise provides a mock function with given fields: franchise
+func (_m *FranchiseProvider) AddFranchise(franchise *models.Franchise) error {
+	ret := _m.Called(franchise)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFranchise")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Franchise) error); ok {
+		r0 = rf(franchise)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFranchise provides a mock function with given fields: id
+func (_m *FranchiseProvider) DeleteFranchise(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFranchise")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMovieRelation provides a mock function with given fields: movieID, relatedMovieID
+func (_m *FranchiseProvider) DeleteMovieRelation(movieID int64, relatedMovieID int64) error {
+	ret := _m.Called(movieID, relatedMovieID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMovieRelation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(movieID, relatedMovieID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditFranchise provides a mock function with given fields: franchise
+func (_m *FranchiseProvider) EditFranchise(franchise *models.Franchise) error {
+	ret := _m.Called(franchise)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditFranchise")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Franchise) error); ok {
+		r0 = rf(franchise)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetFranchise provides a mock function with given fields: id
+func (_m *FranchiseProvider) GetFranchise(id int64) (*models.Franchise, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFranchise")
+	}
+
+	var r0 *models.Franchise
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*models.Franchise, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *models.Franchise); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Franchise)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFranchises provides a mock function with given fields:
+func (_m *FranchiseProvider) GetFranchises() ([]*models.Franchise, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFranchises")
+	}
+
+	var r0 []*models.Franchise
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*models.Franchise, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*models.Franchise); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Franchise)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RelateMovies provides a mock function with given fields: relation
+func (_m *FranchiseProvider) RelateMovies(relation *models.MovieRelation) error {
+	ret := _m.Called(relation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RelateMovies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.MovieRelation) error); ok {
+		r0 = rf(relation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetFranchiseMovies provides a mock function with given fields: franchiseID, movieIDs
+func (_m *FranchiseProvider) SetFranchiseMovies(franchiseID int64, movieIDs []int64) error {
+	ret := _m.Called(franchiseID, movieIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFranchiseMovies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, []int64) error); ok {
+		r0 = rf(franchiseID, movieIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewFranchiseProvider creates a new instance of FranchiseProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFranchiseProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FranchiseProvider {
+	mock := &FranchiseProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GenreProvider is an autogenerated mock type for the GenreProvider type
+type GenreProvider struct {
+	mock.Mock
+}
+
+// AddGenre provides a mock function with given fields: genre
+func (_m *GenreProvider) AddGenre(genre *models.Genre) error {
+	ret := _m.Called(genre)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddGenre")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Genre) error); ok {
+		r0 = rf(genre)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddGenresToMovie provides a mock function with given fields: movieID, genres
+func (_m *GenreProvider) AddGenresToMovie(movieID int64, genres []int64) error {
+	ret := _m.Called(movieID, genres)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddGenresToMovie")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, []int64) error); ok {
+		r0 = rf(movieID, genres)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGenre provides a mock function with given fields: id
+func (_m *GenreProvider) DeleteGenre(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGenre")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditGenre provides a mock function with given fields: genre
+func (_m *GenreProvider) EditGenre(genre *models.Genre) error {
+	ret := _m.Called(genre)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditGenre")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Genre) error); ok {
+		r0 = rf(genre)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGenres provides a mock function with given fields:
+func (_m *GenreProvider) GetGenres() ([]*models.GenreListing, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGenres")
+	}
+
+	var r0 []*models.GenreListing
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*models.GenreListing, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*models.GenreListing); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GenreListing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovieFacets provides a mock function with given fields: filter
+func (_m *GenreProvider) GetMovieFacets(filter models.MovieFilter) (*models.MovieFacets, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovieFacets")
+	}
+
+	var r0 *models.MovieFacets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.MovieFilter) (*models.MovieFacets, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(models.MovieFilter) *models.MovieFacets); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MovieFacets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.MovieFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewGenreProvider creates a new instance of GenreProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGenreProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GenreProvider {
+	mock := &GenreProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
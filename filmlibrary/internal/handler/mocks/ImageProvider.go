@@ -0,0 +1,144 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImageProvider is an autogenerated mock type for the ImageProvider type
+type ImageProvider struct {
+	mock.Mock
+}
+
+// DeleteImage provides a mock function with given fields: id
+func (_m *ImageProvider) DeleteImage(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteImage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetImage provides a mock function with given fields: id, size
+func (_m *ImageProvider) GetImage(id int64, size string) (io.ReadCloser, string, error) {
+	ret := _m.Called(id, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImage")
+	}
+
+	var r0 io.ReadCloser
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int64, string) (io.ReadCloser, string, error)); ok {
+		return rf(id, size)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) io.ReadCloser); ok {
+		r0 = rf(id, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) string); ok {
+		r1 = rf(id, size)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(int64, string) error); ok {
+		r2 = rf(id, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetImages provides a mock function with given fields: ownerType, ownerID
+func (_m *ImageProvider) GetImages(ownerType string, ownerID int64) ([]*models.Image, error) {
+	ret := _m.Called(ownerType, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImages")
+	}
+
+	var r0 []*models.Image
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) ([]*models.Image, error)); ok {
+		return rf(ownerType, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) []*models.Image); ok {
+		r0 = rf(ownerType, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Image)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(ownerType, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadImage provides a mock function with given fields: ownerType, ownerID, r
+func (_m *ImageProvider) UploadImage(ownerType string, ownerID int64, r io.Reader) (*models.Image, error) {
+	ret := _m.Called(ownerType, ownerID, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadImage")
+	}
+
+	var r0 *models.Image
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, io.Reader) (*models.Image, error)); ok {
+		return rf(ownerType, ownerID, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, io.Reader) *models.Image); ok {
+		r0 = rf(ownerType, ownerID, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Image)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, io.Reader) error); ok {
+		r1 = rf(ownerType, ownerID, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewImageProvider creates a new instance of ImageProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImageProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImageProvider {
+	mock := &ImageProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -49,17 +49,47 @@ func (_m *MovieProvider) AddMovie(movie *models.Movie) error {
 	return r0
 }
 
-// DeleteMovie provides a mock function with given fields: id
-func (_m *MovieProvider) DeleteMovie(id int64) error {
-	ret := _m.Called(id)
+// BatchMovies provides a mock function with given fields: items, size, atomic
+func (_m *MovieProvider) BatchMovies(items []*models.MovieBatchItem, size int, atomic bool) ([]*models.BatchResult, error) {
+	ret := _m.Called(items, size, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchMovies")
+	}
+
+	var r0 []*models.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*models.MovieBatchItem, int, bool) ([]*models.BatchResult, error)); ok {
+		return rf(items, size, atomic)
+	}
+	if rf, ok := ret.Get(0).(func([]*models.MovieBatchItem, int, bool) []*models.BatchResult); ok {
+		r0 = rf(items, size, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*models.MovieBatchItem, int, bool) error); ok {
+		r1 = rf(items, size, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMovie provides a mock function with given fields: id, version
+func (_m *MovieProvider) DeleteMovie(id int64, version int64) error {
+	ret := _m.Called(id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteMovie")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -67,27 +97,37 @@ func (_m *MovieProvider) DeleteMovie(id int64) error {
 	return r0
 }
 
-// EditMovie provides a mock function with given fields: movie
-func (_m *MovieProvider) EditMovie(movie *models.Movie) error {
-	ret := _m.Called(movie)
+// EditMovie provides a mock function with given fields: id, version, patch
+func (_m *MovieProvider) EditMovie(id int64, version int64, patch *models.MoviePatch) (int64, error) {
+	ret := _m.Called(id, version, patch)
 
 	if len(ret) == 0 {
 		panic("no return value specified for EditMovie")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Movie) error); ok {
-		r0 = rf(movie)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, *models.MoviePatch) (int64, error)); ok {
+		return rf(id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, *models.MoviePatch) int64); ok {
+		r0 = rf(id, version, patch)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(int64, int64, *models.MoviePatch) error); ok {
+		r1 = rf(id, version, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetMovie provides a mock function with given fields: input
-func (_m *MovieProvider) GetMovie(input string) ([]*models.MovieListing, error) {
-	ret := _m.Called(input)
+// GetMovie provides a mock function with given fields: input, langs
+func (_m *MovieProvider) GetMovie(input string, langs []string) ([]*models.MovieListing, error) {
+	ret := _m.Called(input, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMovie")
@@ -95,19 +135,49 @@ func (_m *MovieProvider) GetMovie(input string) ([]*models.MovieListing, error)
 
 	var r0 []*models.MovieListing
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]*models.MovieListing, error)); ok {
-		return rf(input)
+	if rf, ok := ret.Get(0).(func(string, []string) ([]*models.MovieListing, error)); ok {
+		return rf(input, langs)
 	}
-	if rf, ok := ret.Get(0).(func(string) []*models.MovieListing); ok {
-		r0 = rf(input)
+	if rf, ok := ret.Get(0).(func(string, []string) []*models.MovieListing); ok {
+		r0 = rf(input, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.MovieListing)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(input)
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(input, langs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovieDetail provides a mock function with given fields: id, langs
+func (_m *MovieProvider) GetMovieDetail(id int64, langs []string) (*models.MovieDetail, error) {
+	ret := _m.Called(id, langs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovieDetail")
+	}
+
+	var r0 *models.MovieDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, []string) (*models.MovieDetail, error)); ok {
+		return rf(id, langs)
+	}
+	if rf, ok := ret.Get(0).(func(int64, []string) *models.MovieDetail); ok {
+		r0 = rf(id, langs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MovieDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, []string) error); ok {
+		r1 = rf(id, langs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,9 +185,9 @@ func (_m *MovieProvider) GetMovie(input string) ([]*models.MovieListing, error)
 	return r0, r1
 }
 
-// GetMoviesSorted provides a mock function with given fields: sortBy, sortDirection
-func (_m *MovieProvider) GetMoviesSorted(sortBy string, sortDirection string) ([]*models.MovieListing, error) {
-	ret := _m.Called(sortBy, sortDirection)
+// GetMoviesSorted provides a mock function with given fields: sortBy, sortDirection, filter, langs
+func (_m *MovieProvider) GetMoviesSorted(sortBy string, sortDirection string, filter models.MovieFilter, langs []string) ([]*models.MovieListing, error) {
+	ret := _m.Called(sortBy, sortDirection, filter, langs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMoviesSorted")
@@ -125,19 +195,19 @@ func (_m *MovieProvider) GetMoviesSorted(sortBy string, sortDirection string) ([
 
 	var r0 []*models.MovieListing
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]*models.MovieListing, error)); ok {
-		return rf(sortBy, sortDirection)
+	if rf, ok := ret.Get(0).(func(string, string, models.MovieFilter, []string) ([]*models.MovieListing, error)); ok {
+		return rf(sortBy, sortDirection, filter, langs)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []*models.MovieListing); ok {
-		r0 = rf(sortBy, sortDirection)
+	if rf, ok := ret.Get(0).(func(string, string, models.MovieFilter, []string) []*models.MovieListing); ok {
+		r0 = rf(sortBy, sortDirection, filter, langs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.MovieListing)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(sortBy, sortDirection)
+	if rf, ok := ret.Get(1).(func(string, string, models.MovieFilter, []string) error); ok {
+		r1 = rf(sortBy, sortDirection, filter, langs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RecommendationProvider is an autogenerated mock type for the RecommendationProvider type
+type RecommendationProvider struct {
+	mock.Mock
+}
+
+// GetSimilarMovies provides a mock function with given fields: movieID, page, limit
+func (_m *RecommendationProvider) GetSimilarMovies(movieID int64, page int, limit int) ([]*models.Recommendation, error) {
+	ret := _m.Called(movieID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSimilarMovies")
+	}
+
+	var r0 []*models.Recommendation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) ([]*models.Recommendation, error)); ok {
+		return rf(movieID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*models.Recommendation); ok {
+		r0 = rf(movieID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Recommendation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(movieID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRecommendations provides a mock function with given fields: userID, page, limit
+func (_m *RecommendationProvider) GetUserRecommendations(userID int64, page int, limit int) ([]*models.Recommendation, error) {
+	ret := _m.Called(userID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRecommendations")
+	}
+
+	var r0 []*models.Recommendation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) ([]*models.Recommendation, error)); ok {
+		return rf(userID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*models.Recommendation); ok {
+		r0 = rf(userID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Recommendation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(userID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRecommendationProvider creates a new instance of RecommendationProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecommendationProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecommendationProvider {
+	mock := &RecommendationProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReleaseProvider is an autogenerated mock type for the ReleaseProvider type
+type ReleaseProvider struct {
+	mock.Mock
+}
+
+// AddRelease provides a mock function with given fields: release
+func (_m *ReleaseProvider) AddRelease(release *models.Release) error {
+	ret := _m.Called(release)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRelease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Release) error); ok {
+		r0 = rf(release)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRelease provides a mock function with given fields: id
+func (_m *ReleaseProvider) DeleteRelease(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetReleases provides a mock function with given fields: movieID
+func (_m *ReleaseProvider) GetReleases(movieID int64) ([]*models.Release, error) {
+	ret := _m.Called(movieID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReleases")
+	}
+
+	var r0 []*models.Release
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]*models.Release, error)); ok {
+		return rf(movieID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []*models.Release); ok {
+		r0 = rf(movieID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(movieID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReleaseProvider creates a new instance of ReleaseProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReleaseProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReleaseProvider {
+	mock := &ReleaseProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
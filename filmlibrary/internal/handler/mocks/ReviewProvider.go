@@ -0,0 +1,112 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewProvider is an autogenerated mock type for the ReviewProvider type
+type ReviewProvider struct {
+	mock.Mock
+}
+
+// DeleteReview provides a mock function with given fields: id
+func (_m *ReviewProvider) DeleteReview(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetReviews provides a mock function with given fields: movieID, page, limit
+func (_m *ReviewProvider) GetReviews(movieID int64, page int, limit int) (*models.ReviewsPage, error) {
+	ret := _m.Called(movieID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviews")
+	}
+
+	var r0 *models.ReviewsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) (*models.ReviewsPage, error)); ok {
+		return rf(movieID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) *models.ReviewsPage); ok {
+		r0 = rf(movieID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReviewsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(movieID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModerateReview provides a mock function with given fields: id, hidden
+func (_m *ReviewProvider) ModerateReview(id int64, hidden bool) error {
+	ret := _m.Called(id, hidden)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModerateReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, bool) error); ok {
+		r0 = rf(id, hidden)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubmitReview provides a mock function with given fields: review
+func (_m *ReviewProvider) SubmitReview(review *models.Review) error {
+	ret := _m.Called(review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Review) error); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewReviewProvider creates a new instance of ReviewProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewProvider {
+	mock := &ReviewProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TranslationProvider is an autogenerated mock type for the TranslationProvider type
+type TranslationProvider struct {
+	mock.Mock
+}
+
+// DeleteActorTranslation provides a mock function with given fields: actorID, locale
+func (_m *TranslationProvider) DeleteActorTranslation(actorID int64, locale string) error {
+	ret := _m.Called(actorID, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteActorTranslation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = rf(actorID, locale)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMovieTranslation provides a mock function with given fields: movieID, locale
+func (_m *TranslationProvider) DeleteMovieTranslation(movieID int64, locale string) error {
+	ret := _m.Called(movieID, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMovieTranslation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = rf(movieID, locale)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActorTranslations provides a mock function with given fields: actorID
+func (_m *TranslationProvider) GetActorTranslations(actorID int64) ([]*models.ActorTranslation, error) {
+	ret := _m.Called(actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActorTranslations")
+	}
+
+	var r0 []*models.ActorTranslation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]*models.ActorTranslation, error)); ok {
+		return rf(actorID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []*models.ActorTranslation); ok {
+		r0 = rf(actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ActorTranslation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovieTranslations provides a mock function with given fields: movieID
+func (_m *TranslationProvider) GetMovieTranslations(movieID int64) ([]*models.MovieTranslation, error) {
+	ret := _m.Called(movieID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovieTranslations")
+	}
+
+	var r0 []*models.MovieTranslation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]*models.MovieTranslation, error)); ok {
+		return rf(movieID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []*models.MovieTranslation); ok {
+		r0 = rf(movieID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.MovieTranslation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(movieID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TranslateActor provides a mock function with given fields: translation
+func (_m *TranslationProvider) TranslateActor(translation *models.ActorTranslation) error {
+	ret := _m.Called(translation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TranslateActor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ActorTranslation) error); ok {
+		r0 = rf(translation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TranslateMovie provides a mock function with given fields: translation
+func (_m *TranslationProvider) TranslateMovie(translation *models.MovieTranslation) error {
+	ret := _m.Called(translation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TranslateMovie")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.MovieTranslation) error); ok {
+		r0 = rf(translation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTranslationProvider creates a new instance of TranslationProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTranslationProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TranslationProvider {
+	mock := &TranslationProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,178 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "filmlibrary/internal/domain/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WatchlistProvider is an autogenerated mock type for the WatchlistProvider type
+type WatchlistProvider struct {
+	mock.Mock
+}
+
+// AddToWatchlist provides a mock function with given fields: userID, item
+func (_m *WatchlistProvider) AddToWatchlist(userID int64, item *models.WatchlistItem) error {
+	ret := _m.Called(userID, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddToWatchlist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *models.WatchlistItem) error); ok {
+		r0 = rf(userID, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFromWatchlist provides a mock function with given fields: userID, movieID
+func (_m *WatchlistProvider) DeleteFromWatchlist(userID int64, movieID int64) error {
+	ret := _m.Called(userID, movieID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFromWatchlist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(userID, movieID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWatched provides a mock function with given fields: userID, id
+func (_m *WatchlistProvider) DeleteWatched(userID int64, id int64) error {
+	ret := _m.Called(userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWatched")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetWatched provides a mock function with given fields: userID, page, limit
+func (_m *WatchlistProvider) GetWatched(userID int64, page int, limit int) (*models.WatchedPage, error) {
+	ret := _m.Called(userID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWatched")
+	}
+
+	var r0 *models.WatchedPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) (*models.WatchedPage, error)); ok {
+		return rf(userID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) *models.WatchedPage); ok {
+		r0 = rf(userID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WatchedPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(userID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWatchlist provides a mock function with given fields: userID
+func (_m *WatchlistProvider) GetWatchlist(userID int64) ([]*models.WatchlistItem, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWatchlist")
+	}
+
+	var r0 []*models.WatchlistItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]*models.WatchlistItem, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []*models.WatchlistItem); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WatchlistItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkWatched provides a mock function with given fields: userID, entry
+func (_m *WatchlistProvider) MarkWatched(userID int64, entry *models.WatchedEntry) error {
+	ret := _m.Called(userID, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkWatched")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *models.WatchedEntry) error); ok {
+		r0 = rf(userID, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderWatchlist provides a mock function with given fields: userID, movieIDs
+func (_m *WatchlistProvider) ReorderWatchlist(userID int64, movieIDs []int64) error {
+	ret := _m.Called(userID, movieIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderWatchlist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, []int64) error); ok {
+		r0 = rf(userID, movieIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWatchlistProvider creates a new instance of WatchlistProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWatchlistProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WatchlistProvider {
+	mock := &WatchlistProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,53 +2,66 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"filmlibrary/internal/domain/models"
 	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name MovieProvider
 type MovieProvider interface {
-	GetMovie(input string) ([]*models.MovieListing, error)
-	GetMoviesSorted(sortBy string, sortDirection string) ([]*models.MovieListing, error)
-	EditMovie(movie *models.Movie) error
+	GetMovie(input string, langs []string) ([]*models.MovieListing, error)
+	GetMoviesSorted(sortBy string, sortDirection string, filter models.MovieFilter, langs []string) ([]*models.MovieListing, error)
+	EditMovie(id int64, version int64, patch *models.MoviePatch) (int64, error)
 	AddMovie(movie *models.Movie) error
 	AddActorsToMovie(movieID int64, actors []int64) error
-	DeleteMovie(id int64) error
+	DeleteMovie(id int64, version int64) error
+	BatchMovies(items []*models.MovieBatchItem, size int, atomic bool) ([]*models.BatchResult, error)
+	GetMovieDetail(id int64, langs []string) (*models.MovieDetail, error)
 }
 
 // @Summary Add movie
 // @Security ApiKeyAuth
-// @Description Adds a movie using the provided movie object.
+// @Description Adds a movie using the provided movie object. Money is given in whole units of an ISO 4217 currency, languages as ISO 639 and countries as ISO 3166 codes.
 // @Tags Movies
 // @Accept json
 // @Produce json
-// @Param input body models.addMovie true "Movie object to be added"
+// @Param input body addMovieRequest true "Movie object to be added"
 // @Success 201 {string} string "Successfully added a movie"
 // @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 409 {string} string "Conflict"
 // @Failure 500 {string} string "Internal server error"
-// @Router /add/movie [post]
+// @Router /movies [post]
 func (h *Handler) addMovie(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.addMovie"
 
 	log := h.log.With(slog.String("op", op))
 
-	movie := &models.Movie{}
-	err := json.NewDecoder(r.Body).Decode(movie)
+	req := &addMovieRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
 	if err != nil {
 		log.Error("failed to decode request body", sl.Err(err))
 		http.Error(w, "failed to decode request", http.StatusBadRequest)
 		return
 	}
 
+	if !validRequest(w, log, req) {
+		return
+	}
+
 	log.Info("request body decoded")
 
-	err = h.movieProvider.AddMovie(movie)
+	err = h.movieProvider.AddMovie(req.movie())
 	if err != nil {
-		log.Error("failed to add a movie", sl.Err(err))
-		http.Error(w, "failed to add a movie", http.StatusBadRequest)
+		movieError(w, log, err, "failed to add a movie", http.StatusBadRequest)
 		return
 	}
 
@@ -62,11 +75,13 @@ func (h *Handler) addMovie(w http.ResponseWriter, r *http.Request) {
 // @Tags Movies
 // @Accept json
 // @Produce json
+// @Param id path int true "Movie ID"
 // @Param input body models.ActorsTo true "Movie ID and actor IDs to be added"
 // @Success 200 {string} string "Successfully added actor(s) to movie"
 // @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
 // @Failure 500 {string} string "Internal server error"
-// @Router /movie/add/actors [post]
+// @Router /movies/{id}/actors [post]
 func (h *Handler) addActorsToMovie(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.addActorsToMovie"
 
@@ -81,6 +96,17 @@ func (h *Handler) addActorsToMovie(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = bindPathID(r, "id", &atom.MovieID)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, atom) {
+		return
+	}
+
 	log.Info("request body decoded")
 
 	err = h.movieProvider.AddActorsToMovie(atom.MovieID, atom.Actors)
@@ -95,16 +121,29 @@ func (h *Handler) addActorsToMovie(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Get movies sorted
-// @Description Retrieves movies sorted by the provided criteria.
-// @Tags Movies
+// @Description Retrieves movies sorted by the provided criteria. Titles, descriptions and actor names are translated to the languages of Accept-Language when available.
+// @Tags Movies,v1
 // @Accept json
 // @Produce json
-// @Param sortBy query string false "Field to sort by"
+// @Param Accept-Language header string false "Preferred languages"
+// @Param sortBy query string false "Field to sort by: title, release_date, gross or rating"
 // @Param sortDir query string false "Sort direction: asc or desc"
-// @Success 200 {array} models.MovieListing "Sorted movies"
+// @Param genre query string false "Genre name to filter by"
+// @Param country query string false "Keep movies released in the country, e.g. RU"
+// @Param release_type query string false "Keep movies with a release of the type: theatrical, streaming or festival"
+// @Param released_before query string false "Keep movies released before the date" format(date)
+// @Param released_after query string false "Keep movies released after the date" format(date)
+// @Param suitable_for query string false "Keep movies certified for viewers of the age, e.g. 12 or 12+"
+// @Param won_award query string false "Keep movies that won the award, e.g. Academy Awards"
+// @Param min_runtime query int false "Keep movies at least this many minutes long"
+// @Param max_runtime query int false "Keep movies at most this many minutes long"
+// @Param min_gross query int false "Keep movies that grossed at least the amount in the currency"
+// @Param max_gross query int false "Keep movies that grossed at most the amount in the currency"
+// @Param currency query string false "Currency the gross is filtered and sorted in" default(USD)
+// @Success 200 {array} movieResponse "Sorted movies"
 // @Failure 400 {string} string "Bad request"
 // @Failure 500 {string} string "Internal server error"
-// @Router /get/movies [get]
+// @Router /movies [get]
 func (h *Handler) getMoviesSorted(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.getMoviesSorted"
 
@@ -112,91 +151,313 @@ func (h *Handler) getMoviesSorted(w http.ResponseWriter, r *http.Request) {
 
 	sortBy := r.URL.Query().Get("sortBy")
 	sortDir := r.URL.Query().Get("sortDir")
+	filter, err := parseMovieFilter(r)
+	if err != nil {
+		log.Error("invalid movie filter", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	movies, err := h.movieProvider.GetMoviesSorted(sortBy, sortDir)
+	movies, err := h.movieProvider.GetMoviesSorted(sortBy, sortDir, filter, preferredLanguages(r.Header.Get("Accept-Language")))
 	if err != nil {
-		log.Error("failed to fetch movies", sl.Err(err))
-		http.Error(w, "failed to fetch movies", http.StatusBadRequest)
+		listingError(w, log, err, http.StatusBadRequest)
 		return
 	}
 
-	moviesJSON, err := json.Marshal(movies)
+	moviesJSON, err := json.Marshal(newMovieResponses(movies))
 	if err != nil {
 		log.Error("failed to marshal JSON", sl.Err(err))
 		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Vary", "Accept-Language")
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("Sorted by: " + sortBy + " " + sortDir))
 	w.Write(moviesJSON)
 }
 
+// @Summary Get movies
+// @Description Retrieves movies sorted and filtered like in v1, together with the number of movies the filter keeps in every genre. Titles, descriptions and actor names are translated to the languages of Accept-Language when available.
+// @Tags Movies,v2
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Preferred languages"
+// @Param sortBy query string false "Field to sort by: title, release_date, gross or rating"
+// @Param sortDir query string false "Sort direction: asc or desc"
+// @Param genre query string false "Genre name to filter by"
+// @Param country query string false "Keep movies released in the country, e.g. RU"
+// @Param release_type query string false "Keep movies with a release of the type: theatrical, streaming or festival"
+// @Param released_before query string false "Keep movies released before the date" format(date)
+// @Param released_after query string false "Keep movies released after the date" format(date)
+// @Param suitable_for query string false "Keep movies certified for viewers of the age, e.g. 12 or 12+"
+// @Param won_award query string false "Keep movies that won the award, e.g. Academy Awards"
+// @Param min_runtime query int false "Keep movies at least this many minutes long"
+// @Param max_runtime query int false "Keep movies at most this many minutes long"
+// @Param min_gross query int false "Keep movies that grossed at least the amount in the currency"
+// @Param max_gross query int false "Keep movies that grossed at most the amount in the currency"
+// @Param currency query string false "Currency the gross is filtered and sorted in" default(USD)
+// @Success 200 {object} movieListResponse "Sorted movies with their facets"
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies [get]
+func (h *Handler) getMovies(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getMovies"
+
+	log := h.log.With(slog.String("op", op))
+
+	filter, err := parseMovieFilter(r)
+	if err != nil {
+		log.Error("invalid movie filter", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	movies, err := h.movieProvider.GetMoviesSorted(r.URL.Query().Get("sortBy"), r.URL.Query().Get("sortDir"), filter, preferredLanguages(r.Header.Get("Accept-Language")))
+	if err != nil {
+		listingError(w, log, err, http.StatusInternalServerError)
+		return
+	}
+
+	facets, err := h.genreProvider.GetMovieFacets(filter)
+	if err != nil {
+		listingError(w, log, err, http.StatusInternalServerError)
+		return
+	}
+
+	responseJSON, err := json.Marshal(&movieListResponse{Movies: newMovieResponses(movies), Facets: facets})
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Vary", "Accept-Language")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJSON)
+}
+
+// listingError answers a request for the movie listing that failed, invalid filters
+// with 400 Bad Request and anything else with the status.
+func listingError(w http.ResponseWriter, log *slog.Logger, err error, status int) {
+	for _, invalid := range []error{
+		service.ErrInvalidCountry,
+		service.ErrInvalidReleaseType,
+		service.ErrInvalidFactsRange,
+		service.ErrInvalidMoney,
+	} {
+		if errors.Is(err, invalid) {
+			log.Error("invalid movie filter", sl.Err(err))
+			http.Error(w, invalid.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	log.Error("failed to fetch movies", sl.Err(err))
+	http.Error(w, "failed to fetch movies", status)
+}
+
+// parseMovieFilter reads the filters of the movie listing from the query.
+func parseMovieFilter(r *http.Request) (models.MovieFilter, error) {
+	query := r.URL.Query()
+
+	filter := models.MovieFilter{
+		Genre:       query.Get("genre"),
+		Country:     query.Get("country"),
+		ReleaseType: query.Get("release_type"),
+		WonAward:    strings.TrimSpace(query.Get("won_award")),
+
+		GrossCurrency: query.Get("currency"),
+	}
+
+	var err error
+	if v := query.Get("released_before"); v != "" {
+		filter.ReleasedBefore, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid released_before %q", v)
+		}
+	}
+	if v := query.Get("released_after"); v != "" {
+		filter.ReleasedAfter, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid released_after %q", v)
+		}
+	}
+	if v := query.Get("suitable_for"); v != "" {
+		age, err := strconv.Atoi(strings.TrimSuffix(v, "+"))
+		if err != nil || age < 0 {
+			return filter, fmt.Errorf("invalid suitable_for %q", v)
+		}
+		filter.SuitableForAge = &age
+	}
+	for param, bound := range map[string]*int{"min_runtime": &filter.MinRuntime, "max_runtime": &filter.MaxRuntime} {
+		if v := query.Get(param); v != "" {
+			*bound, err = strconv.Atoi(v)
+			if err != nil || *bound <= 0 {
+				return filter, fmt.Errorf("invalid %s %q", param, v)
+			}
+		}
+	}
+	for param, bound := range map[string]*int64{"min_gross": &filter.MinGross, "max_gross": &filter.MaxGross} {
+		if v := query.Get(param); v != "" {
+			*bound, err = strconv.ParseInt(v, 10, 64)
+			if err != nil || *bound <= 0 {
+				return filter, fmt.Errorf("invalid %s %q", param, v)
+			}
+		}
+	}
+
+	return filter, nil
+}
+
 // @Summary Get movie information
-// @Description Get movie information based on substring of a title or an actor's name
+// @Description Get movie information based on substring of a title or an actor's name in any language. Results are translated like in the movie listing.
 // @Tags Movies
 // @Accept json
 // @Produce json
+// @Param Accept-Language header string false "Preferred languages"
 // @Param input query string true "Input to search for a movie"
-// @Success 200 {array} models.MovieListing
+// @Success 200 {array} movieResponse
 // @Failure 400 {string} string "Bad request"
 // @Failure 500 {string} string "Internal server error"
-// @Router /find/movie [post]
+// @Router /movies/search [get]
 func (h *Handler) getMovie(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.getMovie"
 
 	log := h.log.With(slog.String("op", op))
 
 	input := r.URL.Query().Get("input")
-	movies, err := h.movieProvider.GetMovie(input)
+	movies, err := h.movieProvider.GetMovie(input, preferredLanguages(r.Header.Get("Accept-Language")))
 	if err != nil {
 		log.Error("failed to find a movie", sl.Err(err))
 		http.Error(w, "failed to find a movie", http.StatusBadRequest)
 		return
 	}
 
-	movieJSON, err := json.Marshal(movies)
+	movieJSON, err := json.Marshal(newMovieResponses(movies))
 	if err != nil {
 		log.Error("failed to marshal JSON", sl.Err(err))
 		http.Error(w, "failed to marshal JSON", http.StatusBadRequest)
 		return
 	}
 
+	w.Header().Set("Vary", "Accept-Language")
+	w.WriteHeader(http.StatusOK)
+	w.Write(movieJSON)
+}
+
+// @Summary Get movie
+// @Description Retrieves a movie with its place in the franchise and the related movies, such as sequels and remakes. Titles are translated like in the movie listing.
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Preferred languages"
+// @Param If-None-Match header string false "ETag of the cached version"
+// @Param id path int true "Movie ID"
+// @Success 200 {object} movieDetailResponse
+// @Header 200 {string} ETag "Version of the movie and a hash of the response"
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id} [get]
+func (h *Handler) getMovieDetail(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getMovieDetail"
+
+	log := h.log.With(slog.String("op", op))
+
+	id, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	langs := preferredLanguages(r.Header.Get("Accept-Language"))
+	movie, err := h.movieProvider.GetMovieDetail(id, langs)
+	if err != nil {
+		if errors.Is(err, storage.ErrMovieNotFound) {
+			log.Error("movie not found", sl.Err(err))
+			http.Error(w, "movie not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to fetch a movie", sl.Err(err))
+		http.Error(w, "failed to fetch a movie", http.StatusInternalServerError)
+		return
+	}
+
+	movieJSON, err := json.Marshal(newMovieDetailResponse(movie))
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	if notModified(w, r, representationETag(movie.Version, movieJSON, langs)) {
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	w.Write(movieJSON)
 }
 
 // @Summary Edit movie
 // @Security ApiKeyAuth
-// @Description Edit movie information.
+// @Description Edit movie information with a JSON Merge Patch. Absent fields are left unchanged and null clears a field, the title and the release date cannot be cleared. If-Match must carry the ETag of the movie being edited.
 // @Tags Movies
 // @Accept json
+// @Accept application/merge-patch+json
 // @Produce json
-// @Param input body models.editMovie true "Movie object to be edited"
+// @Param If-Match header string true "ETag of a read of the edited version, or * for any version"
+// @Param id path int true "Movie ID"
+// @Param input body editMovieRequest true "Merge patch of the movie"
 // @Success 200 {string} string "Successfully edited a movie"
 // @Failure 400 {string} string "Bad request"
-// @Failure 501 {string} string "Not Implemented"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 412 {string} string "Version has changed"
+// @Failure 428 {string} string "If-Match is required"
 // @Failure 500 {string} string "Internal server error"
-// @Router /edit/movie [post]
+// @Router /movies/{id} [patch]
 func (h *Handler) editMovie(w http.ResponseWriter, r *http.Request) {
-	const op = "handler.editActor"
+	const op = "handler.editMovie"
 
 	log := h.log.With(slog.String("op", op))
 
-	movie := &models.Movie{}
-	err := json.NewDecoder(r.Body).Decode(movie)
+	req := &editMovieRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
 	if err != nil {
 		log.Error("failed to decode request body", sl.Err(err))
 		http.Error(w, "failed to decode request", http.StatusBadRequest)
 		return
 	}
 
+	err = bindPathID(r, "id", &req.ID)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		log.Error("If-Match header missing")
+		http.Error(w, "If-Match header with the ETag of the movie is required", http.StatusPreconditionRequired)
+		return
+	}
+
+	if !validRequest(w, log, req) {
+		return
+	}
+
 	log.Info("request body decoded")
 
-	err = h.movieProvider.EditMovie(movie)
+	_, err = h.movieProvider.EditMovie(req.ID, version, req.patch())
 	if err != nil {
-		log.Error("failed to edit a movie", sl.Err(err))
-		http.Error(w, "failed to edit a movie", http.StatusNotImplemented)
+		movieError(w, log, err, "failed to edit a movie", http.StatusInternalServerError)
 		return
 	}
 
@@ -206,21 +467,25 @@ func (h *Handler) editMovie(w http.ResponseWriter, r *http.Request) {
 
 // @Summary Delete movie
 // @Security ApiKeyAuth
-// @Description Delete a movie by its ID.
+// @Description Delete a movie by its ID. If-Match must carry the ETag of the movie being deleted.
 // @Tags Movies
 // @Accept json
 // @Produce json
-// @Param id query int true "Movie ID to be deleted"
+// @Param If-Match header string true "ETag of a read of the deleted version, or * for any version"
+// @Param id path int true "Movie ID to be deleted"
 // @Success 200 {string} string "Successfully deleted a movie"
 // @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 412 {string} string "Version has changed"
+// @Failure 428 {string} string "If-Match is required"
 // @Failure 500 {string} string "Internal server error"
-// @Router /delete/movie [delete]
+// @Router /movies/{id} [delete]
 func (h *Handler) deleteMovie(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.deleteMovie"
 
 	log := h.log.With(slog.String("op", op))
 
-	movieIDStr := r.URL.Query().Get("id")
+	movieIDStr := requestParam(r, "id")
 	movieID, err := strconv.ParseInt(movieIDStr, 10, 64)
 	if err != nil {
 		log.Error("invalid movie ID", sl.Err(err))
@@ -230,13 +495,57 @@ func (h *Handler) deleteMovie(w http.ResponseWriter, r *http.Request) {
 
 	log.Info("parsed movie ID")
 
-	err = h.movieProvider.DeleteMovie(movieID)
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		log.Error("If-Match header missing")
+		http.Error(w, "If-Match header with the ETag of the movie is required", http.StatusPreconditionRequired)
+		return
+	}
+
+	err = h.movieProvider.DeleteMovie(movieID, version)
 	if err != nil {
-		log.Error("failed to delete a movie", sl.Err(err))
-		http.Error(w, "failed to delete a movie", http.StatusBadRequest)
+		movieError(w, log, err, "failed to delete a movie", http.StatusBadRequest)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("Successfully deleted a movie"))
 }
+
+// movieError reports an invalid movie, an external ID conflict, a missing movie and a
+// moved version with their own status. Other errors are reported with the status.
+func movieError(w http.ResponseWriter, log *slog.Logger, err error, message string, status int) {
+	message, status = movieErrorStatus(err, message, status)
+	log.Error(message, sl.Err(err))
+	http.Error(w, message, status)
+}
+
+// movieErrorStatus returns the message and the status movieError reports the error with.
+func movieErrorStatus(err error, message string, status int) (string, int) {
+	for _, invalid := range []error{
+		service.ErrInvalidRuntime,
+		service.ErrInvalidMoney,
+		service.ErrInvalidLanguage,
+		service.ErrInvalidCountry,
+		service.ErrInvalidMovieIMDbID,
+		service.ErrInvalidKinopoiskID,
+		service.ErrInvalidTitle,
+		service.ErrInvalidReleaseDate,
+		service.ErrInvalidRating,
+	} {
+		if errors.Is(err, invalid) {
+			return invalid.Error(), http.StatusBadRequest
+		}
+	}
+
+	switch {
+	case errors.Is(err, storage.ErrExternalIDExists):
+		return "external ID belongs to another movie", http.StatusConflict
+	case errors.Is(err, storage.ErrMovieNotFound):
+		return "movie not found", http.StatusNotFound
+	case errors.Is(err, storage.ErrVersionMismatch):
+		return storage.ErrVersionMismatch.Error(), http.StatusPreconditionFailed
+	}
+
+	return message, status
+}
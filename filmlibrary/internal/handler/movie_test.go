@@ -2,8 +2,11 @@ package handler
 
 import (
 	"bytes"
+	"errors"
 	"filmlibrary/internal/domain/models"
 	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -74,7 +77,7 @@ func TestHandler_addMovie(t *testing.T) {
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: httptest.NewRequest(http.MethodPost, "/add/movie", bytes.NewBuffer([]byte(`{"title":"Movie Title","description":"Movie Description"}`))),
+				r: httptest.NewRequest(http.MethodPost, "/add/movie", bytes.NewBuffer([]byte(`{"title":"Movie Title","description":"Movie Description","release_date":"1994-10-14T00:00:00Z"}`))),
 			},
 		},
 		//{
@@ -109,6 +112,65 @@ func TestHandler_addMovie(t *testing.T) {
 	}
 }
 
+func TestHandler_addMovie_Facts(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Invalid currency",
+			err:         fmt.Errorf("service.AddMovie: %w", service.ErrInvalidMoney),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrInvalidMoney.Error(),
+		},
+		{
+			name:        "External ID taken",
+			err:         fmt.Errorf("service.AddMovie: %w", storage.ErrExternalIDExists),
+			wantStatus:  http.StatusConflict,
+			wantMessage: "external ID belongs to another movie",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieMock := &mocks.MovieProvider{}
+			movieMock.On("AddMovie", mock.AnythingOfType("*models.Movie")).Return(tt.err)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				movieProvider: movieMock,
+			}
+
+			body := `{"title":"Movie Title","release_date":"1994-10-14T00:00:00Z","budget":{"amount":1000,"currency":"usd"},"external_ids":{"imdb":"tt0111161"}}`
+			w := httptest.NewRecorder()
+			h.addMovie(w, httptest.NewRequest(http.MethodPost, "/create/movie", strings.NewReader(body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_addMovie_ServerFields(t *testing.T) {
+	movieMock := &mocks.MovieProvider{}
+	movieMock.On("AddMovie", mock.MatchedBy(func(movie *models.Movie) bool {
+		return movie.ID == 0 && movie.DeletedAt.IsZero() && movie.Title == "Movie Title"
+	})).Return(nil)
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		movieProvider: movieMock,
+	}
+
+	body := `{"id":5,"title":"Movie Title","release_date":"1994-10-14T00:00:00Z","deleted_at":"2024-01-01T00:00:00Z"}`
+	w := httptest.NewRecorder()
+	h.addMovie(w, httptest.NewRequest(http.MethodPost, "/create/movie", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	movieMock.AssertExpectations(t)
+}
+
 func TestHandler_addMovie_EmptyBody(t *testing.T) {
 	type fields struct {
 		log           *slog.Logger
@@ -183,7 +245,7 @@ func TestHandler_deleteMovie(t *testing.T) {
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: httptest.NewRequest(http.MethodDelete, "/delete/movie?id=1", nil),
+				r: withIfMatch(httptest.NewRequest(http.MethodDelete, "/delete/movie?id=1", nil), 1),
 			},
 		},
 		{
@@ -205,7 +267,7 @@ func TestHandler_deleteMovie(t *testing.T) {
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: httptest.NewRequest(http.MethodDelete, "/delete/movie?id=1", nil),
+				r: withIfMatch(httptest.NewRequest(http.MethodDelete, "/delete/movie?id=1", nil), 1),
 			},
 		},
 	}
@@ -215,11 +277,11 @@ func TestHandler_deleteMovie(t *testing.T) {
 			var expectedErr error
 			switch tt.name {
 			case "Test delete movie success":
-				movieMock.On("DeleteMovie", int64(1)).Return(nil)
+				movieMock.On("DeleteMovie", int64(1), int64(1)).Return(nil)
 			case "Test delete movie invalid ID":
 				expectedErr = fmt.Errorf("invalid movie ID\n")
 			case "Test delete movie failed":
-				movieMock.On("DeleteMovie", int64(1)).Return(fmt.Errorf("failed to delete movie"))
+				movieMock.On("DeleteMovie", int64(1), int64(1)).Return(fmt.Errorf("failed to delete movie"))
 				expectedErr = fmt.Errorf("failed to delete a movie\n")
 			}
 
@@ -259,6 +321,7 @@ func TestHandler_editMovie(t *testing.T) {
 		name        string
 		fields      fields
 		args        args
+		providerErr error
 		wantStatus  int
 		wantMessage string
 	}{
@@ -270,11 +333,51 @@ func TestHandler_editMovie(t *testing.T) {
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: httptest.NewRequest(http.MethodPost, "/edit/movie", bytes.NewBufferString(`{"id":1,"title":"Updated Movie","release_date":"2024-03-20T12:00:00Z"}`)),
+				r: withIfMatch(httptest.NewRequest(http.MethodPost, "/edit/movie", bytes.NewBufferString(`{"id":1,"title":"Updated Movie","release_date":"2024-03-20T12:00:00Z"}`)), 1),
+			},
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully edited a movie",
+		},
+		{
+			name: "Description Cleared",
+			fields: fields{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+				movieProvider: mocks.NewMovieProvider(t),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withIfMatch(httptest.NewRequest(http.MethodPost, "/edit/movie", bytes.NewBufferString(`{"id":1,"description":null}`)), 1),
 			},
 			wantStatus:  http.StatusOK,
 			wantMessage: "Successfully edited a movie",
 		},
+		{
+			name: "Title Cleared",
+			fields: fields{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+				movieProvider: mocks.NewMovieProvider(t),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withIfMatch(httptest.NewRequest(http.MethodPost, "/edit/movie", bytes.NewBufferString(`{"id":1,"title":null}`)), 1),
+			},
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"title","message":"must not be null"}]}`,
+		},
+		{
+			name: "Movie Not Found",
+			fields: fields{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+				movieProvider: mocks.NewMovieProvider(t),
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: withIfMatch(httptest.NewRequest(http.MethodPost, "/edit/movie", bytes.NewBufferString(`{"id":99,"rating":7.5}`)), 1),
+			},
+			providerErr: fmt.Errorf("service.EditMovie: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
 		{
 			name: "Empty Request Body",
 			fields: fields{
@@ -283,7 +386,7 @@ func TestHandler_editMovie(t *testing.T) {
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: httptest.NewRequest(http.MethodPost, "/edit/movie", nil),
+				r: withIfMatch(httptest.NewRequest(http.MethodPost, "/edit/movie", nil), 1),
 			},
 			wantStatus:  http.StatusBadRequest,
 			wantMessage: "failed to decode request",
@@ -292,7 +395,7 @@ func TestHandler_editMovie(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockMovieProvider := &mocks.MovieProvider{}
-			mockMovieProvider.On("EditMovie", mock.Anything).Return(nil) // Set up mock expectation
+			mockMovieProvider.On("EditMovie", mock.AnythingOfType("int64"), int64(1), mock.AnythingOfType("*models.MoviePatch")).Return(int64(2), tt.providerErr) // Set up mock expectation
 
 			h := &Handler{
 				log:           tt.fields.log,
@@ -356,12 +459,12 @@ func TestHandler_getMovie(t *testing.T) {
 			var expectedResponse string
 			switch tt.name {
 			case "Test get movie success":
-				expectedResponse = `[{"id":1,"title":"Example Movie","release_date":"0001-01-01T00:00:00Z"}]`
-				movieMock.On("GetMovie", "example").Return([]*models.MovieListing{{ID: 1, Title: "Example Movie", ReleaseDate: time.Time{}}}, nil)
+				expectedResponse = `[{"id":1,"title":"Example Movie","release_date":"0001-01-01T00:00:00Z","actors_id":[]}]`
+				movieMock.On("GetMovie", "example", []string(nil)).Return([]*models.MovieListing{{ID: 1, Title: "Example Movie", ReleaseDate: time.Time{}, Actors: []string{}}}, nil)
 			case "Test get movie failed":
 				expectedErr = fmt.Errorf("failed to find a movie")
 				expectedResponse = `[]`
-				movieMock.On("GetMovie", "invalid").Return([]*models.MovieListing{}, expectedErr)
+				movieMock.On("GetMovie", "invalid", []string(nil)).Return([]*models.MovieListing{}, expectedErr)
 			}
 
 			h := &Handler{
@@ -420,7 +523,7 @@ func TestHandler_getMoviesSorted(t *testing.T) {
 				{ID: 1, Title: "Movie 1", Description: "Description 1", ReleaseDate: time.Now(), Rating: ptrFloat64(8.5), Actors: []string{"Oleg", "Putin"}},
 				{ID: 2, Title: "Movie 2", Description: "Description 2", ReleaseDate: time.Now(), Rating: ptrFloat64(7.9), Actors: []string{"OPOPOPO", "GVNO"}},
 			}
-			movieMock.On("GetMoviesSorted", "title", "asc").Return(movies, nil)
+			movieMock.On("GetMoviesSorted", "title", "asc", models.MovieFilter{}, []string(nil)).Return(movies, nil)
 
 			h := &Handler{
 				log:           tt.fields.log,
@@ -439,6 +542,196 @@ func TestHandler_getMoviesSorted(t *testing.T) {
 	}
 }
 
+func TestHandler_getMoviesSorted_Genre(t *testing.T) {
+	movieMock := &mocks.MovieProvider{}
+	movies := []*models.MovieListing{
+		{ID: 1, Title: "Movie 1", Actors: []string{}, Genres: []string{"Comedy"}},
+	}
+	movieMock.On("GetMoviesSorted", "", "", models.MovieFilter{Genre: "comedy"}, []string(nil)).Return(movies, nil)
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		movieProvider: movieMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.getMoviesSorted(w, httptest.NewRequest(http.MethodGet, "/get/movies?genre=comedy", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"genres":["Comedy"]`)
+	movieMock.AssertExpectations(t)
+}
+
+func TestHandler_getMovies(t *testing.T) {
+	facets := &models.MovieFacets{Genres: []*models.GenreListing{{ID: 1, Name: "Comedy", MoviesCount: 1}, {ID: 2, Name: "Drama", MoviesCount: 0}}}
+
+	tests := []struct {
+		name        string
+		target      string
+		filter      models.MovieFilter
+		movies      []*models.MovieListing
+		moviesErr   error
+		facetsErr   error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Movies with facets",
+			target:      "/api/v2/movies?min_runtime=90",
+			filter:      models.MovieFilter{MinRuntime: 90},
+			movies:      []*models.MovieListing{{ID: 1, Title: "Movie 1", Actors: []string{}, Genres: []string{"Comedy"}}},
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"movies":[{"id":1,"title":"Movie 1","release_date":"0001-01-01T00:00:00Z","actors_id":[],"genres":["Comedy"]}],"facets":{"genres":[{"id":1,"name":"Comedy","movies_count":1},{"id":2,"name":"Drama","movies_count":0}]}}`,
+		},
+		{
+			name:        "No movies",
+			target:      "/api/v2/movies",
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"movies":[],"facets":{"genres":[{"id":1,"name":"Comedy","movies_count":1},{"id":2,"name":"Drama","movies_count":0}]}}`,
+		},
+		{
+			name:        "Invalid range",
+			target:      "/api/v2/movies?min_runtime=120&max_runtime=90",
+			filter:      models.MovieFilter{MinRuntime: 120, MaxRuntime: 90},
+			moviesErr:   fmt.Errorf("service.GetMoviesSorted: %w", service.ErrInvalidFactsRange),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrInvalidFactsRange.Error(),
+		},
+		{
+			name:        "Facets fail",
+			target:      "/api/v2/movies",
+			facetsErr:   errors.New("connection refused"),
+			wantStatus:  http.StatusInternalServerError,
+			wantMessage: "failed to fetch movies",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieMock := &mocks.MovieProvider{}
+			movieMock.On("GetMoviesSorted", "", "", tt.filter, []string(nil)).Return(tt.movies, tt.moviesErr)
+			genreMock := &mocks.GenreProvider{}
+			genreMock.On("GetMovieFacets", tt.filter).Return(facets, tt.facetsErr)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				movieProvider: movieMock,
+				genreProvider: genreMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.getMovies(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getMoviesSorted_AcceptLanguage(t *testing.T) {
+	movieMock := &mocks.MovieProvider{}
+	movies := []*models.MovieListing{
+		{ID: 1, Title: "Побег из Шоушенка", Actors: []string{}},
+	}
+	movieMock.On("GetMoviesSorted", "", "", models.MovieFilter{}, []string{"ru", "en"}).Return(movies, nil)
+
+	h := &Handler{
+		log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		movieProvider: movieMock,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/get/movies", nil)
+	r.Header.Set("Accept-Language", "ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7")
+	w := httptest.NewRecorder()
+	h.getMoviesSorted(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	assert.Contains(t, w.Body.String(), `"title":"Побег из Шоушенка"`)
+	movieMock.AssertExpectations(t)
+}
+
+func TestHandler_getMoviesSorted_Releases(t *testing.T) {
+	age := 12
+	tests := []struct {
+		name        string
+		target      string
+		filter      models.MovieFilter
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:   "Released in country before date",
+			target: "/get/movies?country=RU&released_before=2020-01-01",
+			filter: models.MovieFilter{
+				Country:        "RU",
+				ReleasedBefore: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Suitable for age",
+			target:     "/get/movies?suitable_for=12%2B&release_type=streaming",
+			filter:     models.MovieFilter{ReleaseType: "streaming", SuitableForAge: &age},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Won award",
+			target:     "/get/movies?won_award=Academy+Awards",
+			filter:     models.MovieFilter{WonAward: "Academy Awards"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Runtime and gross",
+			target:     "/get/movies?min_runtime=90&max_runtime=150&min_gross=1000000&currency=EUR",
+			filter:     models.MovieFilter{MinRuntime: 90, MaxRuntime: 150, MinGross: 1000000, GrossCurrency: "EUR"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "Invalid runtime",
+			target:      "/get/movies?max_runtime=-5",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: `invalid max_runtime "-5"`,
+		},
+		{
+			name:        "Invalid gross",
+			target:      "/get/movies?min_gross=lots",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: `invalid min_gross "lots"`,
+		},
+		{
+			name:        "Invalid date",
+			target:      "/get/movies?released_after=yesterday",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: `invalid released_after "yesterday"`,
+		},
+		{
+			name:        "Invalid age",
+			target:      "/get/movies?suitable_for=teen",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: `invalid suitable_for "teen"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieMock := &mocks.MovieProvider{}
+			movieMock.On("GetMoviesSorted", "", "", tt.filter, []string(nil)).Return([]*models.MovieListing{}, nil)
+
+			h := &Handler{
+				log:           slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				movieProvider: movieMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.getMoviesSorted(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantMessage != "" {
+				assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+			}
+		})
+	}
+}
+
 func ptrFloat64(f float64) *float64 {
 	return &f
 }
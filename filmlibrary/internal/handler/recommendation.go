@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name RecommendationProvider
+type RecommendationProvider interface {
+	GetSimilarMovies(movieID int64, page, limit int) ([]*models.Recommendation, error)
+	GetUserRecommendations(userID int64, page, limit int) ([]*models.Recommendation, error)
+}
+
+// @Summary Get similar movies
+// @Description Ranks movies that share actors or genres with the given one by shared cast, genre overlap, release proximity and rating.
+// @Tags Recommendations
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param page query int false "Page number, starting from 1"
+// @Param limit query int false "Movies per page, up to 100"
+// @Success 200 {array} models.Recommendation
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/similar [get]
+func (h *Handler) getSimilarMovies(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getSimilarMovies"
+
+	log := h.log.With(slog.String("op", op))
+
+	movieID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		log.Error("invalid pagination", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	movies, err := h.recommendationProvider.GetSimilarMovies(movieID, page, limit)
+	if err != nil {
+		if errors.Is(err, storage.ErrMovieNotFound) {
+			log.Error("movie not found", sl.Err(err))
+			http.Error(w, "movie not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to fetch similar movies", sl.Err(err))
+		http.Error(w, "failed to fetch similar movies", http.StatusInternalServerError)
+		return
+	}
+
+	moviesJSON, err := json.Marshal(movies)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(moviesJSON)
+}
+
+// @Summary Get recommendations
+// @Security ApiKeyAuth
+// @Description Recommends movies similar to the ones the current user rated highly or watched, leaving out movies they already rated or watched.
+// @Tags Recommendations
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number, starting from 1"
+// @Param limit query int false "Movies per page, up to 100"
+// @Success 200 {array} models.Recommendation
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/recommendations [get]
+func (h *Handler) getUserRecommendations(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getUserRecommendations"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		log.Error("invalid pagination", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	movies, err := h.recommendationProvider.GetUserRecommendations(userID, page, limit)
+	if err != nil {
+		log.Error("failed to fetch recommendations", sl.Err(err))
+		http.Error(w, "failed to fetch recommendations", http.StatusInternalServerError)
+		return
+	}
+
+	moviesJSON, err := json.Marshal(movies)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(moviesJSON)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_getSimilarMovies(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Similar movies success",
+			target:      "/api/v1/movies/1/similar",
+			wantStatus:  http.StatusOK,
+			wantMessage: `[{"movie":{"id":2,"title":"Pulp Fiction","release_date":"0001-01-01T00:00:00Z","actors_id":[]},"score":4.5}]`,
+		},
+		{
+			name:        "Movie not found",
+			target:      "/api/v1/movies/1/similar",
+			providerErr: fmt.Errorf("service.GetSimilarMovies: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+		{
+			name:        "Invalid movie ID",
+			target:      "/api/v1/movies/one/similar",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "invalid movie ID",
+		},
+		{
+			name:        "Unknown path",
+			target:      "/api/v1/movies/1/similarity",
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "404 page not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommendationMock := &mocks.RecommendationProvider{}
+			recommendationMock.On("GetSimilarMovies", int64(1), 1, defaultPageLimit).Return([]*models.Recommendation{
+				{Movie: &models.MovieListing{ID: 2, Title: "Pulp Fiction", Actors: []string{}}, Score: 4.5},
+			}, tt.providerErr)
+
+			h := &Handler{
+				log:                    slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				recommendationProvider: recommendationMock,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+			h.InitRoutes().ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name ReleaseProvider
+type ReleaseProvider interface {
+	AddRelease(release *models.Release) error
+	GetReleases(movieID int64) ([]*models.Release, error)
+	DeleteRelease(id int64) error
+}
+
+// @Summary Add movie release
+// @Security ApiKeyAuth
+// @Description Adds the release of a movie in a country with its age certification, such as 12+ or PG-13. A movie has one release of each type per country, adding it again replaces it.
+// @Tags Releases
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param input body models.addRelease true "Release to be added"
+// @Success 201 {object} models.Release
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/releases [post]
+func (h *Handler) addRelease(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addRelease"
+
+	log := h.log.With(slog.String("op", op))
+
+	release := &models.Release{}
+	err := json.NewDecoder(r.Body).Decode(release)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &release.MovieID)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, release) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.releaseProvider.AddRelease(release)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCountry):
+			log.Error("invalid country", sl.Err(err))
+			http.Error(w, service.ErrInvalidCountry.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrInvalidReleaseType):
+			log.Error("invalid release type", sl.Err(err))
+			http.Error(w, service.ErrInvalidReleaseType.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrInvalidReleaseDate):
+			log.Error("invalid release date", sl.Err(err))
+			http.Error(w, service.ErrInvalidReleaseDate.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrUnknownCertification):
+			log.Error("unknown certification", sl.Err(err))
+			http.Error(w, service.ErrUnknownCertification.Error(), http.StatusBadRequest)
+		case errors.Is(err, storage.ErrMovieNotFound):
+			log.Error("movie not found", sl.Err(err))
+			http.Error(w, "movie not found", http.StatusNotFound)
+		default:
+			log.Error("failed to add a release", sl.Err(err))
+			http.Error(w, "failed to add a release", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	releaseJSON, err := json.Marshal(release)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(releaseJSON)
+}
+
+// @Summary Get movie releases
+// @Description Lists the releases of a movie by country, earliest first.
+// @Tags Releases
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Success 200 {array} models.Release
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/releases [get]
+func (h *Handler) getReleases(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getReleases"
+
+	log := h.log.With(slog.String("op", op))
+
+	movieID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	releases, err := h.releaseProvider.GetReleases(movieID)
+	if err != nil {
+		log.Error("failed to fetch releases", sl.Err(err))
+		http.Error(w, "failed to fetch releases", http.StatusInternalServerError)
+		return
+	}
+
+	releasesJSON, err := json.Marshal(releases)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(releasesJSON)
+}
+
+// @Summary Delete movie release
+// @Security ApiKeyAuth
+// @Description Deletes a release by its ID.
+// @Tags Releases
+// @Accept json
+// @Produce json
+// @Param id path int true "Release ID"
+// @Success 200 {string} string "Successfully deleted a release"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Release not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /releases/{id} [delete]
+func (h *Handler) deleteRelease(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteRelease"
+
+	log := h.log.With(slog.String("op", op))
+
+	id, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid release ID", sl.Err(err))
+		http.Error(w, "invalid release ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.releaseProvider.DeleteRelease(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrReleaseNotFound) {
+			log.Error("release not found", sl.Err(err))
+			http.Error(w, "release not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to delete a release", sl.Err(err))
+		http.Error(w, "failed to delete a release", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a release"))
+}
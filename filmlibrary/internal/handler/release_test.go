@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"bytes"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_addRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Release added",
+			body:        `{"movie_id":1,"country":"RU","release_type":"theatrical","release_date":"1995-03-10T00:00:00Z","certification":"16+"}`,
+			wantStatus:  http.StatusCreated,
+			wantMessage: `{"id":5,"movie_id":1,"country":"RU","release_type":"theatrical","release_date":"1995-03-10T00:00:00Z","certification":"16+","min_age":16}`,
+		},
+		{
+			name:        "Unknown certification",
+			body:        `{"movie_id":1,"country":"RU","release_type":"theatrical","release_date":"1995-03-10T00:00:00Z","certification":"XYZ"}`,
+			providerErr: fmt.Errorf("service.AddRelease: %w: %q", service.ErrUnknownCertification, "XYZ"),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrUnknownCertification.Error(),
+		},
+		{
+			name:        "Invalid release type",
+			body:        `{"movie_id":1,"country":"RU","release_type":"dvd","release_date":"1995-03-10T00:00:00Z"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"release_type","message":"must be one of theatrical, streaming, festival"}]}`,
+		},
+		{
+			name:        "Movie not found",
+			body:        `{"movie_id":1,"country":"RU","release_type":"theatrical","release_date":"1995-03-10T00:00:00Z"}`,
+			providerErr: fmt.Errorf("service.AddRelease: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			releaseMock := &mocks.ReleaseProvider{}
+			releaseMock.On("AddRelease", mock.AnythingOfType("*models.Release")).
+				Run(func(args mock.Arguments) {
+					release := args.Get(0).(*models.Release)
+					release.ID = 5
+					age := 16
+					release.MinAge = &age
+				}).
+				Return(tt.providerErr)
+
+			h := &Handler{
+				log:             slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				releaseProvider: releaseMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.addRelease(w, httptest.NewRequest(http.MethodPost, "/movie/add/release", bytes.NewBufferString(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
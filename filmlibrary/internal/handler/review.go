@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name ReviewProvider
+type ReviewProvider interface {
+	SubmitReview(review *models.Review) error
+	GetReviews(movieID int64, page, limit int) (*models.ReviewsPage, error)
+	ModerateReview(id int64, hidden bool) error
+	DeleteReview(id int64) error
+}
+
+// @Summary Rate and review movie
+// @Security ApiKeyAuth
+// @Description Saves the current user's 0-10 rating and optional review of a movie. Submitting again edits the previous review.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param input body models.addReview true "Rating and review"
+// @Success 200 {object} models.Review
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/reviews [post]
+func (h *Handler) submitReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.submitReview"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	review := &models.Review{}
+	err := json.NewDecoder(r.Body).Decode(review)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &review.MovieID)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, review) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	review.UserID = userID
+
+	err = h.reviewProvider.SubmitReview(review)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRating):
+			log.Error("invalid rating", sl.Err(err))
+			http.Error(w, service.ErrInvalidRating.Error(), http.StatusBadRequest)
+		case errors.Is(err, storage.ErrMovieNotFound):
+			log.Error("movie not found", sl.Err(err))
+			http.Error(w, "movie not found", http.StatusNotFound)
+		default:
+			log.Error("failed to submit a review", sl.Err(err))
+			http.Error(w, "failed to submit a review", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	reviewJSON, err := json.Marshal(review)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(reviewJSON)
+}
+
+// @Summary Get movie reviews
+// @Description Retrieves visible reviews of a movie, newest first.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param page query int false "Page number, starting from 1"
+// @Param limit query int false "Reviews per page, up to 100"
+// @Success 200 {object} models.ReviewsPage
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/reviews [get]
+func (h *Handler) getReviews(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getReviews"
+
+	log := h.log.With(slog.String("op", op))
+
+	movieIDParam := r.PathValue("id")
+	if movieIDParam == "" {
+		// the deprecated route names the movie movie_id
+		movieIDParam = r.URL.Query().Get("movie_id")
+	}
+
+	movieID, err := strconv.ParseInt(movieIDParam, 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		log.Error("invalid pagination", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reviews, err := h.reviewProvider.GetReviews(movieID, page, limit)
+	if err != nil {
+		log.Error("failed to fetch reviews", sl.Err(err))
+		http.Error(w, "failed to fetch reviews", http.StatusInternalServerError)
+		return
+	}
+
+	reviewsJSON, err := json.Marshal(reviews)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(reviewsJSON)
+}
+
+// @Summary Moderate review
+// @Security ApiKeyAuth
+// @Description Hides or restores a review. Hidden reviews are not listed and do not count towards the user rating.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param input body models.ReviewModeration true "Review ID and visibility"
+// @Success 200 {string} string "Successfully moderated a review"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reviews/{id} [patch]
+func (h *Handler) moderateReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.moderateReview"
+
+	log := h.log.With(slog.String("op", op))
+
+	moderation := &models.ReviewModeration{}
+	err := json.NewDecoder(r.Body).Decode(moderation)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &moderation.ID)
+	if err != nil {
+		log.Error("invalid review ID", sl.Err(err))
+		http.Error(w, "invalid review ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, moderation) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.reviewProvider.ModerateReview(moderation.ID, moderation.Hidden)
+	if err != nil {
+		if errors.Is(err, storage.ErrReviewNotFound) {
+			log.Error("review not found", sl.Err(err))
+			http.Error(w, "review not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to moderate a review", sl.Err(err))
+		http.Error(w, "failed to moderate a review", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully moderated a review"))
+}
+
+// @Summary Delete review
+// @Security ApiKeyAuth
+// @Description Deletes a review by its ID.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID to be deleted"
+// @Success 200 {string} string "Successfully deleted a review"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /reviews/{id} [delete]
+func (h *Handler) deleteReview(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteReview"
+
+	log := h.log.With(slog.String("op", op))
+
+	reviewID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid review ID", sl.Err(err))
+		http.Error(w, "invalid review ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.reviewProvider.DeleteReview(reviewID)
+	if err != nil {
+		if errors.Is(err, storage.ErrReviewNotFound) {
+			log.Error("review not found", sl.Err(err))
+			http.Error(w, "review not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to delete a review", sl.Err(err))
+		http.Error(w, "failed to delete a review", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a review"))
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_submitReview(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Submit review success",
+			body:        `{"movie_id":1,"rating":8,"text":"Great"}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"id":0,"movie_id":1,"user_id":7,"rating":8,"text":"Great","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:        "Invalid rating",
+			body:        `{"movie_id":1,"rating":11}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"rating","message":"must be at most 10"}]}`,
+		},
+		{
+			name:        "Movie not found",
+			body:        `{"movie_id":1,"rating":5}`,
+			providerErr: fmt.Errorf("service.SubmitReview: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewMock := &mocks.ReviewProvider{}
+			reviewMock.On("SubmitReview", mock.MatchedBy(func(review *models.Review) bool {
+				return review.UserID == 7
+			})).Return(tt.providerErr)
+
+			h := &Handler{
+				log:            slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				reviewProvider: reviewMock,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/review/movie", bytes.NewBufferString(tt.body))
+			r = r.WithContext(context.WithValue(r.Context(), userIDKey, int64(7)))
+			w := httptest.NewRecorder()
+			h.submitReview(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getReviews(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+	}{
+		{name: "Default pagination", target: "/get/reviews?movie_id=1", wantStatus: http.StatusOK},
+		{name: "Invalid movie ID", target: "/get/reviews", wantStatus: http.StatusBadRequest},
+		{name: "Limit too large", target: "/get/reviews?movie_id=1&limit=1000", wantStatus: http.StatusBadRequest},
+		{name: "Invalid page", target: "/get/reviews?movie_id=1&page=0", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewMock := &mocks.ReviewProvider{}
+			reviewMock.On("GetReviews", int64(1), 1, defaultPageLimit).Return(&models.ReviewsPage{
+				Reviews: []*models.Review{}, Total: 0, Page: 1, Limit: defaultPageLimit,
+			}, nil)
+
+			h := &Handler{
+				log:            slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				reviewProvider: reviewMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.getReviews(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, `{"reviews":[],"total":0,"page":1,"limit":20}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_moderateReview(t *testing.T) {
+	reviewMock := &mocks.ReviewProvider{}
+	reviewMock.On("ModerateReview", int64(3), true).Return(nil)
+	reviewMock.On("ModerateReview", int64(4), true).Return(fmt.Errorf("service.ModerateReview: %w", storage.ErrReviewNotFound))
+
+	h := &Handler{
+		log:            slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		reviewProvider: reviewMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.moderateReview(w, httptest.NewRequest(http.MethodPost, "/moderate/review", bytes.NewBufferString(`{"id":3,"hidden":true}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	h.moderateReview(w, httptest.NewRequest(http.MethodPost, "/moderate/review", bytes.NewBufferString(`{"id":4,"hidden":true}`)))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_deleteReview(t *testing.T) {
+	reviewMock := &mocks.ReviewProvider{}
+	reviewMock.On("DeleteReview", int64(3)).Return(nil)
+
+	h := &Handler{
+		log:            slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		reviewProvider: reviewMock,
+	}
+
+	w := httptest.NewRecorder()
+	h.deleteReview(w, httptest.NewRequest(http.MethodDelete, "/delete/review?id=3", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Successfully deleted a review", w.Body.String())
+}
+
+func TestUserAuthMiddleware(t *testing.T) {
+	signed := func(userID int64, expiresAt time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, &models.Token{
+			UserID:         userID,
+			Role:           "user",
+			StandardClaims: &jwt.StandardClaims{ExpiresAt: expiresAt.Unix()},
+		})
+		tokenString, err := token.SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tokenString
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantUserID int64
+	}{
+		{name: "Valid token", header: "Bearer " + signed(7, time.Now().Add(time.Hour)), wantStatus: http.StatusOK, wantUserID: 7},
+		{name: "Expired token", header: "Bearer " + signed(7, time.Now().Add(-time.Hour)), wantStatus: http.StatusUnauthorized},
+		{name: "Missing header", header: "", wantStatus: http.StatusUnauthorized},
+		{name: "Garbage token", header: "Bearer garbage", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUserID int64
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = userIDFromContext(r.Context())
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "/review/movie", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			userAuthMiddleware(next)(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantUserID, gotUserID)
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxPreferredLanguages bounds how many languages of Accept-Language are looked up.
+const maxPreferredLanguages = 10
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name TranslationProvider
+type TranslationProvider interface {
+	TranslateMovie(translation *models.MovieTranslation) error
+	GetMovieTranslations(movieID int64) ([]*models.MovieTranslation, error)
+	DeleteMovieTranslation(movieID int64, locale string) error
+	TranslateActor(translation *models.ActorTranslation) error
+	GetActorTranslations(actorID int64) ([]*models.ActorTranslation, error)
+	DeleteActorTranslation(actorID int64, locale string) error
+}
+
+// @Summary Translate movie
+// @Security ApiKeyAuth
+// @Description Adds the title and description of a movie in a language or replaces the existing translation.
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param input body models.MovieTranslation true "Translation of the movie"
+// @Success 200 {string} string "Successfully translated a movie"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/translations [post]
+func (h *Handler) translateMovie(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.translateMovie"
+
+	log := h.log.With(slog.String("op", op))
+
+	translation := &models.MovieTranslation{}
+	err := json.NewDecoder(r.Body).Decode(translation)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &translation.MovieID)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, translation) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.translationProvider.TranslateMovie(translation)
+	if err != nil {
+		translationError(w, log, err, "movie", "failed to translate a movie")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully translated a movie"))
+}
+
+// @Summary Get movie translations
+// @Description Lists the translations of a movie by locale.
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Success 200 {array} models.MovieTranslation
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/translations [get]
+func (h *Handler) getMovieTranslations(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getMovieTranslations"
+
+	log := h.log.With(slog.String("op", op))
+
+	movieID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	translations, err := h.translationProvider.GetMovieTranslations(movieID)
+	if err != nil {
+		log.Error("failed to fetch translations", sl.Err(err))
+		http.Error(w, "failed to fetch translations", http.StatusInternalServerError)
+		return
+	}
+
+	translationsJSON, err := json.Marshal(translations)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(translationsJSON)
+}
+
+// @Summary Delete movie translation
+// @Security ApiKeyAuth
+// @Description Deletes the translation of a movie in a language.
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param locale path string true "Language of the translation"
+// @Success 200 {string} string "Successfully deleted a translation"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Translation not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /movies/{id}/translations/{locale} [delete]
+func (h *Handler) deleteMovieTranslation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteMovieTranslation"
+
+	log := h.log.With(slog.String("op", op))
+
+	movieID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.translationProvider.DeleteMovieTranslation(movieID, requestParam(r, "locale"))
+	if err != nil {
+		translationError(w, log, err, "movie", "failed to delete a translation")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a translation"))
+}
+
+// @Summary Translate actor
+// @Security ApiKeyAuth
+// @Description Adds the name of an actor in a language or replaces the existing translation.
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Param input body models.ActorTranslation true "Translation of the actor's name"
+// @Success 200 {string} string "Successfully translated an actor"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 404 {string} string "Actor not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /actors/{id}/translations [post]
+func (h *Handler) translateActor(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.translateActor"
+
+	log := h.log.With(slog.String("op", op))
+
+	translation := &models.ActorTranslation{}
+	err := json.NewDecoder(r.Body).Decode(translation)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	err = bindPathID(r, "id", &translation.ActorID)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, translation) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.translationProvider.TranslateActor(translation)
+	if err != nil {
+		translationError(w, log, err, "actor", "failed to translate an actor")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully translated an actor"))
+}
+
+// @Summary Get actor translations
+// @Description Lists the translations of an actor's name by locale.
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Success 200 {array} models.ActorTranslation
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /actors/{id}/translations [get]
+func (h *Handler) getActorTranslations(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getActorTranslations"
+
+	log := h.log.With(slog.String("op", op))
+
+	actorID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	translations, err := h.translationProvider.GetActorTranslations(actorID)
+	if err != nil {
+		log.Error("failed to fetch translations", sl.Err(err))
+		http.Error(w, "failed to fetch translations", http.StatusInternalServerError)
+		return
+	}
+
+	translationsJSON, err := json.Marshal(translations)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(translationsJSON)
+}
+
+// @Summary Delete actor translation
+// @Security ApiKeyAuth
+// @Description Deletes the translation of an actor's name in a language.
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Param locale path string true "Language of the translation"
+// @Success 200 {string} string "Successfully deleted a translation"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Translation not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /actors/{id}/translations/{locale} [delete]
+func (h *Handler) deleteActorTranslation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteActorTranslation"
+
+	log := h.log.With(slog.String("op", op))
+
+	actorID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid actor ID", sl.Err(err))
+		http.Error(w, "invalid actor ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.translationProvider.DeleteActorTranslation(actorID, requestParam(r, "locale"))
+	if err != nil {
+		translationError(w, log, err, "actor", "failed to delete a translation")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a translation"))
+}
+
+// translationError reports an error of changing the translation of a movie or an actor.
+func translationError(w http.ResponseWriter, log *slog.Logger, err error, owner, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidLocale):
+		log.Error("invalid locale", sl.Err(err))
+		http.Error(w, service.ErrInvalidLocale.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrEmptyTranslation):
+		log.Error("translation is empty", sl.Err(err))
+		http.Error(w, service.ErrEmptyTranslation.Error(), http.StatusBadRequest)
+	case errors.Is(err, storage.ErrMovieNotFound), errors.Is(err, storage.ErrPersonNotFound):
+		log.Error(owner+" not found", sl.Err(err))
+		http.Error(w, owner+" not found", http.StatusNotFound)
+	case errors.Is(err, storage.ErrTranslationNotFound):
+		log.Error("translation not found", sl.Err(err))
+		http.Error(w, "translation not found", http.StatusNotFound)
+	default:
+		log.Error(message, sl.Err(err))
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
+
+// preferredLanguages returns the languages of an Accept-Language header from the most
+// to the least preferred. Regional variants fall back to their language, so "ru-RU"
+// becomes "ru", and the wildcard is dropped as the originals are the fallback anyway.
+func preferredLanguages(header string) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+
+	var ranges []weighted
+	for _, item := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(item), ";")
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			q, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if len(lang) < 2 || len(lang) > 3 || strings.Trim(lang, "abcdefghijklmnopqrstuvwxyz") != "" {
+			continue
+		}
+
+		ranges = append(ranges, weighted{lang: lang, q: q})
+	}
+
+	slices.SortStableFunc(ranges, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	var langs []string
+	for _, r := range ranges {
+		if !slices.Contains(langs, r.lang) && len(langs) < maxPreferredLanguages {
+			langs = append(langs, r.lang)
+		}
+	}
+
+	return langs
+}
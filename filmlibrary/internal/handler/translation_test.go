@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"bytes"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPreferredLanguages(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{name: "Empty", header: "", want: nil},
+		{name: "Single language", header: "ru", want: []string{"ru"}},
+		{name: "Regional variants", header: "ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7", want: []string{"ru", "en"}},
+		{name: "Ordered by quality", header: "en;q=0.5, ru, de;q=0.8", want: []string{"ru", "de", "en"}},
+		{name: "Wildcard and refused", header: "*, fr;q=0, EN-gb", want: []string{"en"}},
+		{name: "Malformed", header: "ru;q=abc, x, 12, en;q=0.1", want: []string{"en"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, preferredLanguages(tt.header))
+		})
+	}
+}
+
+func TestHandler_translateMovie(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Translation saved",
+			body:        `{"movie_id":1,"locale":"ru","title":"Побег из Шоушенка"}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully translated a movie",
+		},
+		{
+			name:        "Invalid locale",
+			body:        `{"movie_id":1,"locale":"xx1","title":"Побег из Шоушенка"}`,
+			providerErr: fmt.Errorf("service.TranslateMovie: %w", service.ErrInvalidLocale),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrInvalidLocale.Error(),
+		},
+		{
+			name:        "Movie not found",
+			body:        `{"movie_id":1,"locale":"ru","title":"Побег из Шоушенка"}`,
+			providerErr: fmt.Errorf("service.TranslateMovie: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+		{
+			name:        "Invalid body",
+			body:        `{"movie_id":`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "failed to decode request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translationMock := &mocks.TranslationProvider{}
+			translationMock.On("TranslateMovie", mock.AnythingOfType("*models.MovieTranslation")).Return(tt.providerErr)
+
+			h := &Handler{
+				log:                 slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				translationProvider: translationMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.translateMovie(w, httptest.NewRequest(http.MethodPost, "/movie/add/translation", bytes.NewBufferString(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_deleteActorTranslation(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Translation deleted",
+			target:      "/actor/delete/translation?id=1&locale=ru",
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully deleted a translation",
+		},
+		{
+			name:        "Translation not found",
+			target:      "/actor/delete/translation?id=1&locale=ru",
+			providerErr: fmt.Errorf("service.DeleteActorTranslation: %w", storage.ErrTranslationNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "translation not found",
+		},
+		{
+			name:        "Invalid actor ID",
+			target:      "/actor/delete/translation?id=one&locale=ru",
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "invalid actor ID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translationMock := &mocks.TranslationProvider{}
+			translationMock.On("DeleteActorTranslation", int64(1), "ru").Return(tt.providerErr)
+
+			h := &Handler{
+				log:                 slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				translationProvider: translationMock,
+			}
+
+			w := httptest.NewRecorder()
+			h.deleteActorTranslation(w, httptest.NewRequest(http.MethodDelete, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
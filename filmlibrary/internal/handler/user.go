@@ -23,14 +23,15 @@ type UserProvider interface {
 // @Param input body models.UserCreate true "User creation details"
 // @Success 200 {string} string "Successfully created a new user"
 // @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
 // @Failure 500 {string} string "Internal server error"
-// @Router /create/user [post]
+// @Router /users [post]
 func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.createUser"
 
 	log := h.log.With(slog.String("op", op))
 
-	user := &models.User{}
+	user := &models.UserCreate{}
 	err := json.NewDecoder(r.Body).Decode(user)
 	if err != nil {
 		log.Error("failed to decode request body", sl.Err(err))
@@ -43,24 +44,12 @@ func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info("request body decoded")
-
-	if user.Email == "" {
-		h.log.Error("email is empty")
-		http.Error(w, "email is empty", http.StatusBadRequest)
-		return
-	}
-	if user.Role == "" {
-		h.log.Error("role is empty")
-		http.Error(w, "role is empty", http.StatusBadRequest)
-		return
-	}
-	if user.Password == "" {
-		h.log.Error("password is empty")
-		http.Error(w, "password is empty", http.StatusBadRequest)
+	if !validRequest(w, log, user) {
 		return
 	}
 
+	log.Info("request body decoded")
+
 	err = h.userProvider.CreateUser(user.Email, user.Role, user.Password)
 	if err != nil {
 		log.Error("failed to create user", sl.Err(err))
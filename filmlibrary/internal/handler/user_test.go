@@ -152,8 +152,8 @@ func TestHandler_createUser_RoleMissing(t *testing.T) {
 
 			resp := tt.args.w.(*httptest.ResponseRecorder)
 
-			assert.Equal(t, http.StatusBadRequest, resp.Code)
-			assert.Contains(t, resp.Body.String(), "role is empty\n")
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+			assert.JSONEq(t, `{"errors":[{"field":"role","message":"is required"}]}`, resp.Body.String())
 		})
 	}
 }
@@ -201,8 +201,8 @@ func TestHandler_createUser_EmailMissing(t *testing.T) {
 
 			resp := tt.args.w.(*httptest.ResponseRecorder)
 
-			assert.Equal(t, http.StatusBadRequest, resp.Code)
-			assert.Contains(t, resp.Body.String(), "email is empty\n")
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+			assert.JSONEq(t, `{"errors":[{"field":"email","message":"is required"}]}`, resp.Body.String())
 		})
 	}
 }
@@ -250,8 +250,8 @@ func TestHandler_createUser_PasswordMissing(t *testing.T) {
 
 			resp := tt.args.w.(*httptest.ResponseRecorder)
 
-			assert.Equal(t, http.StatusBadRequest, resp.Code)
-			assert.Contains(t, resp.Body.String(), "password is empty\n")
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+			assert.JSONEq(t, `{"errors":[{"field":"password","message":"is required"}]}`, resp.Body.String())
 		})
 	}
 }
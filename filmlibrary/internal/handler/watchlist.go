@@ -0,0 +1,400 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name WatchlistProvider
+type WatchlistProvider interface {
+	AddToWatchlist(userID int64, item *models.WatchlistItem) error
+	GetWatchlist(userID int64) ([]*models.WatchlistItem, error)
+	ReorderWatchlist(userID int64, movieIDs []int64) error
+	DeleteFromWatchlist(userID, movieID int64) error
+	MarkWatched(userID int64, entry *models.WatchedEntry) error
+	GetWatched(userID int64, page, limit int) (*models.WatchedPage, error)
+	DeleteWatched(userID, id int64) error
+}
+
+// @Summary Get watchlist
+// @Security ApiKeyAuth
+// @Description Retrieves the current user's watchlist in their order.
+// @Tags Watchlist
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.WatchlistItem
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/watchlist [get]
+func (h *Handler) getWatchlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getWatchlist"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	items, err := h.watchlistProvider.GetWatchlist(userID)
+	if err != nil {
+		log.Error("failed to fetch watchlist", sl.Err(err))
+		http.Error(w, "failed to fetch watchlist", http.StatusInternalServerError)
+		return
+	}
+
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(itemsJSON)
+}
+
+// @Summary Add movie to watchlist
+// @Security ApiKeyAuth
+// @Description Adds a movie to the end of the current user's watchlist. Adding a movie that is already listed updates its note.
+// @Tags Watchlist
+// @Accept json
+// @Produce json
+// @Param input body models.addWatchlistItem true "Movie ID and note"
+// @Success 200 {object} models.WatchlistItem
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/watchlist [post]
+func (h *Handler) addToWatchlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.addToWatchlist"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	item := &models.WatchlistItem{}
+	err := json.NewDecoder(r.Body).Decode(item)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, item) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.watchlistProvider.AddToWatchlist(userID, item)
+	if err != nil {
+		if errors.Is(err, storage.ErrMovieNotFound) {
+			log.Error("movie not found", sl.Err(err))
+			http.Error(w, "movie not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to add a movie to watchlist", sl.Err(err))
+		http.Error(w, "failed to add a movie to watchlist", http.StatusInternalServerError)
+		return
+	}
+
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(itemJSON)
+}
+
+// @Summary Reorder watchlist
+// @Security ApiKeyAuth
+// @Description Moves the listed movies to the top of the current user's watchlist in the given order. Movies that are not listed keep their order after them.
+// @Tags Watchlist
+// @Accept json
+// @Produce json
+// @Param input body models.WatchlistOrder true "Movie IDs in the new order"
+// @Success 200 {string} string "Successfully reordered watchlist"
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/watchlist [put]
+func (h *Handler) reorderWatchlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.reorderWatchlist"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	order := &models.WatchlistOrder{}
+	err := json.NewDecoder(r.Body).Decode(order)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, order) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.watchlistProvider.ReorderWatchlist(userID, order.MoviesID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidWatchlistOrder):
+			log.Error("invalid watchlist order", sl.Err(err))
+			http.Error(w, service.ErrInvalidWatchlistOrder.Error(), http.StatusBadRequest)
+		case errors.Is(err, storage.ErrWatchlistItemNotFound):
+			log.Error("movie is not on watchlist", sl.Err(err))
+			http.Error(w, "movie is not on watchlist", http.StatusNotFound)
+		default:
+			log.Error("failed to reorder watchlist", sl.Err(err))
+			http.Error(w, "failed to reorder watchlist", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully reordered watchlist"))
+}
+
+// @Summary Remove movie from watchlist
+// @Security ApiKeyAuth
+// @Description Removes a movie from the current user's watchlist.
+// @Tags Watchlist
+// @Accept json
+// @Produce json
+// @Param movie_id path int true "Movie ID to be removed"
+// @Success 200 {string} string "Successfully removed a movie from watchlist"
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/watchlist/{movie_id} [delete]
+func (h *Handler) deleteFromWatchlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteFromWatchlist"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	movieID, err := strconv.ParseInt(requestParam(r, "movie_id"), 10, 64)
+	if err != nil {
+		log.Error("invalid movie ID", sl.Err(err))
+		http.Error(w, "invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.watchlistProvider.DeleteFromWatchlist(userID, movieID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWatchlistItemNotFound) {
+			log.Error("movie is not on watchlist", sl.Err(err))
+			http.Error(w, "movie is not on watchlist", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to remove a movie from watchlist", sl.Err(err))
+		http.Error(w, "failed to remove a movie from watchlist", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully removed a movie from watchlist"))
+}
+
+// @Summary Get watch history
+// @Security ApiKeyAuth
+// @Description Retrieves the current user's watched movies, most recently watched first.
+// @Tags Watchlist
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number, starting from 1"
+// @Param limit query int false "Entries per page, up to 100"
+// @Success 200 {object} models.WatchedPage
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/watched [get]
+func (h *Handler) getWatched(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.getWatched"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		log.Error("invalid pagination", sl.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	watched, err := h.watchlistProvider.GetWatched(userID, page, limit)
+	if err != nil {
+		log.Error("failed to fetch watch history", sl.Err(err))
+		http.Error(w, "failed to fetch watch history", http.StatusInternalServerError)
+		return
+	}
+
+	watchedJSON, err := json.Marshal(watched)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(watchedJSON)
+}
+
+// @Summary Mark movie as watched
+// @Security ApiKeyAuth
+// @Description Logs that the current user watched a movie, today unless a date is given, and removes it from their watchlist.
+// @Tags Watchlist
+// @Accept json
+// @Produce json
+// @Param input body models.addWatchedEntry true "Movie ID and watch date"
+// @Success 200 {object} models.WatchedEntry
+// @Failure 400 {string} string "Bad request"
+// @Failure 422 {object} models.ValidationErrors "Invalid fields"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/watched [post]
+func (h *Handler) markWatched(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.markWatched"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entry := &models.WatchedEntry{}
+	err := json.NewDecoder(r.Body).Decode(entry)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+		http.Error(w, "failed to decode request", http.StatusBadRequest)
+		return
+	}
+
+	if !validRequest(w, log, entry) {
+		return
+	}
+
+	log.Info("request body decoded")
+
+	err = h.watchlistProvider.MarkWatched(userID, entry)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidWatchDate):
+			log.Error("invalid watch date", sl.Err(err))
+			http.Error(w, service.ErrInvalidWatchDate.Error(), http.StatusBadRequest)
+		case errors.Is(err, storage.ErrMovieNotFound):
+			log.Error("movie not found", sl.Err(err))
+			http.Error(w, "movie not found", http.StatusNotFound)
+		default:
+			log.Error("failed to mark a movie as watched", sl.Err(err))
+			http.Error(w, "failed to mark a movie as watched", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		log.Error("failed to marshal JSON", sl.Err(err))
+		http.Error(w, "failed to marshal JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(entryJSON)
+}
+
+// @Summary Delete watch history entry
+// @Security ApiKeyAuth
+// @Description Deletes an entry from the current user's watch history.
+// @Tags Watchlist
+// @Accept json
+// @Produce json
+// @Param id path int true "Entry ID to be deleted"
+// @Success 200 {string} string "Successfully deleted a watch history entry"
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /me/watched/{id} [delete]
+func (h *Handler) deleteWatched(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.deleteWatched"
+
+	log := h.log.With(slog.String("op", op))
+
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		log.Error("user ID is missing in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entryID, err := strconv.ParseInt(requestParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Error("invalid entry ID", sl.Err(err))
+		http.Error(w, "invalid entry ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.watchlistProvider.DeleteWatched(userID, entryID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWatchedEntryNotFound) {
+			log.Error("watch history entry not found", sl.Err(err))
+			http.Error(w, "watch history entry not found", http.StatusNotFound)
+			return
+		}
+		log.Error("failed to delete a watch history entry", sl.Err(err))
+		http.Error(w, "failed to delete a watch history entry", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Successfully deleted a watch history entry"))
+}
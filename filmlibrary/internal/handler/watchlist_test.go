@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/handler/mocks"
+	"filmlibrary/internal/service"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandler_addToWatchlist(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Add to watchlist success",
+			body:        `{"movie_id":1,"note":"Friday"}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"movie_id":1,"position":0,"note":"Friday","added_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:        "Movie not found",
+			body:        `{"movie_id":1}`,
+			providerErr: fmt.Errorf("service.AddToWatchlist: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+		{
+			name:        "Invalid body",
+			body:        `{"movie_id":"one"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "failed to decode request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watchlistMock := &mocks.WatchlistProvider{}
+			watchlistMock.On("AddToWatchlist", int64(7), mock.AnythingOfType("*models.WatchlistItem")).Return(tt.providerErr)
+
+			h := &Handler{
+				log:               slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				watchlistProvider: watchlistMock,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/me/watchlist/add", bytes.NewBufferString(tt.body))
+			r = r.WithContext(context.WithValue(r.Context(), userIDKey, int64(7)))
+			w := httptest.NewRecorder()
+			h.addToWatchlist(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_reorderWatchlist(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Reorder success",
+			body:        `{"movies_id":[3,1]}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: "Successfully reordered watchlist",
+		},
+		{
+			name:        "Duplicate movies",
+			body:        `{"movies_id":[3,1]}`,
+			providerErr: fmt.Errorf("service.ReorderWatchlist: %w", service.ErrInvalidWatchlistOrder),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: service.ErrInvalidWatchlistOrder.Error(),
+		},
+		{
+			name:        "Movie is not on watchlist",
+			body:        `{"movies_id":[3,1]}`,
+			providerErr: fmt.Errorf("service.ReorderWatchlist: %w", storage.ErrWatchlistItemNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie is not on watchlist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watchlistMock := &mocks.WatchlistProvider{}
+			watchlistMock.On("ReorderWatchlist", int64(7), []int64{3, 1}).Return(tt.providerErr)
+
+			h := &Handler{
+				log:               slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				watchlistProvider: watchlistMock,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/me/watchlist/reorder", bytes.NewBufferString(tt.body))
+			r = r.WithContext(context.WithValue(r.Context(), userIDKey, int64(7)))
+			w := httptest.NewRecorder()
+			h.reorderWatchlist(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_markWatched(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		providerErr error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "Mark watched success",
+			body:        `{"movie_id":1,"watched_at":"2024-03-20T00:00:00Z"}`,
+			wantStatus:  http.StatusOK,
+			wantMessage: `{"id":0,"movie_id":1,"watched_at":"2024-03-20T00:00:00Z"}`,
+		},
+		{
+			name:        "Future date",
+			body:        `{"movie_id":1,"watched_at":"2999-01-01T00:00:00Z"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: `{"errors":[{"field":"watched_at","message":"must not be in the future"}]}`,
+		},
+		{
+			name:        "Movie not found",
+			body:        `{"movie_id":1}`,
+			providerErr: fmt.Errorf("service.MarkWatched: %w", storage.ErrMovieNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "movie not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watchlistMock := &mocks.WatchlistProvider{}
+			watchlistMock.On("MarkWatched", int64(7), mock.AnythingOfType("*models.WatchedEntry")).Return(tt.providerErr)
+
+			h := &Handler{
+				log:               slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				watchlistProvider: watchlistMock,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/me/watched/add", bytes.NewBufferString(tt.body))
+			r = r.WithContext(context.WithValue(r.Context(), userIDKey, int64(7)))
+			w := httptest.NewRecorder()
+			h.markWatched(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMessage, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_getWatched(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+	}{
+		{name: "Default page", target: "/me/watched", wantStatus: http.StatusOK},
+		{name: "Invalid limit", target: "/me/watched?limit=500", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watchlistMock := &mocks.WatchlistProvider{}
+			watchlistMock.On("GetWatched", int64(7), 1, defaultPageLimit).
+				Return(&models.WatchedPage{Entries: []*models.WatchedEntry{}, Page: 1, Limit: defaultPageLimit}, nil)
+
+			h := &Handler{
+				log:               slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				watchlistProvider: watchlistMock,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			r = r.WithContext(context.WithValue(r.Context(), userIDKey, int64(7)))
+			w := httptest.NewRecorder()
+			h.getWatched(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package validate checks request bodies against the rules declared in the validate
+// tags of their fields, e.g. `validate:"required,max=150"`.
+//
+// The rules are:
+//   - required: the field is set and not blank
+//   - min=n, max=n: the length of a string or a list, or the value of a number
+//   - oneof=a b c: a string is one of the values
+//   - notfuture: a date is not in the future
+//   - notnull: a member of a merge patch may be left out but is not null
+//
+// The rules other than required are not checked on fields left empty, pointers that
+// are set are checked even when they point to a zero value. Nested structs, pointers
+// to structs and lists of structs are checked field by field.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldError is a rule a field of the request breaks, Field is its path in the JSON
+// of the request, such as budget.currency or crew[1].role.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Errors lists the rules a request breaks in the order of its fields.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + " " + fieldErr.Message
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Optional is a field that may be left out of a request, such as a member of a merge
+// patch. Its rules apply to its value like to a plain field when it is set, and a nil
+// value only breaks required and notnull.
+type Optional interface {
+	Member() (value interface{}, set bool)
+}
+
+var (
+	optionalType = reflect.TypeOf((*Optional)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// Struct checks the struct v points to and returns Errors when it breaks any rule.
+func Struct(v interface{}) error {
+	var errs Errors
+	checkStruct(reflect.Indirect(reflect.ValueOf(v)), "", time.Now(), &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func checkStruct(v reflect.Value, prefix string, now time.Time, errs *Errors) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			checkStruct(v.Field(i), prefix, now, errs)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		checkField(v.Field(i), prefix+name, field.Tag.Get("validate"), now, errs)
+	}
+}
+
+func checkField(v reflect.Value, path string, rules string, now time.Time, errs *Errors) {
+	set := false
+	if v.Type().Implements(optionalType) {
+		value, ok := v.Interface().(Optional).Member()
+		if !ok {
+			return
+		}
+		if value == nil {
+			switch {
+			case hasRule(rules, "required"):
+				*errs = append(*errs, FieldError{Field: path, Message: "is required"})
+			case hasRule(rules, "notnull"):
+				*errs = append(*errs, FieldError{Field: path, Message: "must not be null"})
+			}
+			return
+		}
+		v = reflect.ValueOf(value)
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			if hasRule(rules, "required") {
+				*errs = append(*errs, FieldError{Field: path, Message: "is required"})
+			}
+			return
+		}
+		v, set = v.Elem(), true
+	}
+
+	empty := !set && isBlank(v)
+	for _, rule := range strings.Split(rules, ",") {
+		if rule == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "notnull" {
+			continue
+		}
+		if name == "required" {
+			if isBlank(v) {
+				*errs = append(*errs, FieldError{Field: path, Message: "is required"})
+				return
+			}
+			continue
+		}
+		if empty {
+			continue
+		}
+		if message := checkRule(v, name, param, now); message != "" {
+			*errs = append(*errs, FieldError{Field: path, Message: message})
+			return
+		}
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != timeType:
+		checkStruct(v, path+".", now, errs)
+	case v.Kind() == reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			elem := reflect.Indirect(v.Index(i))
+			if elem.Kind() == reflect.Struct && elem.Type() != timeType {
+				checkStruct(elem, fmt.Sprintf("%s[%d].", path, i), now, errs)
+			}
+		}
+	}
+}
+
+// checkRule returns the message of the rule v breaks, or an empty string.
+func checkRule(v reflect.Value, name, param string, now time.Time) string {
+	switch name {
+	case "min", "max":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			panic(fmt.Sprintf("validate: invalid %s rule %q", name, param))
+		}
+		size, format := measure(v)
+		if name == "min" && size < limit {
+			return fmt.Sprintf(format, "at least "+param)
+		}
+		if name == "max" && size > limit {
+			return fmt.Sprintf(format, "at most "+param)
+		}
+	case "oneof":
+		values := strings.Fields(param)
+		if !slices.Contains(values, v.String()) {
+			return "must be one of " + strings.Join(values, ", ")
+		}
+	case "notfuture":
+		if v.Interface().(time.Time).After(now) {
+			return "must not be in the future"
+		}
+	default:
+		panic(fmt.Sprintf("validate: unknown rule %q", name))
+	}
+
+	return ""
+}
+
+// measure returns the length of a string or a list, or the value of a number, and
+// the message a broken limit is reported with.
+func measure(v reflect.Value) (float64, string) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), "must be %s characters long"
+	case reflect.Slice, reflect.Map:
+		return float64(v.Len()), "must have %s items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "must be %s"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), "must be %s"
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), "must be %s"
+	}
+	panic(fmt.Sprintf("validate: cannot measure %s", v.Type()))
+}
+
+// isBlank reports whether v is a zero value, a string of spaces or an empty list.
+func isBlank(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func hasRule(rules string, name string) bool {
+	return slices.Contains(strings.Split(rules, ","), name)
+}
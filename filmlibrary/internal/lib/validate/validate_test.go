@@ -0,0 +1,127 @@
+package validate_test
+
+import (
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/validate"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type movieRequest struct {
+	Title       string        `json:"title" validate:"required,max=150"`
+	Description string        `json:"description,omitempty" validate:"max=1000"`
+	Rating      *float64      `json:"rating,omitempty" validate:"min=0,max=10"`
+	Runtime     int           `json:"runtime,omitempty" validate:"min=1"`
+	Budget      *models.Money `json:"budget,omitempty"`
+}
+
+type actorRequest struct {
+	Name     string    `json:"name" validate:"required,max=100"`
+	Sex      string    `json:"sex" validate:"required,oneof=male female"`
+	Birthday time.Time `json:"birthday" validate:"required,notfuture"`
+}
+
+type actorPatch struct {
+	Name        models.Field[string]                  `json:"name" validate:"notnull,max=100"`
+	Sex         models.Field[string]                  `json:"sex" validate:"oneof=male female"`
+	DeathDate   models.Field[time.Time]               `json:"death_date" validate:"notfuture"`
+	ExternalIDs models.Field[models.ExternalIDsPatch] `json:"external_ids"`
+}
+
+func TestStruct(t *testing.T) {
+	rating := 11.0
+	zero := 0.0
+	tomorrow := time.Now().AddDate(0, 0, 1)
+
+	tests := []struct {
+		name    string
+		request interface{}
+		want    validate.Errors
+	}{
+		{
+			name:    "Valid movie",
+			request: &movieRequest{Title: "The Shawshank Redemption", Rating: &zero},
+		},
+		{
+			name:    "Blank title",
+			request: &movieRequest{Title: "  "},
+			want:    validate.Errors{{Field: "title", Message: "is required"}},
+		},
+		{
+			name: "Every broken field",
+			request: &movieRequest{
+				Title:       string(make([]rune, 151)),
+				Description: "Two imprisoned men bond over a number of years",
+				Rating:      &rating,
+				Runtime:     -1,
+				Budget:      &models.Money{Amount: -1},
+			},
+			want: validate.Errors{
+				{Field: "title", Message: "must be at most 150 characters long"},
+				{Field: "rating", Message: "must be at most 10"},
+				{Field: "runtime", Message: "must be at least 1"},
+				{Field: "budget.amount", Message: "must be at least 0"},
+				{Field: "budget.currency", Message: "is required"},
+			},
+		},
+		{
+			name:    "Enum and date",
+			request: &actorRequest{Name: "Morgan Freeman", Sex: "unknown", Birthday: tomorrow},
+			want: validate.Errors{
+				{Field: "sex", Message: "must be one of male, female"},
+				{Field: "birthday", Message: "must not be in the future"},
+			},
+		},
+		{
+			name:    "Optional fields left empty",
+			request: &models.Person{Name: "Frank Darabont"},
+		},
+		{
+			name: "List of structs",
+			request: &models.CrewTo{MovieID: 1, Crew: []models.CrewRole{
+				{PersonID: 1, Role: "director"},
+				{Role: "gaffer"},
+			}},
+			want: validate.Errors{
+				{Field: "crew[1].person_id", Message: "is required"},
+				{Field: "crew[1].role", Message: "must be one of actor, director, writer, composer, producer"},
+			},
+		},
+		{
+			name:    "Merge patch members left out",
+			request: &actorPatch{},
+		},
+		{
+			name: "Merge patch members",
+			request: &actorPatch{
+				Name:        models.SetNull[string](),
+				Sex:         models.SetTo(""),
+				DeathDate:   models.SetNull[time.Time](),
+				ExternalIDs: models.SetTo(models.ExternalIDsPatch{IMDb: models.SetTo("nm00000000000")}),
+			},
+			want: validate.Errors{
+				{Field: "name", Message: "must not be null"},
+				{Field: "external_ids.imdb", Message: "must be at most 12 characters long"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestStruct_UnknownRule(t *testing.T) {
+	request := struct {
+		Name string `json:"name" validate:"email"`
+	}{Name: "ivanov@mail.ru"}
+
+	assert.Panics(t, func() { validate.Struct(&request) })
+}
@@ -1,26 +1,53 @@
 package service
 
 import (
+	"errors"
 	"filmlibrary/internal/domain/models"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBirthdayInFuture   = errors.New("birthday is in the future")
+	ErrDeathInFuture      = errors.New("date of death is in the future")
+	ErrDeathBeforeBirth   = errors.New("date of death is before the birthday")
+	ErrInvalidIMDbID      = errors.New("external IMDb ID must look like nm0000158")
+	ErrInvalidKinopoiskID = errors.New("external Kinopoisk ID must be a number")
+	ErrInvalidActorName   = errors.New("name must not be empty")
+)
+
+var (
+	imdbIDRegexp      = regexp.MustCompile(`^nm\d{7,8}$`)
+	kinopoiskIDRegexp = regexp.MustCompile(`^\d{1,9}$`)
 )
 
 type ActorStorage interface {
-	EditActorStorage(actor *models.Actor) error
+	EditActorStorage(id int64, version int64, patch *models.ActorPatch) (int64, error)
 	AddActorStorage(actor *models.Actor) error
-	DeleteActorStorage(id int64) error
-	GetActorsStorage() ([]*models.ActorListing, error)
+	DeleteActorStorage(id int64, version int64) error
+	BatchActorsStorage(items []*models.ActorBatchItem, atomic bool) ([]*models.BatchResult, error)
+	GetActorsStorage(langs []string) ([]*models.ActorListing, error)
+	GetActorProfileStorage(id int64, langs []string) (*models.Actor, error)
 	AddMoviesToActorStorage(actorID int64, movies []int64) error
 }
 
 func (s *Service) AddActor(actor *models.Actor) error {
 	const op = "service.AddActor"
 
+	if err := validateActor(actor, time.Now()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	err := s.actorStorage.AddActorStorage(actor)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.invalidateCostars()
+
 	return nil
 }
 
@@ -32,38 +59,201 @@ func (s *Service) AddMoviesToActor(actorID int64, movies []int64) error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.invalidateCostars()
+
 	return nil
 }
 
-func (s *Service) GetActors() ([]*models.ActorListing, error) {
+func (s *Service) GetActors(langs []string) ([]*models.ActorListing, error) {
 	const op = "service.GetActorsStorage"
 
-	actors, err := s.actorStorage.GetActorsStorage()
+	actors, err := s.actorStorage.GetActorsStorage(langs)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	now := time.Now()
+	for _, actor := range actors {
+		actor.Age = ageOf(actor.Birthday, actor.DeathDate, now)
+	}
+
 	return actors, nil
 }
 
-func (s *Service) EditActor(actor *models.Actor) error {
+func (s *Service) GetActor(id int64, langs []string) (*models.Actor, error) {
+	const op = "service.GetActor"
+
+	actor, err := s.actorStorage.GetActorProfileStorage(id, langs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	actor.Age = ageOf(actor.Birthday, actor.DeathDate, time.Now())
+
+	return actor, nil
+}
+
+// EditActor applies the merge patch to the actor at the version the editor has seen
+// and returns the new version. The dates are checked together with the stored ones,
+// so a date of death alone cannot precede the birthday.
+func (s *Service) EditActor(id int64, version int64, patch *models.ActorPatch) (int64, error) {
 	const op = "service.EditActor"
 
-	err := s.actorStorage.EditActorStorage(actor)
+	stored := &models.Actor{}
+	if patch.Birthday.Set || patch.DeathDate.Set {
+		var err error
+		stored, err = s.actorStorage.GetActorProfileStorage(id, nil)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := validateActorPatch(patch, stored, time.Now()); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	version, err := s.actorStorage.EditActorStorage(id, version, patch)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	s.invalidateCostars()
+
+	return version, nil
 }
 
-func (s *Service) DeleteActor(id int64) error {
+func (s *Service) DeleteActor(id int64, version int64) error {
 	const op = "service.DeleteActor"
 
-	err := s.actorStorage.DeleteActorStorage(id)
+	err := s.actorStorage.DeleteActorStorage(id, version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.invalidateCostars()
+
 	return nil
 }
+
+// validateActor normalizes the profile fields of a new actor and checks its dates.
+func validateActor(actor *models.Actor, now time.Time) error {
+	actor.Birthplace = strings.TrimSpace(actor.Birthplace)
+	actor.Biography = strings.TrimSpace(actor.Biography)
+
+	if actor.Aliases != nil {
+		actor.Aliases = normalizeAliases(actor.Aliases)
+	}
+
+	if ids := actor.ExternalIDs; ids != nil {
+		ids.IMDb, ids.Kinopoisk = strings.TrimSpace(ids.IMDb), strings.TrimSpace(ids.Kinopoisk)
+		if ids.IMDb != "" && !imdbIDRegexp.MatchString(ids.IMDb) {
+			return ErrInvalidIMDbID
+		}
+		if ids.Kinopoisk != "" && !kinopoiskIDRegexp.MatchString(ids.Kinopoisk) {
+			return ErrInvalidKinopoiskID
+		}
+	}
+
+	return checkLifeDates(actor.Birthday, actor.DeathDate, now)
+}
+
+// validateActorPatch normalizes the members of the patch, blank text clears the field,
+// and checks the dates as they will be once the patch is applied to stored.
+func validateActorPatch(patch *models.ActorPatch, stored *models.Actor, now time.Time) error {
+	if patch.Name.Set {
+		patch.Name.Value = strings.TrimSpace(patch.Name.Value)
+		if patch.Name.Null || patch.Name.Value == "" {
+			return ErrInvalidActorName
+		}
+	}
+	clearIfBlank(&patch.Sex)
+	clearIfBlank(&patch.Birthplace)
+	clearIfBlank(&patch.Biography)
+
+	if patch.Aliases.Changed() {
+		patch.Aliases.Value = normalizeAliases(patch.Aliases.Value)
+	}
+
+	if ids := &patch.ExternalIDs.Value; patch.ExternalIDs.Changed() {
+		clearIfBlank(&ids.IMDb)
+		clearIfBlank(&ids.Kinopoisk)
+		if ids.IMDb.Changed() && !imdbIDRegexp.MatchString(ids.IMDb.Value) {
+			return ErrInvalidIMDbID
+		}
+		if ids.Kinopoisk.Changed() && !kinopoiskIDRegexp.MatchString(ids.Kinopoisk.Value) {
+			return ErrInvalidKinopoiskID
+		}
+	}
+
+	birthday, deathDate := stored.Birthday, stored.DeathDate
+	if patch.Birthday.Set {
+		birthday = patch.Birthday.Value
+	}
+	if patch.DeathDate.Set {
+		deathDate = nil
+		if patch.DeathDate.Changed() {
+			deathDate = &patch.DeathDate.Value
+		}
+	}
+
+	return checkLifeDates(birthday, deathDate, now)
+}
+
+// checkLifeDates checks that the birthday and the date of death are not in the future
+// and that the actor did not die before being born.
+func checkLifeDates(birthday time.Time, deathDate *time.Time, now time.Time) error {
+	if birthday.After(now) {
+		return ErrBirthdayInFuture
+	}
+	if deathDate != nil {
+		if deathDate.After(now) {
+			return ErrDeathInFuture
+		}
+		if !birthday.IsZero() && deathDate.Before(birthday) {
+			return ErrDeathBeforeBirth
+		}
+	}
+
+	return nil
+}
+
+// normalizeAliases trims the aliases and drops the blank and repeated ones.
+func normalizeAliases(aliases []string) []string {
+	normalized := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias != "" && !slices.Contains(normalized, alias) {
+			normalized = append(normalized, alias)
+		}
+	}
+	return normalized
+}
+
+// clearIfBlank trims the text a member sets and turns blank text into a null member,
+// so clearing a field with "" or null has the same effect.
+func clearIfBlank(field *models.Field[string]) {
+	field.Value = strings.TrimSpace(field.Value)
+	if field.Changed() && field.Value == "" {
+		*field = models.SetNull[string]()
+	}
+}
+
+// ageOf returns the age in full years on the date of death, or now for the living,
+// and nil when the birthday is unknown.
+func ageOf(birthday time.Time, deathDate *time.Time, now time.Time) *int {
+	if birthday.IsZero() {
+		return nil
+	}
+
+	on := now
+	if deathDate != nil {
+		on = *deathDate
+	}
+
+	age := on.Year() - birthday.Year()
+	if on.Month() < birthday.Month() || on.Month() == birthday.Month() && on.Day() < birthday.Day() {
+		age--
+	}
+
+	return &age
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"testing"
+	"time"
+)
+
+type memoryActorStorage struct {
+	ActorStorage
+	actor  *models.Actor
+	edited *models.ActorPatch
+}
+
+func (m *memoryActorStorage) GetActorProfileStorage(id int64, langs []string) (*models.Actor, error) {
+	return m.actor, nil
+}
+
+func (m *memoryActorStorage) EditActorStorage(id int64, version int64, patch *models.ActorPatch) (int64, error) {
+	m.edited = patch
+	return version + 1, nil
+}
+
+func TestService_EditActor(t *testing.T) {
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	stored := &models.Actor{ID: 1, Birthday: *date(1950, 6, 15)}
+
+	tests := []struct {
+		name    string
+		patch   models.ActorPatch
+		wantErr error
+	}{
+		{name: "Death after stored birthday", patch: models.ActorPatch{DeathDate: models.SetTo(*date(2001, 1, 1))}},
+		{name: "Death before stored birthday", patch: models.ActorPatch{DeathDate: models.SetTo(*date(1949, 1, 1))}, wantErr: ErrDeathBeforeBirth},
+		{name: "Birthday after death", patch: models.ActorPatch{Birthday: models.SetTo(*date(2002, 1, 1)), DeathDate: models.SetTo(*date(2001, 1, 1))}, wantErr: ErrDeathBeforeBirth},
+		{name: "Birthday in the future", patch: models.ActorPatch{Birthday: models.SetTo(time.Now().AddDate(1, 0, 0))}, wantErr: ErrBirthdayInFuture},
+		{name: "Death in the future", patch: models.ActorPatch{DeathDate: models.SetTo(*date(time.Now().Year()+1, 1, 1))}, wantErr: ErrDeathInFuture},
+		{name: "Clear date of death", patch: models.ActorPatch{DeathDate: models.SetNull[time.Time]()}},
+		{name: "Clear name", patch: models.ActorPatch{Name: models.SetNull[string]()}, wantErr: ErrInvalidActorName},
+		{name: "Blank name", patch: models.ActorPatch{Name: models.SetTo("  ")}, wantErr: ErrInvalidActorName},
+		{name: "Invalid IMDb ID", patch: models.ActorPatch{ExternalIDs: models.SetTo(models.ExternalIDsPatch{IMDb: models.SetTo("tt0111161")})}, wantErr: ErrInvalidIMDbID},
+		{name: "Invalid Kinopoisk ID", patch: models.ActorPatch{ExternalIDs: models.SetTo(models.ExternalIDsPatch{Kinopoisk: models.SetTo("abc")})}, wantErr: ErrInvalidKinopoiskID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actorStorage := &memoryActorStorage{actor: stored}
+			s := &Service{actorStorage: actorStorage}
+
+			patch := tt.patch
+			_, err := s.EditActor(1, 1, &patch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EditActor() error = %v, want %v", err, tt.wantErr)
+			}
+			if edited := actorStorage.edited != nil; edited != (tt.wantErr == nil) {
+				t.Errorf("actor edited = %t, want %t", edited, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestService_EditActor_Aliases(t *testing.T) {
+	actorStorage := &memoryActorStorage{}
+	s := &Service{actorStorage: actorStorage}
+
+	_, err := s.EditActor(1, 1, &models.ActorPatch{Aliases: models.SetTo([]string{" Bob ", "", "Bob", "Robert"})})
+	if err != nil {
+		t.Fatalf("EditActor() error = %v", err)
+	}
+
+	if got := actorStorage.edited.Aliases.Value; len(got) != 2 || got[0] != "Bob" || got[1] != "Robert" {
+		t.Errorf("Aliases = %q, want [Bob Robert]", got)
+	}
+}
+
+func TestService_EditActor_BlankText(t *testing.T) {
+	actorStorage := &memoryActorStorage{}
+	s := &Service{actorStorage: actorStorage}
+
+	_, err := s.EditActor(1, 1, &models.ActorPatch{Biography: models.SetTo("  "), Birthplace: models.SetTo(" Leningrad ")})
+	if err != nil {
+		t.Fatalf("EditActor() error = %v", err)
+	}
+
+	if got := actorStorage.edited.Biography; !got.Null {
+		t.Errorf("Biography = %+v, want null", got)
+	}
+	if got := actorStorage.edited.Birthplace.Value; got != "Leningrad" {
+		t.Errorf("Birthplace = %q, want Leningrad", got)
+	}
+}
+
+func TestAgeOf(t *testing.T) {
+	birthday := time.Date(1950, 6, 15, 0, 0, 0, 0, time.UTC)
+	died := time.Date(2000, 6, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		birthday  time.Time
+		deathDate *time.Time
+		now       time.Time
+		want      int
+	}{
+		{name: "Before birthday", birthday: birthday, now: time.Date(2020, 6, 14, 0, 0, 0, 0, time.UTC), want: 69},
+		{name: "On birthday", birthday: birthday, now: time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC), want: 70},
+		{name: "Age at death", birthday: birthday, deathDate: &died, now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), want: 49},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ageOf(tt.birthday, tt.deathDate, tt.now)
+			if got == nil || *got != tt.want {
+				t.Errorf("ageOf() = %v, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := ageOf(time.Time{}, nil, time.Now()); got != nil {
+		t.Errorf("ageOf() without birthday = %d, want nil", *got)
+	}
+}
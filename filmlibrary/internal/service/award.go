@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAwardName     = errors.New("award name is empty")
+	ErrInvalidCategoryName  = errors.New("category name is empty")
+	ErrInvalidCeremonyYear  = errors.New("ceremony year is out of range")
+	ErrInvalidNominationsOf = errors.New("nominations are browsed by movie, person or ceremony")
+)
+
+// firstCeremonyYear is the earliest year a ceremony can be held, before any
+// movie award existed.
+const firstCeremonyYear = 1900
+
+type AwardStorage interface {
+	AddAwardStorage(award *models.Award) error
+	DeleteAwardStorage(id int64) error
+	GetAwardsStorage() ([]*models.Award, error)
+	AddCeremonyStorage(ceremony *models.Ceremony) error
+	AddAwardCategoryStorage(category *models.AwardCategory) error
+	UpsertNominationStorage(nomination *models.Nomination) error
+	DeleteNominationStorage(id int64) error
+	GetNominationsStorage(of string, id int64, langs []string) ([]*models.NominationListing, error)
+}
+
+func (s *Service) AddAward(award *models.Award) error {
+	const op = "service.AddAward"
+
+	award.Name = strings.TrimSpace(award.Name)
+	if award.Name == "" {
+		return fmt.Errorf("%s: %w", op, ErrInvalidAwardName)
+	}
+
+	err := s.awardStorage.AddAwardStorage(award)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteAward(id int64) error {
+	const op = "service.DeleteAward"
+
+	err := s.awardStorage.DeleteAwardStorage(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetAwards() ([]*models.Award, error) {
+	const op = "service.GetAwards"
+
+	awards, err := s.awardStorage.GetAwardsStorage()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return awards, nil
+}
+
+// AddCeremony adds the ceremony of an award held in a year, up to the next year
+// so upcoming nominations can be announced.
+func (s *Service) AddCeremony(ceremony *models.Ceremony) error {
+	const op = "service.AddCeremony"
+
+	if ceremony.Year < firstCeremonyYear || ceremony.Year > time.Now().Year()+1 {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCeremonyYear)
+	}
+
+	err := s.awardStorage.AddCeremonyStorage(ceremony)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) AddAwardCategory(category *models.AwardCategory) error {
+	const op = "service.AddAwardCategory"
+
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCategoryName)
+	}
+
+	err := s.awardStorage.AddAwardCategoryStorage(category)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) AddNomination(nomination *models.Nomination) error {
+	const op = "service.AddNomination"
+
+	err := s.awardStorage.UpsertNominationStorage(nomination)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteNomination(id int64) error {
+	const op = "service.DeleteNomination"
+
+	err := s.awardStorage.DeleteNominationStorage(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetNominations(of string, id int64, langs []string) ([]*models.NominationListing, error) {
+	const op = "service.GetNominations"
+
+	switch of {
+	case models.NominationsOfMovie, models.NominationsOfPerson, models.NominationsOfCeremony:
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidNominationsOf)
+	}
+
+	nominations, err := s.awardStorage.GetNominationsStorage(of, id, langs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nominations, nil
+}
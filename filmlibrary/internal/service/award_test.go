@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"testing"
+	"time"
+)
+
+type memoryAwardStorage struct {
+	AwardStorage
+	ceremonies []*models.Ceremony
+}
+
+func (m *memoryAwardStorage) AddCeremonyStorage(ceremony *models.Ceremony) error {
+	m.ceremonies = append(m.ceremonies, ceremony)
+	return nil
+}
+
+func TestService_AddCeremony(t *testing.T) {
+	tests := []struct {
+		name    string
+		year    int
+		wantErr error
+	}{
+		{name: "Past ceremony", year: 1995},
+		{name: "Upcoming ceremony", year: time.Now().Year() + 1},
+		{name: "Before movie awards", year: 1850, wantErr: ErrInvalidCeremonyYear},
+		{name: "Far future", year: time.Now().Year() + 2, wantErr: ErrInvalidCeremonyYear},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{awardStorage: &memoryAwardStorage{}}
+
+			err := s.AddCeremony(&models.Ceremony{AwardID: 1, Year: tt.year})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddCeremony() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_GetNominations_InvalidNominee(t *testing.T) {
+	s := &Service{awardStorage: &memoryAwardStorage{}}
+
+	_, err := s.GetNominations("studio", 1, nil)
+	if !errors.Is(err, ErrInvalidNominationsOf) {
+		t.Errorf("GetNominations() error = %v, want %v", err, ErrInvalidNominationsOf)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"time"
+)
+
+// DefaultMaxBatchSize is the largest number of items in a batch accepted unless
+// configured otherwise.
+const DefaultMaxBatchSize = 500
+
+var (
+	ErrEmptyBatch    = errors.New("batch has no items")
+	ErrBatchTooLarge = errors.New("batch has too many items")
+)
+
+// SetMaxBatchSize changes the largest accepted number of items in a batch.
+func (s *Service) SetMaxBatchSize(size int) {
+	s.maxBatchSize = size
+}
+
+// BatchMovies creates, updates and deletes movies in one transaction. An atomic batch
+// is applied as a whole or not at all, otherwise every item succeeds or fails on its
+// own. Items are validated like single movies and an invalid item fails as if it had
+// failed in storage. The batch is limited by size, the number of items in the request
+// including those the caller has already turned down and left out of items.
+func (s *Service) BatchMovies(items []*models.MovieBatchItem, size int, atomic bool) ([]*models.BatchResult, error) {
+	const op = "service.BatchMovies"
+
+	if err := s.checkBatchSize(size); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invalid := make([]error, len(items))
+	for i, item := range items {
+		switch item.Op {
+		case models.BatchCreate:
+			invalid[i] = validateMovieFacts(&item.Movie.MovieFacts)
+		case models.BatchUpdate:
+			invalid[i] = validateMoviePatch(item.Patch)
+		}
+	}
+
+	results, err := applyBatch(items, invalid, atomic, s.movieStorage.BatchMoviesStorage)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidateCostars()
+
+	return results, nil
+}
+
+// BatchActors creates, updates and deletes actors in one transaction like BatchMovies.
+// The dates of an update are checked together with the stored ones as in EditActor.
+func (s *Service) BatchActors(items []*models.ActorBatchItem, size int, atomic bool) ([]*models.BatchResult, error) {
+	const op = "service.BatchActors"
+
+	if err := s.checkBatchSize(size); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	invalid := make([]error, len(items))
+	for i, item := range items {
+		switch item.Op {
+		case models.BatchCreate:
+			invalid[i] = validateActor(item.Actor, now)
+		case models.BatchUpdate:
+			stored := &models.Actor{}
+			if item.Patch.Birthday.Set || item.Patch.DeathDate.Set {
+				stored, invalid[i] = s.actorStorage.GetActorProfileStorage(item.ID, nil)
+			}
+			if invalid[i] == nil {
+				invalid[i] = validateActorPatch(item.Patch, stored, now)
+			}
+		}
+	}
+
+	results, err := applyBatch(items, invalid, atomic, s.actorStorage.BatchActorsStorage)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidateCostars()
+
+	return results, nil
+}
+
+func (s *Service) checkBatchSize(n int) error {
+	if n == 0 {
+		return ErrEmptyBatch
+	}
+	if n > s.maxBatchSize {
+		return ErrBatchTooLarge
+	}
+	return nil
+}
+
+// applyBatch stores the items that passed validation and returns the results of all
+// of them in order. When an atomic batch has an invalid item nothing is stored and
+// the valid items fail with storage.ErrBatchRolledBack.
+func applyBatch[T any](items []T, invalid []error, atomic bool, store func(items []T, atomic bool) ([]*models.BatchResult, error)) ([]*models.BatchResult, error) {
+	results := make([]*models.BatchResult, len(items))
+	var valid []T
+	var positions []int
+	for i, item := range items {
+		if invalid[i] != nil {
+			results[i] = &models.BatchResult{Err: invalid[i]}
+			continue
+		}
+		valid = append(valid, item)
+		positions = append(positions, i)
+	}
+
+	if atomic && len(valid) < len(items) {
+		for _, i := range positions {
+			results[i] = &models.BatchResult{Err: storage.ErrBatchRolledBack}
+		}
+		return results, nil
+	}
+	if len(valid) == 0 {
+		return results, nil
+	}
+
+	stored, err := store(valid, atomic)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range positions {
+		results[i] = stored[j]
+	}
+
+	return results, nil
+}
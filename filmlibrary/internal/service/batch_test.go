@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"testing"
+	"time"
+)
+
+type memoryBatchStorage struct {
+	MovieStorage
+	stored []*models.MovieBatchItem
+}
+
+func (m *memoryBatchStorage) BatchMoviesStorage(items []*models.MovieBatchItem, atomic bool) ([]*models.BatchResult, error) {
+	m.stored = items
+	results := make([]*models.BatchResult, len(items))
+	for i, item := range items {
+		results[i] = &models.BatchResult{ID: item.ID, Version: item.Version + 1}
+	}
+	return results, nil
+}
+
+func TestService_BatchMovies(t *testing.T) {
+	valid := &models.MovieBatchItem{Op: models.BatchUpdate, ID: 1, Version: 1, Patch: &models.MoviePatch{Runtime: models.SetTo(90)}}
+	invalid := &models.MovieBatchItem{Op: models.BatchCreate, Movie: &models.Movie{Title: "Heat", ReleaseDate: time.Now(), MovieFacts: models.MovieFacts{Runtime: -1}}}
+
+	tests := []struct {
+		name       string
+		items      []*models.MovieBatchItem
+		size       int
+		atomic     bool
+		wantErr    error
+		wantStored int
+		wantErrs   []error
+	}{
+		{name: "Empty", wantErr: ErrEmptyBatch},
+		{name: "Too large", items: []*models.MovieBatchItem{valid, valid, valid}, wantErr: ErrBatchTooLarge},
+		{name: "Too large with items left out", items: []*models.MovieBatchItem{valid}, size: 3, wantErr: ErrBatchTooLarge},
+		{name: "Atomic with invalid item", items: []*models.MovieBatchItem{valid, invalid}, atomic: true, wantErrs: []error{storage.ErrBatchRolledBack, ErrInvalidRuntime}},
+		{name: "Partial with invalid item", items: []*models.MovieBatchItem{invalid, valid}, wantStored: 1, wantErrs: []error{ErrInvalidRuntime, nil}},
+		{name: "Atomic", items: []*models.MovieBatchItem{valid, valid}, atomic: true, wantStored: 2, wantErrs: []error{nil, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieStorage := &memoryBatchStorage{}
+			s := &Service{movieStorage: movieStorage, maxBatchSize: 2}
+
+			size := tt.size
+			if size == 0 {
+				size = len(tt.items)
+			}
+			results, err := s.BatchMovies(tt.items, size, tt.atomic)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BatchMovies() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(movieStorage.stored) != tt.wantStored {
+				t.Errorf("stored %d items, want %d", len(movieStorage.stored), tt.wantStored)
+			}
+			if len(results) != len(tt.wantErrs) {
+				t.Fatalf("BatchMovies() returned %d results, want %d", len(results), len(tt.wantErrs))
+			}
+			for i, wantErr := range tt.wantErrs {
+				if !errors.Is(results[i].Err, wantErr) {
+					t.Errorf("item %d error = %v, want %v", i, results[i].Err, wantErr)
+				}
+				if wantErr == nil && results[i].Version != 2 {
+					t.Errorf("item %d version = %d, want 2", i, results[i].Version)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,564 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/validate"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of catalog dumps. Exports are also written as NDJSON, one JSON record per line.
+const (
+	CatalogCSV    = "csv"
+	CatalogJSON   = "json"
+	CatalogNDJSON = "ndjson"
+)
+
+// importDateLayout is how dates are written in catalog dumps.
+const importDateLayout = "2006-01-02"
+
+var (
+	ErrUnsupportedCatalogFormat = errors.New("catalog format must be csv or json")
+	ErrUnsupportedExportFormat  = errors.New("export format must be csv, json or ndjson")
+	ErrUnsupportedExportType    = errors.New("export type must be movie or actor")
+)
+
+type CatalogStorage interface {
+	ImportCatalogStorage(next func() (*models.ImportItem, error), dryRun bool) ([]*models.ImportChange, error)
+	ExportMoviesStorage(sortBy string, sortDirection string, filter models.MovieFilter, langs []string, each func(*models.MovieListing) error) error
+	ExportActorsStorage(langs []string, each func(*models.ActorListing) error) error
+}
+
+// ImportCatalog reads a catalog dump in CSV or JSON record by record and upserts its
+// movies and actors. Every record is imported on its own: a record that cannot be read,
+// is invalid or fails to be stored is reported by its row and the rest are imported.
+// A dump malformed past a record is imported up to it. A dry run reports the changes
+// without making them.
+func (s *Service) ImportCatalog(r io.Reader, format string, dryRun bool) (*models.ImportReport, error) {
+	const op = "service.ImportCatalog"
+
+	var reader catalogReader
+	switch format {
+	case CatalogCSV:
+		reader = newCSVCatalogReader(r)
+	case CatalogJSON:
+		reader = newJSONCatalogReader(r)
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrUnsupportedCatalogFormat)
+	}
+
+	report := &models.ImportReport{DryRun: dryRun, Changes: []*models.ImportChange{}, Errors: []*models.ImportError{}}
+	now := time.Now()
+	next := func() (*models.ImportItem, error) {
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+
+			var recordErr *recordError
+			if errors.As(err, &recordErr) {
+				report.Errors = append(report.Errors, &models.ImportError{Row: recordErr.row, Message: recordErr.Error()})
+				if recordErr.malformed {
+					return nil, io.EOF
+				}
+				report.Rows++
+				continue
+			}
+			report.Rows++
+
+			item, err := importItem(record, now)
+			if err != nil {
+				report.Errors = append(report.Errors, &models.ImportError{Row: record.Row, Message: err.Error()})
+				continue
+			}
+
+			return item, nil
+		}
+	}
+
+	changes, err := s.catalogStorage.ImportCatalogStorage(next, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.Err != nil:
+			report.Errors = append(report.Errors, &models.ImportError{Row: change.Row, Message: change.Err.Error()})
+		case change.Action == models.ImportUnchanged:
+			report.Unchanged++
+		case change.Action == models.ImportCreate:
+			report.Created++
+			report.Changes = append(report.Changes, change)
+		default:
+			report.Updated++
+			report.Changes = append(report.Changes, change)
+		}
+	}
+	slices.SortStableFunc(report.Errors, func(a, b *models.ImportError) int { return a.Row - b.Row })
+	report.Failed = len(report.Errors)
+
+	if !dryRun {
+		s.invalidateCostars()
+	}
+
+	return report, nil
+}
+
+// importItem checks a record of a catalog dump like a movie or an actor added on its own.
+func importItem(record *models.ImportRecord, now time.Time) (*models.ImportItem, error) {
+	if err := validate.Struct(record); err != nil {
+		return nil, err
+	}
+
+	var externalIDs *models.ExternalIDs
+	if record.IMDbID != "" || record.KinopoiskID != "" {
+		externalIDs = &models.ExternalIDs{IMDb: record.IMDbID, Kinopoisk: record.KinopoiskID}
+	}
+
+	item := &models.ImportItem{Row: record.Row}
+	if record.Type == models.CatalogMovie {
+		title := strings.TrimSpace(record.Title)
+		if title == "" {
+			return nil, ErrInvalidTitle
+		}
+		releaseDate, err := importDate("release_date", record.ReleaseDate, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, imdbID := range record.Cast {
+			if !imdbIDRegexp.MatchString(imdbID) {
+				return nil, ErrInvalidIMDbID
+			}
+		}
+
+		item.Movie = &models.Movie{
+			Title:       title,
+			Description: strings.TrimSpace(record.Description),
+			ReleaseDate: *releaseDate,
+			Rating:      record.Rating,
+			MovieFacts: models.MovieFacts{
+				Runtime:          record.Runtime,
+				OriginalLanguage: record.OriginalLanguage,
+				ExternalIDs:      externalIDs,
+			},
+		}
+		item.Cast = record.Cast
+		if err := validateMovieFacts(&item.Movie.MovieFacts); err != nil {
+			return nil, err
+		}
+
+		return item, nil
+	}
+
+	name := strings.TrimSpace(record.Name)
+	if name == "" {
+		return nil, ErrInvalidActorName
+	}
+	birthday, err := importDate("birthday", record.Birthday, true)
+	if err != nil {
+		return nil, err
+	}
+	deathDate, err := importDate("death_date", record.DeathDate, false)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Actor = &models.Actor{
+		Name:        name,
+		Sex:         record.Sex,
+		Birthday:    *birthday,
+		DeathDate:   deathDate,
+		Birthplace:  record.Birthplace,
+		ExternalIDs: externalIDs,
+	}
+	if err := validateActor(item.Actor, now); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// importDate parses a date of a catalog dump, nil when it is left empty.
+func importDate(field string, value string, required bool) (*time.Time, error) {
+	if value == "" {
+		if required {
+			return nil, validate.Errors{{Field: field, Message: "is required"}}
+		}
+		return nil, nil
+	}
+
+	date, err := time.Parse(importDateLayout, value)
+	if err != nil {
+		return nil, validate.Errors{{Field: field, Message: "must be a date like " + importDateLayout}}
+	}
+
+	return &date, nil
+}
+
+// catalogReader reads the records of a catalog dump one by one. A record that cannot
+// be read is returned as a *recordError and the next one is read after it, unless the
+// dump is malformed past the record.
+type catalogReader interface {
+	Read() (*models.ImportRecord, error)
+}
+
+type recordError struct {
+	row       int
+	err       error
+	malformed bool
+}
+
+func (e *recordError) Error() string {
+	if e.malformed {
+		return fmt.Sprintf("%v, the rest of the dump is not read", e.err)
+	}
+	return e.err.Error()
+}
+
+// csvColumns sets the field of a record each column of a CSV dump holds. The cast
+// of a movie is separated by semicolons.
+var csvColumns = map[string]func(record *models.ImportRecord, value string) error{
+	"type":              func(record *models.ImportRecord, value string) error { record.Type = value; return nil },
+	"imdb_id":           func(record *models.ImportRecord, value string) error { record.IMDbID = value; return nil },
+	"kinopoisk_id":      func(record *models.ImportRecord, value string) error { record.KinopoiskID = value; return nil },
+	"title":             func(record *models.ImportRecord, value string) error { record.Title = value; return nil },
+	"description":       func(record *models.ImportRecord, value string) error { record.Description = value; return nil },
+	"release_date":      func(record *models.ImportRecord, value string) error { record.ReleaseDate = value; return nil },
+	"original_language": func(record *models.ImportRecord, value string) error { record.OriginalLanguage = value; return nil },
+	"name":              func(record *models.ImportRecord, value string) error { record.Name = value; return nil },
+	"sex":               func(record *models.ImportRecord, value string) error { record.Sex = value; return nil },
+	"birthday":          func(record *models.ImportRecord, value string) error { record.Birthday = value; return nil },
+	"death_date":        func(record *models.ImportRecord, value string) error { record.DeathDate = value; return nil },
+	"birthplace":        func(record *models.ImportRecord, value string) error { record.Birthplace = value; return nil },
+	"rating": func(record *models.ImportRecord, value string) error {
+		if value == "" {
+			return nil
+		}
+		rating, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return validate.Errors{{Field: "rating", Message: "must be a number"}}
+		}
+		record.Rating = &rating
+		return nil
+	},
+	"runtime": func(record *models.ImportRecord, value string) error {
+		if value == "" {
+			return nil
+		}
+		runtime, err := strconv.Atoi(value)
+		if err != nil {
+			return validate.Errors{{Field: "runtime", Message: "must be a whole number"}}
+		}
+		record.Runtime = runtime
+		return nil
+	},
+	"cast": func(record *models.ImportRecord, value string) error {
+		for _, imdbID := range strings.Split(value, ";") {
+			if imdbID = strings.TrimSpace(imdbID); imdbID != "" {
+				record.Cast = append(record.Cast, imdbID)
+			}
+		}
+		return nil
+	},
+}
+
+// csvCatalogReader reads a CSV dump whose header names the columns of csvColumns, in
+// any order.
+type csvCatalogReader struct {
+	r       *csv.Reader
+	columns []string
+	row     int
+}
+
+func newCSVCatalogReader(r io.Reader) *csvCatalogReader {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	return &csvCatalogReader{r: reader}
+}
+
+func (c *csvCatalogReader) Read() (*models.ImportRecord, error) {
+	if c.columns == nil {
+		header, err := c.r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		c.row++
+		if err != nil {
+			return nil, &recordError{row: c.row, err: err, malformed: true}
+		}
+		for _, column := range header {
+			if _, ok := csvColumns[column]; !ok {
+				return nil, &recordError{row: c.row, err: fmt.Errorf("unknown column %q", column), malformed: true}
+			}
+		}
+		c.columns = slices.Clone(header)
+	}
+
+	fields, err := c.r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	c.row++
+	if err != nil {
+		return nil, &recordError{row: c.row, err: err, malformed: !errors.Is(err, csv.ErrFieldCount)}
+	}
+
+	record := &models.ImportRecord{Row: c.row}
+	for i, value := range fields {
+		if err := csvColumns[c.columns[i]](record, strings.TrimSpace(value)); err != nil {
+			return nil, &recordError{row: c.row, err: err}
+		}
+	}
+
+	return record, nil
+}
+
+// jsonCatalogReader reads a JSON dump, an array of records.
+type jsonCatalogReader struct {
+	dec *json.Decoder
+	row int
+}
+
+func newJSONCatalogReader(r io.Reader) *jsonCatalogReader {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	return &jsonCatalogReader{dec: dec}
+}
+
+func (j *jsonCatalogReader) Read() (*models.ImportRecord, error) {
+	if j.row == 0 {
+		token, err := j.dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if err != nil || token != json.Delim('[') {
+			return nil, &recordError{row: 1, err: errors.New("dump must be a JSON array of records"), malformed: true}
+		}
+	}
+
+	if !j.dec.More() {
+		if _, err := j.dec.Token(); err != nil {
+			return nil, &recordError{row: j.row + 1, err: err, malformed: true}
+		}
+		return nil, io.EOF
+	}
+
+	j.row++
+	record := &models.ImportRecord{Row: j.row}
+	if err := j.dec.Decode(record); err != nil {
+		var syntaxErr *json.SyntaxError
+		malformed := errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF)
+		return nil, &recordError{row: j.row, err: err, malformed: malformed}
+	}
+
+	return record, nil
+}
+
+// ExportCatalog writes the movies of the listing narrowed down by the filter, with
+// their cast, or the actors with the movies they played in, to w in CSV, as a JSON
+// array or as NDJSON, translated to the first of langs. Records are written as they
+// are read from storage and nothing is written before the first one, so an export
+// failing to start leaves w untouched. It returns the number of records written.
+func (s *Service) ExportCatalog(w io.Writer, kind string, format string, sortBy string, sortDirection string, filter models.MovieFilter, langs []string) (int, error) {
+	const op = "service.ExportCatalog"
+
+	if format != CatalogCSV && format != CatalogJSON && format != CatalogNDJSON {
+		return 0, fmt.Errorf("%s: %w", op, ErrUnsupportedExportFormat)
+	}
+
+	var n int
+	var err error
+	switch kind {
+	case models.CatalogMovie:
+		if err := validateReleaseFilter(&filter); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := validateFactsFilter(&filter); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		writer := newCatalogWriter(w, format, movieExportColumns, movieExportRow)
+		err = s.catalogStorage.ExportMoviesStorage(sortBy, sortDirection, filter, langs, writer.Write)
+		if err == nil {
+			err = writer.Close()
+		}
+		n = writer.written
+	case models.CatalogActor:
+		now := time.Now()
+		writer := newCatalogWriter(w, format, actorExportColumns, actorExportRow)
+		err = s.catalogStorage.ExportActorsStorage(langs, func(actor *models.ActorListing) error {
+			actor.Age = ageOf(actor.Birthday, actor.DeathDate, now)
+			return writer.Write(actor)
+		})
+		if err == nil {
+			err = writer.Close()
+		}
+		n = writer.written
+	default:
+		return 0, fmt.Errorf("%s: %w", op, ErrUnsupportedExportType)
+	}
+	if err != nil {
+		return n, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+// movieExportColumns are the columns of a CSV export of movies, lists are separated
+// by semicolons like the cast of an imported movie.
+var movieExportColumns = []string{
+	"id", "title", "description", "release_date", "rating", "user_rating", "votes_count",
+	"genres", "actors", "runtime", "budget_amount", "budget_currency", "gross_amount", "gross_currency",
+	"original_language", "spoken_languages", "countries", "imdb_id", "kinopoisk_id",
+}
+
+func movieExportRow(movie *models.MovieListing) []string {
+	var budgetAmount, budgetCurrency, grossAmount, grossCurrency string
+	if movie.Budget != nil {
+		budgetAmount, budgetCurrency = strconv.FormatInt(movie.Budget.Amount, 10), movie.Budget.Currency
+	}
+	if movie.Gross != nil {
+		grossAmount, grossCurrency = strconv.FormatInt(movie.Gross.Amount, 10), movie.Gross.Currency
+	}
+	var runtime string
+	if movie.Runtime > 0 {
+		runtime = strconv.Itoa(movie.Runtime)
+	}
+	var imdbID, kinopoiskID string
+	if movie.ExternalIDs != nil {
+		imdbID, kinopoiskID = movie.ExternalIDs.IMDb, movie.ExternalIDs.Kinopoisk
+	}
+
+	return []string{
+		strconv.FormatInt(movie.ID, 10), movie.Title, movie.Description, exportDate(movie.ReleaseDate),
+		exportFloat(movie.Rating), exportFloat(movie.UserRating), strconv.FormatInt(movie.VotesCount, 10),
+		strings.Join(movie.Genres, ";"), strings.Join(movie.Actors, ";"), runtime,
+		budgetAmount, budgetCurrency, grossAmount, grossCurrency,
+		movie.OriginalLanguage, strings.Join(movie.SpokenLanguages, ";"), strings.Join(movie.Countries, ";"),
+		imdbID, kinopoiskID,
+	}
+}
+
+// actorExportColumns are the columns of a CSV export of actors.
+var actorExportColumns = []string{"id", "name", "sex", "birthday", "death_date", "age", "movies"}
+
+func actorExportRow(actor *models.ActorListing) []string {
+	var deathDate, age string
+	if actor.DeathDate != nil {
+		deathDate = exportDate(*actor.DeathDate)
+	}
+	if actor.Age != nil {
+		age = strconv.Itoa(*actor.Age)
+	}
+
+	return []string{
+		strconv.FormatInt(actor.ID, 10), actor.Name, actor.Sex, exportDate(actor.Birthday), deathDate, age,
+		strings.Join(actor.Movies, ";"),
+	}
+}
+
+// exportDate writes a date like importDate reads it, an unknown date is left empty.
+func exportDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(importDateLayout)
+}
+
+func exportFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+// catalogWriter writes the records of an export one by one in its format. The CSV
+// header or the opening bracket of a JSON array is only written with the first record,
+// or by Close when there is none.
+type catalogWriter[T any] struct {
+	w       io.Writer
+	format  string
+	columns []string
+	row     func(T) []string
+	csv     *csv.Writer
+	written int
+}
+
+func newCatalogWriter[T any](w io.Writer, format string, columns []string, row func(T) []string) *catalogWriter[T] {
+	return &catalogWriter[T]{w: w, format: format, columns: columns, row: row, csv: csv.NewWriter(w)}
+}
+
+func (c *catalogWriter[T]) Write(record T) error {
+	if c.written == 0 {
+		if err := c.start(); err != nil {
+			return err
+		}
+	}
+
+	switch c.format {
+	case CatalogCSV:
+		c.csv.Write(c.row(record))
+		c.csv.Flush()
+		if err := c.csv.Error(); err != nil {
+			return err
+		}
+	default:
+		recordJSON, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		switch {
+		case c.format == CatalogNDJSON:
+			recordJSON = append(recordJSON, '\n')
+		case c.written > 0:
+			recordJSON = append([]byte{','}, recordJSON...)
+		}
+		if _, err := c.w.Write(recordJSON); err != nil {
+			return err
+		}
+	}
+
+	c.written++
+	return nil
+}
+
+func (c *catalogWriter[T]) start() error {
+	switch c.format {
+	case CatalogCSV:
+		c.csv.Write(c.columns)
+		c.csv.Flush()
+		return c.csv.Error()
+	case CatalogJSON:
+		_, err := io.WriteString(c.w, "[")
+		return err
+	}
+	return nil
+}
+
+// Close ends the export, writing the header of an empty CSV export and closing a JSON
+// array.
+func (c *catalogWriter[T]) Close() error {
+	if c.written == 0 {
+		if err := c.start(); err != nil {
+			return err
+		}
+	}
+	if c.format == CatalogJSON {
+		_, err := io.WriteString(c.w, "]\n")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryCatalogStorage struct {
+	CatalogStorage
+	imported []*models.ImportItem
+	movies   []*models.MovieListing
+	actors   []*models.ActorListing
+	err      error
+}
+
+func (m *memoryCatalogStorage) ImportCatalogStorage(next func() (*models.ImportItem, error), dryRun bool) ([]*models.ImportChange, error) {
+	var changes []*models.ImportChange
+	for {
+		item, err := next()
+		if err == io.EOF {
+			return changes, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.imported = append(m.imported, item)
+		changes = append(changes, &models.ImportChange{Row: item.Row, Action: models.ImportCreate})
+	}
+}
+
+func (m *memoryCatalogStorage) ExportMoviesStorage(sortBy string, sortDirection string, filter models.MovieFilter, langs []string, each func(*models.MovieListing) error) error {
+	for _, movie := range m.movies {
+		if err := each(movie); err != nil {
+			return err
+		}
+	}
+	return m.err
+}
+
+func (m *memoryCatalogStorage) ExportActorsStorage(langs []string, each func(*models.ActorListing) error) error {
+	for _, actor := range m.actors {
+		if err := each(actor); err != nil {
+			return err
+		}
+	}
+	return m.err
+}
+
+func TestService_ImportCatalog(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		dump       string
+		wantRows   int
+		wantItems  []int
+		wantErrors []string
+	}{
+		{
+			name:   "CSV",
+			format: CatalogCSV,
+			dump: "type,title,release_date,rating,cast,name,birthday\n" +
+				"movie,Heat,1995-12-15,8.3,nm0000199; nm0000134,,\n" +
+				"movie,Heat,1995-12-15,high,,,\n" +
+				"actor,,,,,Al Pacino,\n" +
+				"movie,Heat\n" +
+				"actor,,,,,Robert De Niro,1943-08-17\n",
+			wantRows:   5,
+			wantItems:  []int{2, 6},
+			wantErrors: []string{"3: rating must be a number", "4: birthday is required", "5: record on line 5: wrong number of fields"},
+		},
+		{
+			name:       "CSV unknown column",
+			format:     CatalogCSV,
+			dump:       "type,year\nmovie,1995\n",
+			wantErrors: []string{`1: unknown column "year", the rest of the dump is not read`},
+		},
+		{
+			name:   "JSON",
+			format: CatalogJSON,
+			dump: `[{"type":"movie","title":"Heat","release_date":"1995-12-15","cast":["tt0113277"]},` +
+				`{"type":"actor","name":"Al Pacino","birthday":"1940-04-25","year":1940},` +
+				`{"type":"movie","title":"Heat","release_date":"15.12.1995"},` +
+				`{"type":"actor","name":"Al Pacino","birthday":"1940-04-25"},` +
+				`{"type":"movie" "title":"Heat"}]`,
+			wantRows:  4,
+			wantItems: []int{4},
+			wantErrors: []string{
+				"1: " + ErrInvalidIMDbID.Error(),
+				`2: json: unknown field "year"`,
+				"3: release_date must be a date like 2006-01-02",
+				"5: invalid character '\"' after object key:value pair, the rest of the dump is not read",
+			},
+		},
+		{
+			name:       "JSON object",
+			format:     CatalogJSON,
+			dump:       `{"type":"movie"}`,
+			wantErrors: []string{"1: dump must be a JSON array of records, the rest of the dump is not read"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalogStorage := &memoryCatalogStorage{}
+			s := &Service{catalogStorage: catalogStorage}
+
+			report, err := s.ImportCatalog(strings.NewReader(tt.dump), tt.format, false)
+			if err != nil {
+				t.Fatalf("ImportCatalog() error = %v", err)
+			}
+
+			var items []int
+			for _, item := range catalogStorage.imported {
+				items = append(items, item.Row)
+			}
+			var errs []string
+			for _, importErr := range report.Errors {
+				errs = append(errs, fmt.Sprintf("%d: %s", importErr.Row, importErr.Message))
+			}
+
+			if report.Rows != tt.wantRows {
+				t.Errorf("Rows = %d, want %d", report.Rows, tt.wantRows)
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("imported rows %v, want %v", items, tt.wantItems)
+			}
+			if !reflect.DeepEqual(errs, tt.wantErrors) {
+				t.Errorf("Errors = %q, want %q", errs, tt.wantErrors)
+			}
+			if report.Created != len(tt.wantItems) || report.Failed != len(tt.wantErrors) {
+				t.Errorf("Created, Failed = %d, %d, want %d, %d", report.Created, report.Failed, len(tt.wantItems), len(tt.wantErrors))
+			}
+		})
+	}
+}
+
+func TestService_ImportCatalog_UnsupportedFormat(t *testing.T) {
+	s := &Service{catalogStorage: &memoryCatalogStorage{}}
+
+	if _, err := s.ImportCatalog(strings.NewReader(""), "xml", false); !errors.Is(err, ErrUnsupportedCatalogFormat) {
+		t.Errorf("ImportCatalog() error = %v, want %v", err, ErrUnsupportedCatalogFormat)
+	}
+}
+
+func TestService_ExportCatalog(t *testing.T) {
+	rating := 8.3
+	movies := []*models.MovieListing{
+		{
+			ID: 1, Title: "Heat", ReleaseDate: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC), Rating: &rating,
+			Actors: []string{"Al Pacino", "Robert De Niro"}, Genres: []string{"Crime", "Drama"},
+			MovieFacts: models.MovieFacts{Runtime: 170, Gross: &models.Money{Amount: 187436818, Currency: "USD"}, ExternalIDs: &models.ExternalIDs{IMDb: "tt0113277"}},
+		},
+		{ID: 2, Title: "Ronin, the \"Samurai\"", ReleaseDate: time.Date(1998, 9, 25, 0, 0, 0, 0, time.UTC), Actors: []string{}},
+	}
+	errReset := errors.New("connection reset")
+	deathDate := time.Date(2004, 7, 1, 0, 0, 0, 0, time.UTC)
+	actors := []*models.ActorListing{
+		{ID: 3, Name: "Marlon Brando", Sex: "male", Birthday: time.Date(1924, 4, 3, 0, 0, 0, 0, time.UTC), DeathDate: &deathDate, Movies: []string{"The Godfather"}},
+	}
+
+	tests := []struct {
+		name       string
+		kind       string
+		format     string
+		movies     []*models.MovieListing
+		storageErr error
+		want       string
+		wantN      int
+		wantErr    error
+	}{
+		{
+			name:   "Movies CSV",
+			kind:   models.CatalogMovie,
+			format: CatalogCSV,
+			movies: movies,
+			want: "id,title,description,release_date,rating,user_rating,votes_count,genres,actors,runtime,budget_amount,budget_currency,gross_amount,gross_currency,original_language,spoken_languages,countries,imdb_id,kinopoisk_id\n" +
+				"1,Heat,,1995-12-15,8.3,,0,Crime;Drama,Al Pacino;Robert De Niro,170,,,187436818,USD,,,,tt0113277,\n" +
+				"2,\"Ronin, the \"\"Samurai\"\"\",,1998-09-25,,,0,,,,,,,,,,,,\n",
+			wantN: 2,
+		},
+		{
+			name:   "Movies JSON",
+			kind:   models.CatalogMovie,
+			format: CatalogJSON,
+			movies: movies[1:],
+			want:   `[{"id":2,"title":"Ronin, the \"Samurai\"","release_date":"1998-09-25T00:00:00Z","actors_id":[]}]` + "\n",
+			wantN:  1,
+		},
+		{
+			name:   "Empty JSON",
+			kind:   models.CatalogMovie,
+			format: CatalogJSON,
+			want:   "[]\n",
+		},
+		{
+			name:   "Actors NDJSON",
+			kind:   models.CatalogActor,
+			format: CatalogNDJSON,
+			want:   `{"id":3,"name":"Marlon Brando","sex":"male","birthday":"1924-04-03T00:00:00Z","death_date":"2004-07-01T00:00:00Z","age":80,"movies":["The Godfather"]}` + "\n",
+			wantN:  1,
+		},
+		{
+			name:   "Actors CSV",
+			kind:   models.CatalogActor,
+			format: CatalogCSV,
+			want:   "id,name,sex,birthday,death_date,age,movies\n3,Marlon Brando,male,1924-04-03,2004-07-01,80,The Godfather\n",
+			wantN:  1,
+		},
+		{
+			name:       "Storage fails before the first record",
+			kind:       models.CatalogMovie,
+			format:     CatalogJSON,
+			storageErr: errReset,
+			wantErr:    errReset,
+		},
+		{
+			name:       "Storage fails after a record",
+			kind:       models.CatalogMovie,
+			format:     CatalogNDJSON,
+			movies:     movies[1:],
+			storageErr: errReset,
+			want:       `{"id":2,"title":"Ronin, the \"Samurai\"","release_date":"1998-09-25T00:00:00Z","actors_id":[]}` + "\n",
+			wantN:      1,
+			wantErr:    errReset,
+		},
+		{
+			name:    "Unsupported format",
+			kind:    models.CatalogMovie,
+			format:  "xml",
+			wantErr: ErrUnsupportedExportFormat,
+		},
+		{
+			name:    "Unsupported type",
+			kind:    "genre",
+			format:  CatalogCSV,
+			wantErr: ErrUnsupportedExportType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{catalogStorage: &memoryCatalogStorage{movies: tt.movies, actors: actors, err: tt.storageErr}}
+
+			var out strings.Builder
+			n, err := s.ExportCatalog(&out, tt.kind, tt.format, "", "", models.MovieFilter{}, nil)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExportCatalog() error = %v, want %v", err, tt.wantErr)
+			}
+			if n != tt.wantN {
+				t.Errorf("ExportCatalog() = %d, want %d", n, tt.wantN)
+			}
+			if out.String() != tt.want {
+				t.Errorf("ExportCatalog() wrote %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ExportCatalog_InvalidFilter(t *testing.T) {
+	s := &Service{catalogStorage: &memoryCatalogStorage{}}
+
+	filter := models.MovieFilter{MinRuntime: 120, MaxRuntime: 90}
+	if _, err := s.ExportCatalog(io.Discard, models.CatalogMovie, CatalogCSV, "", "", filter, nil); !errors.Is(err, ErrInvalidFactsRange) {
+		t.Errorf("ExportCatalog() error = %v, want %v", err, ErrInvalidFactsRange)
+	}
+}
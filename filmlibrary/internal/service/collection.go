@@ -0,0 +1,188 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidCollectionTitle  = errors.New("collection title is required")
+	ErrInvalidSlug             = errors.New("slug may only contain lowercase letters, digits and hyphens")
+	ErrInvalidCollectionMovies = errors.New("collection movies must list each movie once")
+)
+
+type CollectionStorage interface {
+	AddCollectionStorage(collection *models.Collection) error
+	EditCollectionStorage(collection *models.Collection) error
+	DeleteCollectionStorage(ownerID, id int64) error
+	AddMoviesToCollectionStorage(ownerID, collectionID int64, movieIDs []int64) error
+	DeleteMovieFromCollectionStorage(ownerID, collectionID, movieID int64) error
+	ReorderCollectionStorage(ownerID, collectionID int64, movieIDs []int64) error
+	GetPublicCollectionsStorage(limit, offset int) ([]*models.Collection, int64, error)
+	GetUserCollectionsStorage(ownerID int64) ([]*models.Collection, error)
+	GetCollectionStorage(slug string, viewerID int64) (*models.Collection, error)
+}
+
+// AddCollection creates a collection, its slug is made from the title unless one is given.
+func (s *Service) AddCollection(collection *models.Collection) error {
+	const op = "service.AddCollection"
+
+	collection.Title = strings.TrimSpace(collection.Title)
+	if collection.Title == "" {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCollectionTitle)
+	}
+
+	if collection.Slug == "" {
+		collection.Slug = slugify(collection.Title)
+	}
+	if !validSlug(collection.Slug) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidSlug)
+	}
+
+	err := s.collectionStorage.AddCollectionStorage(collection)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) EditCollection(collection *models.Collection) error {
+	const op = "service.EditCollection"
+
+	collection.Title = strings.TrimSpace(collection.Title)
+	if collection.Title == "" {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCollectionTitle)
+	}
+
+	err := s.collectionStorage.EditCollectionStorage(collection)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteCollection(ownerID, id int64) error {
+	const op = "service.DeleteCollection"
+
+	err := s.collectionStorage.DeleteCollectionStorage(ownerID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) AddMoviesToCollection(ownerID, collectionID int64, movieIDs []int64) error {
+	const op = "service.AddMoviesToCollection"
+
+	if len(movieIDs) == 0 || hasDuplicates(movieIDs) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCollectionMovies)
+	}
+
+	err := s.collectionStorage.AddMoviesToCollectionStorage(ownerID, collectionID, movieIDs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteMovieFromCollection(ownerID, collectionID, movieID int64) error {
+	const op = "service.DeleteMovieFromCollection"
+
+	err := s.collectionStorage.DeleteMovieFromCollectionStorage(ownerID, collectionID, movieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReorderCollection puts the listed movies first, in the given order.
+func (s *Service) ReorderCollection(ownerID, collectionID int64, movieIDs []int64) error {
+	const op = "service.ReorderCollection"
+
+	if len(movieIDs) == 0 || hasDuplicates(movieIDs) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCollectionMovies)
+	}
+
+	err := s.collectionStorage.ReorderCollectionStorage(ownerID, collectionID, movieIDs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetPublicCollections(page, limit int) (*models.CollectionsPage, error) {
+	const op = "service.GetPublicCollections"
+
+	collections, total, err := s.collectionStorage.GetPublicCollectionsStorage(limit, (page-1)*limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &models.CollectionsPage{Collections: collections, Total: total, Page: page, Limit: limit}, nil
+}
+
+func (s *Service) GetUserCollections(ownerID int64) ([]*models.Collection, error) {
+	const op = "service.GetUserCollections"
+
+	collections, err := s.collectionStorage.GetUserCollectionsStorage(ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collections, nil
+}
+
+func (s *Service) GetCollection(slug string, viewerID int64) (*models.Collection, error) {
+	const op = "service.GetCollection"
+
+	collection, err := s.collectionStorage.GetCollectionStorage(slug, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collection, nil
+}
+
+// slugify lowercases the title and joins its words with hyphens.
+func slugify(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, "-")
+}
+
+func validSlug(slug string) bool {
+	if slug == "" || len(slug) > 100 || strings.HasPrefix(slug, "-") || strings.HasSuffix(slug, "-") {
+		return false
+	}
+
+	for _, r := range slug {
+		if r != '-' && !unicode.IsDigit(r) && !(unicode.IsLetter(r) && unicode.IsLower(r)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasDuplicates(ids []int64) bool {
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+	}
+
+	return false
+}
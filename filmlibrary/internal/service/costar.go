@@ -0,0 +1,210 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxSeparationDepth is the longest chain of movies the separation search looks for.
+	MaxSeparationDepth = 6
+	separationTimeout  = 2 * time.Second
+)
+
+var (
+	ErrActorNotFound      = errors.New("actor not found")
+	ErrInvalidDepth       = fmt.Errorf("depth must be between 1 and %d", MaxSeparationDepth)
+	ErrActorsNotConnected = errors.New("actors are not connected within the depth limit")
+	ErrSeparationTimeout  = errors.New("separation search timed out")
+)
+
+type CostarStorage interface {
+	GetActorCreditsStorage() ([]*models.ActorCredit, error)
+}
+
+// costarIndex is the in-memory actor-movie graph the separation search runs over.
+type costarIndex struct {
+	actorMovies map[int64][]int64
+	movieActors map[int64][]int64
+	actorNames  map[int64]string
+	movieTitles map[int64]string
+}
+
+func newCostarIndex(credits []*models.ActorCredit) *costarIndex {
+	index := &costarIndex{
+		actorMovies: make(map[int64][]int64),
+		movieActors: make(map[int64][]int64),
+		actorNames:  make(map[int64]string),
+		movieTitles: make(map[int64]string),
+	}
+
+	for _, credit := range credits {
+		index.actorMovies[credit.PersonID] = append(index.actorMovies[credit.PersonID], credit.MovieID)
+		index.movieActors[credit.MovieID] = append(index.movieActors[credit.MovieID], credit.PersonID)
+		index.actorNames[credit.PersonID] = credit.PersonName
+		index.movieTitles[credit.MovieID] = credit.MovieTitle
+	}
+
+	return index
+}
+
+// costarGraph holds the index until the catalog changes, it is rebuilt on the next search.
+type costarGraph struct {
+	mu    sync.Mutex
+	index *costarIndex
+}
+
+func (s *Service) costarIndex() (*costarIndex, error) {
+	s.costars.mu.Lock()
+	defer s.costars.mu.Unlock()
+
+	if s.costars.index == nil {
+		credits, err := s.costarStorage.GetActorCreditsStorage()
+		if err != nil {
+			return nil, err
+		}
+		s.costars.index = newCostarIndex(credits)
+	}
+
+	return s.costars.index, nil
+}
+
+// invalidateCostars drops the co-star index after a change to movies, actors or casts.
+func (s *Service) invalidateCostars() {
+	s.costars.mu.Lock()
+	defer s.costars.mu.Unlock()
+
+	s.costars.index = nil
+}
+
+// GetSeparation finds the shortest chain of movies connecting two actors with a
+// breadth-first search, giving up after maxDepth movies or when the time limit is hit.
+func (s *Service) GetSeparation(fromID, toID int64, maxDepth int) (*models.Separation, error) {
+	const op = "service.GetSeparation"
+
+	if maxDepth < 1 || maxDepth > MaxSeparationDepth {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidDepth)
+	}
+
+	index, err := s.costarIndex()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, ok := index.actorMovies[fromID]; !ok {
+		return nil, fmt.Errorf("%s: %w: %d", op, ErrActorNotFound, fromID)
+	}
+	if _, ok := index.actorMovies[toID]; !ok {
+		return nil, fmt.Errorf("%s: %w: %d", op, ErrActorNotFound, toID)
+	}
+
+	type step struct {
+		actor int64
+		movie int64
+	}
+
+	// cameFrom maps every reached actor to the actor and movie it was reached through
+	cameFrom := map[int64]step{fromID: {}}
+	visitedMovies := make(map[int64]bool)
+	deadline := time.Now().Add(separationTimeout)
+
+	frontier := []int64{fromID}
+	for depth := 0; depth < maxDepth && len(frontier) > 0; depth++ {
+		if _, ok := cameFrom[toID]; ok {
+			break
+		}
+
+		var next []int64
+		for _, actor := range frontier {
+			if time.Now().After(deadline) {
+				return nil, fmt.Errorf("%s: %w", op, ErrSeparationTimeout)
+			}
+
+			for _, movie := range index.actorMovies[actor] {
+				if visitedMovies[movie] {
+					continue
+				}
+				visitedMovies[movie] = true
+
+				for _, costar := range index.movieActors[movie] {
+					if _, ok := cameFrom[costar]; ok {
+						continue
+					}
+					cameFrom[costar] = step{actor: actor, movie: movie}
+					next = append(next, costar)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	if _, ok := cameFrom[toID]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, ErrActorsNotConnected)
+	}
+
+	separation := &models.Separation{
+		Actors: []*models.ActorRef{{ID: toID, Name: index.actorNames[toID]}},
+		Movies: []*models.MovieRef{},
+	}
+	for actor := toID; actor != fromID; {
+		prev := cameFrom[actor]
+		separation.Movies = append(separation.Movies, &models.MovieRef{ID: prev.movie, Title: index.movieTitles[prev.movie]})
+		separation.Actors = append(separation.Actors, &models.ActorRef{ID: prev.actor, Name: index.actorNames[prev.actor]})
+		actor = prev.actor
+	}
+	slices.Reverse(separation.Actors)
+	slices.Reverse(separation.Movies)
+	separation.Degrees = len(separation.Movies)
+
+	return separation, nil
+}
+
+// GetCostars lists the actors who played with the given one, most shared movies first.
+func (s *Service) GetCostars(actorID int64, page, limit int) ([]*models.Costar, error) {
+	const op = "service.GetCostars"
+
+	index, err := s.costarIndex()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	movies, ok := index.actorMovies[actorID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: %d", op, ErrActorNotFound, actorID)
+	}
+
+	shared := make(map[int64]int)
+	for _, movie := range movies {
+		for _, costar := range index.movieActors[movie] {
+			if costar != actorID {
+				shared[costar]++
+			}
+		}
+	}
+
+	costars := make([]*models.Costar, 0, len(shared))
+	for id, count := range shared {
+		costars = append(costars, &models.Costar{ID: id, Name: index.actorNames[id], SharedMovies: count})
+	}
+	sort.Slice(costars, func(i, j int) bool {
+		if costars[i].SharedMovies != costars[j].SharedMovies {
+			return costars[i].SharedMovies > costars[j].SharedMovies
+		}
+		if costars[i].Name != costars[j].Name {
+			return costars[i].Name < costars[j].Name
+		}
+		return costars[i].ID < costars[j].ID
+	})
+
+	offset := (page - 1) * limit
+	if offset >= len(costars) {
+		return []*models.Costar{}, nil
+	}
+
+	return costars[offset:min(offset+limit, len(costars))], nil
+}
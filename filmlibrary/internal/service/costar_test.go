@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"testing"
+)
+
+type creditsStorage []*models.ActorCredit
+
+func (c creditsStorage) GetActorCreditsStorage() ([]*models.ActorCredit, error) {
+	return c, nil
+}
+
+func TestService_GetSeparation(t *testing.T) {
+	// 1 and 2 played in movie 10, 2 and 3 in movie 20, 3 and 4 in movie 30, 5 played alone
+	credits := creditsStorage{
+		{PersonID: 1, MovieID: 10}, {PersonID: 2, MovieID: 10},
+		{PersonID: 2, MovieID: 20}, {PersonID: 3, MovieID: 20},
+		{PersonID: 3, MovieID: 30}, {PersonID: 4, MovieID: 30},
+		{PersonID: 5, MovieID: 40},
+	}
+	s := &Service{costarStorage: credits}
+
+	tests := []struct {
+		name       string
+		from, to   int64
+		maxDepth   int
+		wantMovies []int64
+		wantErr    error
+	}{
+		{name: "Direct co-stars", from: 1, to: 2, maxDepth: 6, wantMovies: []int64{10}},
+		{name: "Three degrees", from: 1, to: 4, maxDepth: 6, wantMovies: []int64{10, 20, 30}},
+		{name: "Beyond depth limit", from: 1, to: 4, maxDepth: 2, wantErr: ErrActorsNotConnected},
+		{name: "Not connected", from: 1, to: 5, maxDepth: 6, wantErr: ErrActorsNotConnected},
+		{name: "Unknown actor", from: 1, to: 99, maxDepth: 6, wantErr: ErrActorNotFound},
+		{name: "Depth too large", from: 1, to: 2, maxDepth: 7, wantErr: ErrInvalidDepth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			separation, err := s.GetSeparation(tt.from, tt.to, tt.maxDepth)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSeparation() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if separation.Degrees != len(tt.wantMovies) || len(separation.Actors) != len(tt.wantMovies)+1 {
+				t.Fatalf("GetSeparation() = %+v, want %d degrees", separation, len(tt.wantMovies))
+			}
+			for i, movie := range separation.Movies {
+				if movie.ID != tt.wantMovies[i] {
+					t.Errorf("movie %d: got %d, want %d", i, movie.ID, tt.wantMovies[i])
+				}
+			}
+			if separation.Actors[0].ID != tt.from || separation.Actors[len(separation.Actors)-1].ID != tt.to {
+				t.Errorf("GetSeparation() chain %+v does not go from %d to %d", separation.Actors, tt.from, tt.to)
+			}
+		})
+	}
+}
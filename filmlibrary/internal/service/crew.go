@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"slices"
+)
+
+var ErrInvalidCrewRole = errors.New("invalid crew role")
+
+type CrewStorage interface {
+	AddPersonStorage(person *models.Person) error
+	GetFilmographyStorage(personID int64) (*models.Filmography, error)
+	GetMovieCrewStorage(movieID int64) ([]*models.CrewMember, error)
+	AddCrewToMovieStorage(movieID int64, crew []models.CrewRole) error
+	DeleteCrewFromMovieStorage(movieID, personID int64, role string) error
+}
+
+func (s *Service) AddPerson(person *models.Person) error {
+	const op = "service.AddPerson"
+
+	err := s.crewStorage.AddPersonStorage(person)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetFilmography(personID int64) (*models.Filmography, error) {
+	const op = "service.GetFilmography"
+
+	filmography, err := s.crewStorage.GetFilmographyStorage(personID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return filmography, nil
+}
+
+func (s *Service) GetMovieCrew(movieID int64) ([]*models.CrewMember, error) {
+	const op = "service.GetMovieCrew"
+
+	crew, err := s.crewStorage.GetMovieCrewStorage(movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return crew, nil
+}
+
+func (s *Service) AddCrewToMovie(movieID int64, crew []models.CrewRole) error {
+	const op = "service.AddCrewToMovie"
+
+	for _, member := range crew {
+		if !slices.Contains(models.CrewRoles, member.Role) {
+			return fmt.Errorf("%s: %w: %q", op, ErrInvalidCrewRole, member.Role)
+		}
+	}
+
+	err := s.crewStorage.AddCrewToMovieStorage(movieID, crew)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidateCostars()
+
+	return nil
+}
+
+func (s *Service) DeleteCrewFromMovie(movieID, personID int64, role string) error {
+	const op = "service.DeleteCrewFromMovie"
+
+	err := s.crewStorage.DeleteCrewFromMovieStorage(movieID, personID, role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidateCostars()
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidFranchiseName   = errors.New("franchise name is empty")
+	ErrInvalidFranchiseMovies = errors.New("franchise movies must not repeat")
+	ErrInvalidRelationType    = errors.New("relation type must be one of sequel, prequel, remake or spin_off")
+	ErrSelfRelation           = errors.New("a movie cannot be related to itself")
+	ErrRelationCycle          = errors.New("relation would make the sequel chain a cycle")
+)
+
+type FranchiseStorage interface {
+	AddFranchiseStorage(franchise *models.Franchise) error
+	EditFranchiseStorage(franchise *models.Franchise) error
+	DeleteFranchiseStorage(id int64) error
+	GetFranchisesStorage() ([]*models.Franchise, error)
+	GetFranchiseStorage(id int64) (*models.Franchise, error)
+	SetFranchiseMoviesStorage(franchiseID int64, movieIDs []int64) error
+	UpsertMovieRelationStorage(relation *models.MovieRelation, check func(later func(movieID int64) ([]int64, error)) error) error
+	DeleteMovieRelationStorage(movieID, relatedMovieID int64) error
+}
+
+func (s *Service) AddFranchise(franchise *models.Franchise) error {
+	const op = "service.AddFranchise"
+
+	franchise.Name = strings.TrimSpace(franchise.Name)
+	if franchise.Name == "" {
+		return fmt.Errorf("%s: %w", op, ErrInvalidFranchiseName)
+	}
+
+	err := s.franchiseStorage.AddFranchiseStorage(franchise)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) EditFranchise(franchise *models.Franchise) error {
+	const op = "service.EditFranchise"
+
+	franchise.Name = strings.TrimSpace(franchise.Name)
+	if franchise.Name == "" {
+		return fmt.Errorf("%s: %w", op, ErrInvalidFranchiseName)
+	}
+
+	err := s.franchiseStorage.EditFranchiseStorage(franchise)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteFranchise(id int64) error {
+	const op = "service.DeleteFranchise"
+
+	err := s.franchiseStorage.DeleteFranchiseStorage(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetFranchises() ([]*models.Franchise, error) {
+	const op = "service.GetFranchises"
+
+	franchises, err := s.franchiseStorage.GetFranchisesStorage()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return franchises, nil
+}
+
+func (s *Service) GetFranchise(id int64) (*models.Franchise, error) {
+	const op = "service.GetFranchise"
+
+	franchise, err := s.franchiseStorage.GetFranchiseStorage(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return franchise, nil
+}
+
+// SetFranchiseMovies replaces the movies of the franchise, the order of movieIDs is
+// the order of the franchise. An empty list empties the franchise.
+func (s *Service) SetFranchiseMovies(franchiseID int64, movieIDs []int64) error {
+	const op = "service.SetFranchiseMovies"
+
+	if hasDuplicates(movieIDs) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidFranchiseMovies)
+	}
+
+	err := s.franchiseStorage.SetFranchiseMoviesStorage(franchiseID, movieIDs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RelateMovies sets the relation between two movies. Sequels and prequels must keep
+// the story in order, so a movie cannot end up before itself in a sequel chain.
+func (s *Service) RelateMovies(relation *models.MovieRelation) error {
+	const op = "service.RelateMovies"
+
+	if !slices.Contains(models.RelationTypes, relation.Type) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidRelationType)
+	}
+	if relation.MovieID == relation.RelatedMovieID {
+		return fmt.Errorf("%s: %w", op, ErrSelfRelation)
+	}
+
+	err := s.franchiseStorage.UpsertMovieRelationStorage(relation, func(later func(movieID int64) ([]int64, error)) error {
+		if relation.Type != models.RelationSequel && relation.Type != models.RelationPrequel {
+			return nil
+		}
+
+		before, after := relation.MovieID, relation.RelatedMovieID
+		if relation.Type == models.RelationPrequel {
+			before, after = after, before
+		}
+
+		// later leaves out the relation the pair has now, as this one replaces it
+		following, err := later(after)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(following, before) {
+			return ErrRelationCycle
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteMovieRelation(movieID, relatedMovieID int64) error {
+	const op = "service.DeleteMovieRelation"
+
+	err := s.franchiseStorage.DeleteMovieRelationStorage(movieID, relatedMovieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
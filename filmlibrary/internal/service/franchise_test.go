@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"testing"
+)
+
+type memoryFranchiseStorage struct {
+	FranchiseStorage
+	relations []*models.MovieRelation
+	saved     []*models.MovieRelation
+}
+
+func (m *memoryFranchiseStorage) UpsertMovieRelationStorage(relation *models.MovieRelation, check func(later func(movieID int64) ([]int64, error)) error) error {
+	if err := check(func(movieID int64) ([]int64, error) { return m.later(movieID, relation), nil }); err != nil {
+		return err
+	}
+	m.saved = append(m.saved, relation)
+	return nil
+}
+
+// later walks the sequel edges of the relations other than the one of the replaced pair.
+func (m *memoryFranchiseStorage) later(movieID int64, replaced *models.MovieRelation) []int64 {
+	sequels := map[int64][]int64{}
+	for _, r := range m.relations {
+		switch {
+		case r.MovieID == replaced.MovieID && r.RelatedMovieID == replaced.RelatedMovieID:
+		case r.Type == models.RelationSequel:
+			sequels[r.MovieID] = append(sequels[r.MovieID], r.RelatedMovieID)
+		case r.Type == models.RelationPrequel:
+			sequels[r.RelatedMovieID] = append(sequels[r.RelatedMovieID], r.MovieID)
+		}
+	}
+
+	var later []int64
+	queue := []int64{movieID}
+	seen := map[int64]bool{}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range sequels[id] {
+			if !seen[next] {
+				seen[next] = true
+				later = append(later, next)
+				queue = append(queue, next)
+			}
+		}
+	}
+	return later
+}
+
+func TestService_RelateMovies(t *testing.T) {
+	// 1 -> 2 -> 3 in story order
+	relations := []*models.MovieRelation{
+		{MovieID: 1, RelatedMovieID: 2, Type: models.RelationSequel},
+		{MovieID: 3, RelatedMovieID: 2, Type: models.RelationPrequel},
+	}
+
+	tests := []struct {
+		name     string
+		relation models.MovieRelation
+		wantErr  error
+	}{
+		{name: "Sequel extends the chain", relation: models.MovieRelation{MovieID: 3, RelatedMovieID: 4, Type: models.RelationSequel}},
+		{name: "Prequel extends the chain", relation: models.MovieRelation{MovieID: 1, RelatedMovieID: 0, Type: models.RelationPrequel}},
+		{name: "Sequel closes a cycle", relation: models.MovieRelation{MovieID: 3, RelatedMovieID: 1, Type: models.RelationSequel}, wantErr: ErrRelationCycle},
+		{name: "Prequel closes a cycle", relation: models.MovieRelation{MovieID: 1, RelatedMovieID: 3, Type: models.RelationPrequel}, wantErr: ErrRelationCycle},
+		{name: "Sequel turned into a prequel", relation: models.MovieRelation{MovieID: 1, RelatedMovieID: 2, Type: models.RelationPrequel}},
+		{name: "Prequel turned into a sequel", relation: models.MovieRelation{MovieID: 3, RelatedMovieID: 2, Type: models.RelationSequel}},
+		{name: "Reversed pair closes a cycle", relation: models.MovieRelation{MovieID: 2, RelatedMovieID: 1, Type: models.RelationSequel}, wantErr: ErrRelationCycle},
+		{name: "Remake is not ordered", relation: models.MovieRelation{MovieID: 3, RelatedMovieID: 1, Type: models.RelationRemake}},
+		{name: "Self relation", relation: models.MovieRelation{MovieID: 2, RelatedMovieID: 2, Type: models.RelationSpinOff}, wantErr: ErrSelfRelation},
+		{name: "Invalid type", relation: models.MovieRelation{MovieID: 1, RelatedMovieID: 2, Type: models.RelationOriginal}, wantErr: ErrInvalidRelationType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			franchiseStorage := &memoryFranchiseStorage{relations: relations}
+			s := &Service{franchiseStorage: franchiseStorage}
+
+			relation := tt.relation
+			err := s.RelateMovies(&relation)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RelateMovies() error = %v, want %v", err, tt.wantErr)
+			}
+			if saved := len(franchiseStorage.saved) == 1; saved != (tt.wantErr == nil) {
+				t.Errorf("relation saved = %t, want %t", saved, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestService_SetFranchiseMovies(t *testing.T) {
+	s := &Service{franchiseStorage: &memoryFranchiseStorage{}}
+
+	err := s.SetFranchiseMovies(1, []int64{4, 5, 4})
+	if !errors.Is(err, ErrInvalidFranchiseMovies) {
+		t.Fatalf("SetFranchiseMovies() error = %v, want %v", err, ErrInvalidFranchiseMovies)
+	}
+}
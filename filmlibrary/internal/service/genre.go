@@ -0,0 +1,90 @@
+package service
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+)
+
+type GenreStorage interface {
+	AddGenreStorage(genre *models.Genre) error
+	EditGenreStorage(genre *models.Genre) error
+	DeleteGenreStorage(id int64) error
+	GetGenresStorage() ([]*models.GenreListing, error)
+	GetGenreFacetsStorage(filter models.MovieFilter) ([]*models.GenreListing, error)
+	AddGenresToMovieStorage(movieID int64, genres []int64) error
+}
+
+func (s *Service) AddGenre(genre *models.Genre) error {
+	const op = "service.AddGenre"
+
+	err := s.genreStorage.AddGenreStorage(genre)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) EditGenre(genre *models.Genre) error {
+	const op = "service.EditGenre"
+
+	err := s.genreStorage.EditGenreStorage(genre)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteGenre(id int64) error {
+	const op = "service.DeleteGenre"
+
+	err := s.genreStorage.DeleteGenreStorage(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetGenres() ([]*models.GenreListing, error) {
+	const op = "service.GetGenres"
+
+	genres, err := s.genreStorage.GetGenresStorage()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return genres, nil
+}
+
+func (s *Service) AddGenresToMovie(movieID int64, genres []int64) error {
+	const op = "service.AddGenresToMovie"
+
+	err := s.genreStorage.AddGenresToMovieStorage(movieID, genres)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetMovieFacets counts the movies the listing keeps with the filter by genre, the
+// filter is checked like in GetMoviesSorted.
+func (s *Service) GetMovieFacets(filter models.MovieFilter) (*models.MovieFacets, error) {
+	const op = "service.GetMovieFacets"
+
+	if err := validateReleaseFilter(&filter); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := validateFactsFilter(&filter); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	genres, err := s.genreStorage.GetGenreFacetsStorage(filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &models.MovieFacets{Genres: genres}, nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/lib/logger/sl"
+	"fmt"
+	"golang.org/x/image/draw"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+)
+
+// DefaultMaxImageSize is the largest image upload accepted unless configured otherwise.
+const DefaultMaxImageSize = 5 << 20
+
+// DefaultMaxImagePixels is the largest width times height of an image accepted unless
+// configured otherwise. A small file can decode into a huge bitmap, so the size of the
+// upload alone does not bound the memory a decode takes.
+const DefaultMaxImagePixels = 40_000_000
+
+const (
+	ImageSizeOriginal = "original"
+	thumbnailQuality  = 85
+)
+
+// thumbnailWidths lists the generated thumbnails by name, they keep the aspect ratio
+// of the original and are never wider than it.
+var thumbnailWidths = map[string]int{
+	"small":  160,
+	"medium": 320,
+	"large":  640,
+}
+
+// imageSizes are all sizes an image can be downloaded in.
+var imageSizes = []string{ImageSizeOriginal, "small", "medium", "large"}
+
+var allowedImageTypes = []string{"image/jpeg", "image/png"}
+
+var (
+	ErrUnsupportedImageType = errors.New("image must be a JPEG or PNG")
+	ErrImageTooLarge        = errors.New("image is too large")
+	ErrImageTooManyPixels   = errors.New("image dimensions are too large")
+	ErrInvalidImage         = errors.New("image is corrupted")
+	ErrInvalidImageSize     = errors.New("image size must be one of original, small, medium or large")
+)
+
+type ImageStorage interface {
+	AddImageStorage(image *models.Image) error
+	GetImageStorage(id int64) (*models.Image, error)
+	GetImagesStorage(ownerType string, ownerID int64) ([]*models.Image, error)
+	DeleteImageStorage(id int64) error
+}
+
+// SetMaxImageSize changes the largest accepted image upload, in bytes.
+func (s *Service) SetMaxImageSize(size int64) {
+	s.maxImageSize = size
+}
+
+// SetMaxImagePixels changes the largest accepted width times height of an image.
+func (s *Service) SetMaxImagePixels(pixels int64) {
+	s.maxImagePixels = pixels
+}
+
+// UploadImage validates the image, attaches it to the movie or actor and stores it
+// along with its thumbnails.
+func (s *Service) UploadImage(ownerType string, ownerID int64, r io.Reader) (*models.Image, error) {
+	const op = "service.UploadImage"
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if int64(len(data)) > s.maxImageSize {
+		return nil, fmt.Errorf("%s: %w", op, ErrImageTooLarge)
+	}
+
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(allowedImageTypes, contentType) {
+		return nil, fmt.Errorf("%s: %w", op, ErrUnsupportedImageType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidImage, err)
+	}
+	if int64(config.Width)*int64(config.Height) > s.maxImagePixels {
+		return nil, fmt.Errorf("%s: %w", op, ErrImageTooManyPixels)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidImage, err)
+	}
+
+	thumbnails := make(map[string][]byte, len(thumbnailWidths))
+	for name, width := range thumbnailWidths {
+		thumbnails[name], err = thumbnail(src, width)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	img := &models.Image{
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       src.Bounds().Dx(),
+		Height:      src.Bounds().Dy(),
+		Sizes:       imageSizes,
+	}
+	err = s.imageStorage.AddImageStorage(img)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.blobStore.Put(imageKey(img.ID, ImageSizeOriginal), data, contentType)
+	for name, thumb := range thumbnails {
+		if err != nil {
+			break
+		}
+		err = s.blobStore.Put(imageKey(img.ID, name), thumb, "image/jpeg")
+	}
+	if err != nil {
+		s.imageStorage.DeleteImageStorage(img.ID)
+		s.deleteImageFiles(img.ID)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return img, nil
+}
+
+func (s *Service) GetImages(ownerType string, ownerID int64) ([]*models.Image, error) {
+	const op = "service.GetImages"
+
+	images, err := s.imageStorage.GetImagesStorage(ownerType, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, img := range images {
+		img.Sizes = imageSizes
+	}
+
+	return images, nil
+}
+
+// GetImage opens the image in the given size and returns its content type.
+// The caller closes the returned reader.
+func (s *Service) GetImage(id int64, size string) (io.ReadCloser, string, error) {
+	const op = "service.GetImage"
+
+	if !slices.Contains(imageSizes, size) {
+		return nil, "", fmt.Errorf("%s: %w", op, ErrInvalidImageSize)
+	}
+
+	img, err := s.imageStorage.GetImageStorage(id)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	contentType := "image/jpeg"
+	if size == ImageSizeOriginal {
+		contentType = img.ContentType
+	}
+
+	r, err := s.blobStore.Get(imageKey(id, size))
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return r, contentType, nil
+}
+
+func (s *Service) DeleteImage(id int64) error {
+	const op = "service.DeleteImage"
+
+	err := s.imageStorage.DeleteImageStorage(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.deleteImageFiles(id)
+
+	return nil
+}
+
+// deleteImageFiles deletes the stored files of an image. Failures are only logged,
+// an orphaned file is not worth failing the request for.
+func (s *Service) deleteImageFiles(id int64) {
+	for _, size := range imageSizes {
+		if err := s.blobStore.Delete(imageKey(id, size)); err != nil {
+			s.log.Warn("failed to delete an image file", slog.String("key", imageKey(id, size)), sl.Err(err))
+		}
+	}
+}
+
+func imageKey(id int64, size string) string {
+	return fmt.Sprintf("images/%d/%s", id, size)
+}
+
+// thumbnail scales the image down to the width and encodes it as a JPEG.
+// Transparent areas become white, as JPEG has no alpha channel.
+func thumbnail(src image.Image, width int) ([]byte, error) {
+	bounds := src.Bounds()
+	if bounds.Dx() < width {
+		width = bounds.Dx()
+	}
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage/blob"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+type memoryImageStorage struct {
+	images map[int64]*models.Image
+}
+
+func (m *memoryImageStorage) AddImageStorage(img *models.Image) error {
+	img.ID = int64(len(m.images) + 1)
+	m.images[img.ID] = img
+	return nil
+}
+
+func (m *memoryImageStorage) GetImageStorage(id int64) (*models.Image, error) {
+	return m.images[id], nil
+}
+
+func (m *memoryImageStorage) GetImagesStorage(string, int64) ([]*models.Image, error) {
+	return nil, nil
+}
+
+func (m *memoryImageStorage) DeleteImageStorage(id int64) error {
+	delete(m.images, id)
+	return nil
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, x%height, color.RGBA{R: 200, A: 255})
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestService_UploadImage(t *testing.T) {
+	store, err := blob.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{
+		log:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		imageStorage:   &memoryImageStorage{images: make(map[int64]*models.Image)},
+		blobStore:      store,
+		maxImageSize:   1 << 20,
+		maxImagePixels: 1_000_000,
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "Wide PNG", data: encodePNG(t, 1000, 500)},
+		{name: "Narrower than thumbnails", data: encodePNG(t, 100, 50)},
+		{name: "Not an image", data: []byte("plain text"), wantErr: ErrUnsupportedImageType},
+		{name: "Corrupted PNG", data: encodePNG(t, 100, 50)[:60], wantErr: ErrInvalidImage},
+		{name: "Too many pixels", data: encodePNG(t, 2000, 1000), wantErr: ErrImageTooManyPixels},
+		{name: "Too large", data: append(encodePNG(t, 10, 10), make([]byte, 1<<20)...), wantErr: ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := s.UploadImage(models.ImageOwnerMovie, 1, bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UploadImage() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if img.ContentType != "image/png" {
+				t.Errorf("UploadImage() content type = %q, want image/png", img.ContentType)
+			}
+
+			for name, width := range thumbnailWidths {
+				r, contentType, err := s.GetImage(img.ID, name)
+				if err != nil {
+					t.Fatalf("GetImage(%q) error = %v", name, err)
+				}
+				thumb, format, err := image.DecodeConfig(r)
+				r.Close()
+				if err != nil {
+					t.Fatalf("GetImage(%q) returned an undecodable image: %v", name, err)
+				}
+
+				wantWidth := min(width, img.Width)
+				if contentType != "image/jpeg" || format != "jpeg" || thumb.Width != wantWidth || thumb.Height != img.Height*wantWidth/img.Width {
+					t.Errorf("thumbnail %q is a %dx%d %s, want a %dx%d jpeg", name, thumb.Width, thumb.Height, format, wantWidth, img.Height*wantWidth/img.Width)
+				}
+			}
+		})
+	}
+
+	if _, _, err := s.GetImage(1, "huge"); !errors.Is(err, ErrInvalidImageSize) {
+		t.Errorf("GetImage() with unknown size error = %v, want %v", err, ErrInvalidImageSize)
+	}
+}
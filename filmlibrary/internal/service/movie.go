@@ -1,27 +1,56 @@
 package service
 
 import (
+	"errors"
 	"filmlibrary/internal/domain/models"
 	"fmt"
+	"regexp"
+	"strings"
 )
 
+var (
+	ErrInvalidRuntime     = errors.New("runtime must be a positive number of minutes")
+	ErrInvalidMoney       = errors.New("amount must not be negative and currency must be a three letter ISO 4217 code")
+	ErrInvalidLanguage    = errors.New("language must be a two or three letter ISO 639 code")
+	ErrInvalidMovieIMDbID = errors.New("external IMDb ID must look like tt0111161")
+	ErrInvalidFactsRange  = errors.New("minimum of a range must not exceed its maximum")
+	ErrInvalidTitle       = errors.New("title must not be empty")
+)
+
+var (
+	currencyRegexp    = regexp.MustCompile(`^[A-Z]{3}$`)
+	movieIMDbIDRegexp = regexp.MustCompile(`^tt\d{7,8}$`)
+)
+
+// DefaultGrossCurrency is the currency the box office is filtered and sorted in when
+// none is given, as it is usually reported in US dollars.
+const DefaultGrossCurrency = "USD"
+
 type MovieStorage interface {
-	EditMovieStorage(movie *models.Movie) error
+	EditMovieStorage(id int64, version int64, patch *models.MoviePatch) (int64, error)
 	AddMovieStorage(movie *models.Movie) error
-	DeleteMovieStorage(id int64) error
-	GetMoviesSortedStorage(sortBy string, sortDirection string) ([]*models.MovieListing, error)
+	DeleteMovieStorage(id int64, version int64) error
+	BatchMoviesStorage(items []*models.MovieBatchItem, atomic bool) ([]*models.BatchResult, error)
+	GetMoviesSortedStorage(sortBy string, sortDirection string, filter models.MovieFilter, langs []string) ([]*models.MovieListing, error)
 	AddActorsToMovieStorage(movieID int64, actors []int64) error
-	GetMovieStorage(searchTerm string) ([]*models.MovieListing, error)
+	GetMovieStorage(searchTerm string, langs []string) ([]*models.MovieListing, error)
+	GetMovieDetailStorage(id int64, langs []string) (*models.MovieDetail, error)
 }
 
 func (s *Service) AddMovie(movie *models.Movie) error {
 	const op = "service.AddMovie"
 
+	if err := validateMovieFacts(&movie.MovieFacts); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	err := s.movieStorage.AddMovieStorage(movie)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.invalidateCostars()
+
 	return nil
 }
 
@@ -33,13 +62,23 @@ func (s *Service) AddActorsToMovie(movieID int64, actors []int64) error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.invalidateCostars()
+
 	return nil
 }
 
-func (s *Service) GetMoviesSorted(sortBy string, sortDirection string) ([]*models.MovieListing, error) {
+// GetMoviesSorted lists movies translated to the first of langs they have a translation in.
+func (s *Service) GetMoviesSorted(sortBy string, sortDirection string, filter models.MovieFilter, langs []string) ([]*models.MovieListing, error) {
 	const op = "service.GetMoviesSorted"
 
-	movies, err := s.movieStorage.GetMoviesSortedStorage(sortBy, sortDirection)
+	if err := validateReleaseFilter(&filter); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := validateFactsFilter(&filter); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	movies, err := s.movieStorage.GetMoviesSortedStorage(sortBy, sortDirection, filter, langs)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -47,10 +86,11 @@ func (s *Service) GetMoviesSorted(sortBy string, sortDirection string) ([]*model
 	return movies, nil
 }
 
-func (s *Service) GetMovie(input string) ([]*models.MovieListing, error) {
+// GetMovie searches movies in all languages and translates the results like GetMoviesSorted.
+func (s *Service) GetMovie(input string, langs []string) ([]*models.MovieListing, error) {
 	const op = "service.GetMovie"
 
-	movies, err := s.movieStorage.GetMovieStorage(input)
+	movies, err := s.movieStorage.GetMovieStorage(input, langs)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -58,24 +98,161 @@ func (s *Service) GetMovie(input string) ([]*models.MovieListing, error) {
 	return movies, nil
 }
 
-func (s *Service) EditMovie(movie *models.Movie) error {
+// GetMovieDetail returns the movie with its franchise and related movies, translated
+// like GetMoviesSorted.
+func (s *Service) GetMovieDetail(id int64, langs []string) (*models.MovieDetail, error) {
+	const op = "service.GetMovieDetail"
+
+	movie, err := s.movieStorage.GetMovieDetailStorage(id, langs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return movie, nil
+}
+
+// EditMovie applies the merge patch to the movie at the version the editor has seen
+// and returns the new version.
+func (s *Service) EditMovie(id int64, version int64, patch *models.MoviePatch) (int64, error) {
 	const op = "service.EditMovie"
 
-	err := s.movieStorage.EditMovieStorage(movie)
+	if err := validateMoviePatch(patch); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	version, err := s.movieStorage.EditMovieStorage(id, version, patch)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	s.invalidateCostars()
+
+	return version, nil
 }
 
-func (s *Service) DeleteMovie(id int64) error {
+func (s *Service) DeleteMovie(id int64, version int64) error {
 	const op = "service.DeleteMovie"
 
-	err := s.movieStorage.DeleteMovieStorage(id)
+	err := s.movieStorage.DeleteMovieStorage(id, version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.invalidateCostars()
+
+	return nil
+}
+
+// validateMovieFacts normalizes the codes in the production facts of a movie. Facts
+// left empty are not checked, so a partial update only checks what it changes.
+func validateMovieFacts(facts *models.MovieFacts) error {
+	if facts.Runtime < 0 {
+		return ErrInvalidRuntime
+	}
+
+	for _, money := range []*models.Money{facts.Budget, facts.Gross} {
+		if money == nil {
+			continue
+		}
+		money.Currency = strings.ToUpper(strings.TrimSpace(money.Currency))
+		if money.Amount < 0 || !currencyRegexp.MatchString(money.Currency) {
+			return ErrInvalidMoney
+		}
+	}
+
+	if facts.OriginalLanguage != "" {
+		facts.OriginalLanguage = strings.ToLower(strings.TrimSpace(facts.OriginalLanguage))
+		if !localeRegexp.MatchString(facts.OriginalLanguage) {
+			return ErrInvalidLanguage
+		}
+	}
+	for i, language := range facts.SpokenLanguages {
+		facts.SpokenLanguages[i] = strings.ToLower(strings.TrimSpace(language))
+		if !localeRegexp.MatchString(facts.SpokenLanguages[i]) {
+			return ErrInvalidLanguage
+		}
+	}
+	for i, country := range facts.Countries {
+		facts.Countries[i] = strings.ToUpper(strings.TrimSpace(country))
+		if !countryRegexp.MatchString(facts.Countries[i]) {
+			return ErrInvalidCountry
+		}
+	}
+
+	if ids := facts.ExternalIDs; ids != nil {
+		ids.IMDb, ids.Kinopoisk = strings.TrimSpace(ids.IMDb), strings.TrimSpace(ids.Kinopoisk)
+		if ids.IMDb != "" && !movieIMDbIDRegexp.MatchString(ids.IMDb) {
+			return ErrInvalidMovieIMDbID
+		}
+		if ids.Kinopoisk != "" && !kinopoiskIDRegexp.MatchString(ids.Kinopoisk) {
+			return ErrInvalidKinopoiskID
+		}
+	}
+
+	return nil
+}
+
+// validateMoviePatch normalizes the members of the patch like validateMovieFacts, blank
+// text clears the field, and keeps the title and the release date from being cleared.
+func validateMoviePatch(patch *models.MoviePatch) error {
+	if patch.Title.Set {
+		patch.Title.Value = strings.TrimSpace(patch.Title.Value)
+		if patch.Title.Null || patch.Title.Value == "" {
+			return ErrInvalidTitle
+		}
+	}
+	if patch.ReleaseDate.Set && patch.ReleaseDate.Value.IsZero() {
+		return ErrInvalidReleaseDate
+	}
+	if patch.Rating.Changed() && (patch.Rating.Value < 0 || patch.Rating.Value > 10) {
+		return ErrInvalidRating
+	}
+	if patch.Runtime.Changed() && patch.Runtime.Value <= 0 {
+		return ErrInvalidRuntime
+	}
+
+	clearIfBlank(&patch.Description)
+	clearIfBlank(&patch.OriginalLanguage)
+	ids := &patch.ExternalIDs.Value
+	clearIfBlank(&ids.IMDb)
+	clearIfBlank(&ids.Kinopoisk)
+
+	facts := models.MovieFacts{
+		OriginalLanguage: patch.OriginalLanguage.Value,
+		SpokenLanguages:  patch.SpokenLanguages.Value,
+		Countries:        patch.Countries.Value,
+		ExternalIDs:      &models.ExternalIDs{IMDb: ids.IMDb.Value, Kinopoisk: ids.Kinopoisk.Value},
+	}
+	if patch.Budget.Changed() {
+		facts.Budget = &patch.Budget.Value
+	}
+	if patch.Gross.Changed() {
+		facts.Gross = &patch.Gross.Value
+	}
+	if err := validateMovieFacts(&facts); err != nil {
+		return err
+	}
+	patch.OriginalLanguage.Value = facts.OriginalLanguage
+
+	return nil
+}
+
+// validateFactsFilter checks the runtime and box office filters of the movie listing.
+func validateFactsFilter(filter *models.MovieFilter) error {
+	if filter.MinRuntime > 0 && filter.MaxRuntime > 0 && filter.MinRuntime > filter.MaxRuntime {
+		return ErrInvalidFactsRange
+	}
+	if filter.MinGross > 0 && filter.MaxGross > 0 && filter.MinGross > filter.MaxGross {
+		return ErrInvalidFactsRange
+	}
+
+	filter.GrossCurrency = strings.ToUpper(filter.GrossCurrency)
+	if filter.GrossCurrency == "" {
+		filter.GrossCurrency = DefaultGrossCurrency
+	}
+	if !currencyRegexp.MatchString(filter.GrossCurrency) {
+		return ErrInvalidMoney
+	}
+
 	return nil
 }
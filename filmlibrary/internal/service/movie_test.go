@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestValidateMovieFacts(t *testing.T) {
+	tests := []struct {
+		name    string
+		facts   models.MovieFacts
+		wantErr error
+	}{
+		{name: "Empty", facts: models.MovieFacts{}},
+		{
+			name: "Full",
+			facts: models.MovieFacts{
+				Runtime:          142,
+				Budget:           &models.Money{Amount: 25000000, Currency: "usd"},
+				Gross:            &models.Money{Amount: 73300000, Currency: " USD"},
+				OriginalLanguage: "EN",
+				SpokenLanguages:  []string{"en", "FR"},
+				Countries:        []string{"us"},
+				ExternalIDs:      &models.ExternalIDs{IMDb: "tt0111161", Kinopoisk: "326"},
+			},
+		},
+		{name: "Negative runtime", facts: models.MovieFacts{Runtime: -1}, wantErr: ErrInvalidRuntime},
+		{name: "Negative budget", facts: models.MovieFacts{Budget: &models.Money{Amount: -1, Currency: "USD"}}, wantErr: ErrInvalidMoney},
+		{name: "Unknown currency", facts: models.MovieFacts{Gross: &models.Money{Amount: 1, Currency: "dollars"}}, wantErr: ErrInvalidMoney},
+		{name: "Invalid language", facts: models.MovieFacts{SpokenLanguages: []string{"english"}}, wantErr: ErrInvalidLanguage},
+		{name: "Invalid country", facts: models.MovieFacts{Countries: []string{"USA"}}, wantErr: ErrInvalidCountry},
+		{name: "Actor IMDb ID", facts: models.MovieFacts{ExternalIDs: &models.ExternalIDs{IMDb: "nm0000209"}}, wantErr: ErrInvalidMovieIMDbID},
+		{name: "Invalid Kinopoisk ID", facts: models.MovieFacts{ExternalIDs: &models.ExternalIDs{Kinopoisk: "kp326"}}, wantErr: ErrInvalidKinopoiskID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMovieFacts(&tt.facts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateMovieFacts() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	facts := models.MovieFacts{
+		Gross:           &models.Money{Amount: 1, Currency: " eur "},
+		SpokenLanguages: []string{"RU"},
+		Countries:       []string{"ru"},
+	}
+	if err := validateMovieFacts(&facts); err != nil {
+		t.Fatal(err)
+	}
+	if facts.Gross.Currency != "EUR" || !slices.Equal(facts.SpokenLanguages, []string{"ru"}) || !slices.Equal(facts.Countries, []string{"RU"}) {
+		t.Errorf("facts are not normalized: %+v", facts)
+	}
+}
+
+func TestValidateFactsFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		filter       models.MovieFilter
+		wantErr      error
+		wantCurrency string
+	}{
+		{name: "Default currency", filter: models.MovieFilter{MinGross: 1000}, wantCurrency: "USD"},
+		{name: "Lower case currency", filter: models.MovieFilter{GrossCurrency: "rub"}, wantCurrency: "RUB"},
+		{name: "Open runtime range", filter: models.MovieFilter{MinRuntime: 90}, wantCurrency: "USD"},
+		{name: "Reversed runtime", filter: models.MovieFilter{MinRuntime: 120, MaxRuntime: 90}, wantErr: ErrInvalidFactsRange},
+		{name: "Reversed gross", filter: models.MovieFilter{MinGross: 10, MaxGross: 5}, wantErr: ErrInvalidFactsRange},
+		{name: "Unknown currency", filter: models.MovieFilter{GrossCurrency: "euro"}, wantErr: ErrInvalidMoney},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFactsFilter(&tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateFactsFilter() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && tt.filter.GrossCurrency != tt.wantCurrency {
+				t.Errorf("GrossCurrency = %q, want %q", tt.filter.GrossCurrency, tt.wantCurrency)
+			}
+		})
+	}
+}
+
+func TestValidateMoviePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   models.MoviePatch
+		wantErr error
+	}{
+		{name: "Empty", patch: models.MoviePatch{}},
+		{name: "Clear description and rating", patch: models.MoviePatch{Description: models.SetNull[string](), Rating: models.SetNull[float64]()}},
+		{name: "Clear title", patch: models.MoviePatch{Title: models.SetNull[string]()}, wantErr: ErrInvalidTitle},
+		{name: "Blank title", patch: models.MoviePatch{Title: models.SetTo(" ")}, wantErr: ErrInvalidTitle},
+		{name: "Clear release date", patch: models.MoviePatch{ReleaseDate: models.SetNull[time.Time]()}, wantErr: ErrInvalidReleaseDate},
+		{name: "Rating out of range", patch: models.MoviePatch{Rating: models.SetTo(11.0)}, wantErr: ErrInvalidRating},
+		{name: "Zero runtime", patch: models.MoviePatch{Runtime: models.SetTo(0)}, wantErr: ErrInvalidRuntime},
+		{name: "Unknown currency", patch: models.MoviePatch{Budget: models.SetTo(models.Money{Amount: 1, Currency: "dollars"})}, wantErr: ErrInvalidMoney},
+		{name: "Actor IMDb ID", patch: models.MoviePatch{ExternalIDs: models.SetTo(models.ExternalIDsPatch{IMDb: models.SetTo("nm0000209")})}, wantErr: ErrInvalidMovieIMDbID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMoviePatch(&tt.patch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateMoviePatch() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMoviePatch_Normalizes(t *testing.T) {
+	patch := models.MoviePatch{
+		Title:            models.SetTo(" The Godfather "),
+		Description:      models.SetTo("  "),
+		Budget:           models.SetTo(models.Money{Amount: 6000000, Currency: "usd"}),
+		OriginalLanguage: models.SetTo(" EN"),
+		Countries:        models.SetTo([]string{"us"}),
+		ExternalIDs:      models.SetTo(models.ExternalIDsPatch{Kinopoisk: models.SetTo(""), IMDb: models.SetTo(" tt0068646")}),
+	}
+
+	if err := validateMoviePatch(&patch); err != nil {
+		t.Fatalf("validateMoviePatch() error = %v", err)
+	}
+
+	if patch.Title.Value != "The Godfather" || !patch.Description.Null || patch.Budget.Value.Currency != "USD" ||
+		patch.OriginalLanguage.Value != "en" || patch.Countries.Value[0] != "US" {
+		t.Errorf("validateMoviePatch() patch = %+v", patch)
+	}
+	if ids := patch.ExternalIDs.Value; !ids.Kinopoisk.Null || ids.IMDb.Value != "tt0068646" {
+		t.Errorf("ExternalIDs = %+v, want Kinopoisk cleared and IMDb trimmed", ids)
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"sort"
+)
+
+// tasteSeedsLimit is how many of the user's latest ratings and watches recommendations are based on.
+const tasteSeedsLimit = 50
+
+type RecommendationStorage interface {
+	GetMovieStorageByID(id int64) (*models.MovieListing, error)
+	GetMoviesStorageByIDs(ids []int64) ([]*models.MovieListing, error)
+	GetSimilarityCandidatesStorage(sourceIDs, exclude []int64) ([]*models.SimilarityCandidate, error)
+	GetTasteSeedsStorage(userID int64, limit int) ([]*models.TasteSeed, error)
+}
+
+// Scorer rates how similar a candidate is to its source movie, higher is more similar.
+type Scorer func(candidate *models.SimilarityCandidate) float64
+
+type ScoreWeights struct {
+	SharedActor      float64
+	SharedGenre      float64
+	ReleaseProximity float64
+	Rating           float64
+}
+
+var DefaultScoreWeights = ScoreWeights{SharedActor: 3, SharedGenre: 2, ReleaseProximity: 1, Rating: 1}
+
+// WeightedScorer adds up the shared actors and genres, a release proximity term that
+// falls from 1 for movies released the same year, and the rating scaled to 0-1.
+// The movie's critic rating is averaged with the user rating when there are votes.
+func WeightedScorer(weights ScoreWeights) Scorer {
+	return func(c *models.SimilarityCandidate) float64 {
+		score := weights.SharedActor*float64(c.SharedActors) + weights.SharedGenre*float64(c.SharedGenres)
+
+		yearsApart := float64(c.DaysApart) / 365.25
+		score += weights.ReleaseProximity / (1 + yearsApart)
+
+		rating := c.Rating
+		if c.UserRating != nil {
+			rating = (rating + *c.UserRating) / 2
+		}
+		score += weights.Rating * rating / 10
+
+		return score
+	}
+}
+
+// SetScorer replaces the scoring function used for recommendations.
+func (s *Service) SetScorer(scorer Scorer) {
+	s.scorer = scorer
+}
+
+// GetSimilarMovies ranks the movies that share actors or genres with the given one.
+func (s *Service) GetSimilarMovies(movieID int64, page, limit int) ([]*models.Recommendation, error) {
+	const op = "service.GetSimilarMovies"
+
+	_, err := s.recommendationStorage.GetMovieStorageByID(movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	candidates, err := s.recommendationStorage.GetSimilarityCandidatesStorage([]int64{movieID}, []int64{movieID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recommendations, err := s.rankCandidates(candidates, map[int64]float64{movieID: 1}, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return recommendations, nil
+}
+
+// GetUserRecommendations ranks movies by their similarity to what the user rated and
+// watched. Highly rated movies pull similar ones up, poorly rated ones push them down,
+// and movies the user already rated or watched are never recommended.
+func (s *Service) GetUserRecommendations(userID int64, page, limit int) ([]*models.Recommendation, error) {
+	const op = "service.GetUserRecommendations"
+
+	seeds, err := s.recommendationStorage.GetTasteSeedsStorage(userID, tasteSeedsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	seen := make([]int64, 0, len(seeds))
+	weights := make(map[int64]float64, len(seeds))
+	sources := make([]int64, 0, len(seeds))
+	for _, seed := range seeds {
+		seen = append(seen, seed.MovieID)
+
+		weight := seedWeight(seed)
+		if weight != 0 {
+			weights[seed.MovieID] = weight
+			sources = append(sources, seed.MovieID)
+		}
+	}
+
+	if len(sources) == 0 {
+		return []*models.Recommendation{}, nil
+	}
+
+	candidates, err := s.recommendationStorage.GetSimilarityCandidatesStorage(sources, seen)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recommendations, err := s.rankCandidates(candidates, weights, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return recommendations, nil
+}
+
+// seedWeight maps a rating to -1..1, with 5 out of 10 being neutral.
+// A movie that was watched but not rated counts as mildly liked.
+func seedWeight(seed *models.TasteSeed) float64 {
+	if seed.Rating != nil {
+		return float64(*seed.Rating-5) / 5
+	}
+	if seed.Watched {
+		return 0.5
+	}
+
+	return 0
+}
+
+// rankCandidates sums the weighted scores of each candidate over its source movies and
+// returns the requested page of positively scored movies, best first.
+func (s *Service) rankCandidates(candidates []*models.SimilarityCandidate, weights map[int64]float64, page, limit int) ([]*models.Recommendation, error) {
+	scores := make(map[int64]float64)
+	for _, c := range candidates {
+		scores[c.MovieID] += weights[c.SourceID] * s.scorer(c)
+	}
+
+	ids := make([]int64, 0, len(scores))
+	for id, score := range scores {
+		if score > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	offset := (page - 1) * limit
+	if offset >= len(ids) {
+		return []*models.Recommendation{}, nil
+	}
+	ids = ids[offset:min(offset+limit, len(ids))]
+
+	movies, err := s.recommendationStorage.GetMoviesStorageByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*models.MovieListing, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+
+	recommendations := make([]*models.Recommendation, 0, len(ids))
+	for _, id := range ids {
+		if movie, ok := byID[id]; ok {
+			recommendations = append(recommendations, &models.Recommendation{Movie: movie, Score: scores[id]})
+		}
+	}
+
+	return recommendations, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"filmlibrary/internal/domain/models"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type memoryRecommendationStorage struct {
+	RecommendationStorage
+	seeds      []*models.TasteSeed
+	candidates []*models.SimilarityCandidate
+	sources    []int64
+	excluded   []int64
+}
+
+func (m *memoryRecommendationStorage) GetTasteSeedsStorage(userID int64, limit int) ([]*models.TasteSeed, error) {
+	return m.seeds, nil
+}
+
+func (m *memoryRecommendationStorage) GetSimilarityCandidatesStorage(sourceIDs, exclude []int64) ([]*models.SimilarityCandidate, error) {
+	m.sources, m.excluded = sourceIDs, exclude
+
+	var candidates []*models.SimilarityCandidate
+	for _, c := range m.candidates {
+		if slices.Contains(sourceIDs, c.SourceID) && !slices.Contains(exclude, c.MovieID) {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates, nil
+}
+
+func (m *memoryRecommendationStorage) GetMoviesStorageByIDs(ids []int64) ([]*models.MovieListing, error) {
+	movies := make([]*models.MovieListing, 0, len(ids))
+	for _, id := range ids {
+		movies = append(movies, &models.MovieListing{ID: id})
+	}
+	return movies, nil
+}
+
+func TestWeightedScorer(t *testing.T) {
+	userRating := 6.0
+
+	tests := []struct {
+		name      string
+		weights   ScoreWeights
+		candidate *models.SimilarityCandidate
+		want      float64
+	}{
+		{name: "Shared actors", weights: ScoreWeights{SharedActor: 3}, candidate: &models.SimilarityCandidate{SharedActors: 2, DaysApart: 400}, want: 6},
+		{name: "Shared genres", weights: ScoreWeights{SharedGenre: 2}, candidate: &models.SimilarityCandidate{SharedGenres: 3}, want: 6},
+		{name: "Released the same year", weights: ScoreWeights{ReleaseProximity: 1}, candidate: &models.SimilarityCandidate{}, want: 1},
+		{name: "Released a year apart", weights: ScoreWeights{ReleaseProximity: 1}, candidate: &models.SimilarityCandidate{DaysApart: 365}, want: 1 / (1 + 365/365.25)},
+		{name: "Rating", weights: ScoreWeights{Rating: 1}, candidate: &models.SimilarityCandidate{Rating: 8}, want: 0.8},
+		{name: "Rating with user rating", weights: ScoreWeights{Rating: 1}, candidate: &models.SimilarityCandidate{Rating: 8, UserRating: &userRating}, want: 0.7},
+		{
+			name:      "Every term",
+			weights:   DefaultScoreWeights,
+			candidate: &models.SimilarityCandidate{SharedActors: 1, SharedGenres: 1, Rating: 5},
+			want:      3 + 2 + 1 + 0.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeightedScorer(tt.weights)(tt.candidate); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("WeightedScorer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeedWeight(t *testing.T) {
+	rating := func(r int) *int { return &r }
+
+	tests := []struct {
+		name string
+		seed *models.TasteSeed
+		want float64
+	}{
+		{name: "Loved", seed: &models.TasteSeed{Rating: rating(10)}, want: 1},
+		{name: "Liked", seed: &models.TasteSeed{Rating: rating(8), Watched: true}, want: 0.6},
+		{name: "Neutral", seed: &models.TasteSeed{Rating: rating(5)}, want: 0},
+		{name: "Disliked", seed: &models.TasteSeed{Rating: rating(2)}, want: -0.6},
+		{name: "Hated", seed: &models.TasteSeed{Rating: rating(0)}, want: -1},
+		{name: "Watched without a rating", seed: &models.TasteSeed{Watched: true}, want: 0.5},
+		{name: "Neither", seed: &models.TasteSeed{}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := seedWeight(tt.seed); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("seedWeight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetUserRecommendations(t *testing.T) {
+	rating := func(r int) *int { return &r }
+
+	// 1 is loved, 2 is hated, 3 is neutral and 4 was watched without a rating, the score
+	// of a candidate is its number of shared actors
+	seeds := []*models.TasteSeed{
+		{MovieID: 1, Rating: rating(10)},
+		{MovieID: 2, Rating: rating(0)},
+		{MovieID: 3, Rating: rating(5)},
+		{MovieID: 4, Watched: true},
+	}
+	candidates := []*models.SimilarityCandidate{
+		{SourceID: 1, MovieID: 10, SharedActors: 3},
+		{SourceID: 2, MovieID: 10, SharedActors: 1},
+		{SourceID: 2, MovieID: 11, SharedActors: 2},
+		{SourceID: 4, MovieID: 12, SharedActors: 2},
+		{SourceID: 1, MovieID: 13, SharedActors: 1},
+		{SourceID: 1, MovieID: 3, SharedActors: 5},
+		{SourceID: 4, MovieID: 2, SharedActors: 5},
+	}
+
+	tests := []struct {
+		name        string
+		seeds       []*models.TasteSeed
+		page, limit int
+		want        []int64
+		wantScores  []float64
+	}{
+		{name: "First page", seeds: seeds, page: 1, limit: 20, want: []int64{10, 12, 13}, wantScores: []float64{2, 1, 1}},
+		{name: "Second page", seeds: seeds, page: 2, limit: 2, want: []int64{13}, wantScores: []float64{1}},
+		{name: "Past the last page", seeds: seeds, page: 3, limit: 2, want: []int64{}},
+		{name: "Only neutral seeds", seeds: seeds[2:3], page: 1, limit: 20, want: []int64{}},
+		{name: "No seeds", page: 1, limit: 20, want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &memoryRecommendationStorage{seeds: tt.seeds, candidates: candidates}
+			s := &Service{recommendationStorage: storage}
+			s.SetScorer(func(c *models.SimilarityCandidate) float64 { return float64(c.SharedActors) })
+
+			recommendations, err := s.GetUserRecommendations(7, tt.page, tt.limit)
+			if err != nil {
+				t.Fatalf("GetUserRecommendations() error = %v", err)
+			}
+
+			got := make([]int64, 0, len(recommendations))
+			var scores []float64
+			for _, recommendation := range recommendations {
+				got = append(got, recommendation.Movie.ID)
+				scores = append(scores, recommendation.Score)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetUserRecommendations() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(scores, tt.wantScores) {
+				t.Errorf("scores = %v, want %v", scores, tt.wantScores)
+			}
+		})
+	}
+}
+
+func TestService_GetUserRecommendations_ExcludesSeen(t *testing.T) {
+	rating := func(r int) *int { return &r }
+
+	storage := &memoryRecommendationStorage{seeds: []*models.TasteSeed{
+		{MovieID: 1, Rating: rating(9)},
+		{MovieID: 2, Rating: rating(5)},
+		{MovieID: 3, Watched: true},
+	}}
+	s := &Service{recommendationStorage: storage, scorer: WeightedScorer(DefaultScoreWeights)}
+
+	if _, err := s.GetUserRecommendations(7, 1, 20); err != nil {
+		t.Fatalf("GetUserRecommendations() error = %v", err)
+	}
+
+	// the neutral seed is no source of candidates but is seen all the same
+	if want := []int64{1, 3}; !reflect.DeepEqual(storage.sources, want) {
+		t.Errorf("sources = %v, want %v", storage.sources, want)
+	}
+	if want := []int64{1, 2, 3}; !reflect.DeepEqual(storage.excluded, want) {
+		t.Errorf("excluded = %v, want %v", storage.excluded, want)
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidCountry       = errors.New("country must be a two letter ISO 3166 code")
+	ErrInvalidReleaseType   = errors.New("release type must be one of theatrical, streaming or festival")
+	ErrInvalidReleaseDate   = errors.New("release date is missing")
+	ErrUnknownCertification = errors.New("unknown age certification")
+)
+
+var (
+	countryRegexp = regexp.MustCompile(`^[A-Z]{2}$`)
+	// ageRatingRegexp matches certifications given as the minimum age, such as 12+ or 18+.
+	ageRatingRegexp = regexp.MustCompile(`^(\d{1,2})\+$`)
+)
+
+// certificationAges maps the MPA and BBFC ratings to the youngest age they allow
+// to watch a movie alone.
+var certificationAges = map[string]int{
+	"G":     0,
+	"PG":    0,
+	"PG-13": 13,
+	"R":     17,
+	"NC-17": 18,
+	"U":     0,
+	"12A":   12,
+	"12":    12,
+	"15":    15,
+	"18":    18,
+	"R18":   18,
+}
+
+type ReleaseStorage interface {
+	UpsertReleaseStorage(release *models.Release) error
+	GetReleasesStorage(movieID int64) ([]*models.Release, error)
+	DeleteReleaseStorage(id int64) error
+}
+
+func (s *Service) AddRelease(release *models.Release) error {
+	const op = "service.AddRelease"
+
+	release.Country = strings.ToUpper(strings.TrimSpace(release.Country))
+	if !countryRegexp.MatchString(release.Country) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCountry)
+	}
+	if !slices.Contains(models.ReleaseTypes, release.Type) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidReleaseType)
+	}
+	if release.ReleaseDate.IsZero() {
+		return fmt.Errorf("%s: %w", op, ErrInvalidReleaseDate)
+	}
+
+	release.Certification = strings.ToUpper(strings.TrimSpace(release.Certification))
+	release.MinAge = nil
+	if release.Certification != "" {
+		minAge, ok := certificationMinAge(release.Certification)
+		if !ok {
+			return fmt.Errorf("%s: %w: %q", op, ErrUnknownCertification, release.Certification)
+		}
+		release.MinAge = &minAge
+	}
+
+	err := s.releaseStorage.UpsertReleaseStorage(release)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetReleases(movieID int64) ([]*models.Release, error) {
+	const op = "service.GetReleases"
+
+	releases, err := s.releaseStorage.GetReleasesStorage(movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return releases, nil
+}
+
+func (s *Service) DeleteRelease(id int64) error {
+	const op = "service.DeleteRelease"
+
+	err := s.releaseStorage.DeleteReleaseStorage(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// certificationMinAge returns the youngest age a certification allows.
+func certificationMinAge(certification string) (int, bool) {
+	if match := ageRatingRegexp.FindStringSubmatch(certification); match != nil {
+		age, err := strconv.Atoi(match[1])
+		return age, err == nil
+	}
+
+	age, ok := certificationAges[certification]
+	return age, ok
+}
+
+// validateReleaseFilter normalizes the release filters of the movie listing.
+func validateReleaseFilter(filter *models.MovieFilter) error {
+	if filter.Country != "" {
+		filter.Country = strings.ToUpper(filter.Country)
+		if !countryRegexp.MatchString(filter.Country) {
+			return ErrInvalidCountry
+		}
+	}
+	if filter.ReleaseType != "" && !slices.Contains(models.ReleaseTypes, filter.ReleaseType) {
+		return ErrInvalidReleaseType
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"testing"
+	"time"
+)
+
+type memoryReleaseStorage struct {
+	releases []*models.Release
+}
+
+func (m *memoryReleaseStorage) UpsertReleaseStorage(release *models.Release) error {
+	m.releases = append(m.releases, release)
+	return nil
+}
+
+func (m *memoryReleaseStorage) GetReleasesStorage(movieID int64) ([]*models.Release, error) {
+	return m.releases, nil
+}
+
+func (m *memoryReleaseStorage) DeleteReleaseStorage(id int64) error {
+	return nil
+}
+
+func TestService_AddRelease(t *testing.T) {
+	premiere := time.Date(1995, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		release    models.Release
+		wantErr    error
+		wantMinAge int
+	}{
+		{name: "Age rating", release: models.Release{Country: "ru", Type: models.ReleaseTheatrical, ReleaseDate: premiere, Certification: "16+"}, wantMinAge: 16},
+		{name: "MPA rating", release: models.Release{Country: "US", Type: models.ReleaseStreaming, ReleaseDate: premiere, Certification: "pg-13"}, wantMinAge: 13},
+		{name: "BBFC rating", release: models.Release{Country: "GB", Type: models.ReleaseFestival, ReleaseDate: premiere, Certification: "12A"}, wantMinAge: 12},
+		{name: "Not certified", release: models.Release{Country: "RU", Type: models.ReleaseTheatrical, ReleaseDate: premiere}, wantMinAge: -1},
+		{name: "Unknown certification", release: models.Release{Country: "RU", Type: models.ReleaseTheatrical, ReleaseDate: premiere, Certification: "M"}, wantErr: ErrUnknownCertification},
+		{name: "Invalid country", release: models.Release{Country: "RUS", Type: models.ReleaseTheatrical, ReleaseDate: premiere}, wantErr: ErrInvalidCountry},
+		{name: "Invalid type", release: models.Release{Country: "RU", Type: "dvd", ReleaseDate: premiere}, wantErr: ErrInvalidReleaseType},
+		{name: "Missing date", release: models.Release{Country: "RU", Type: models.ReleaseTheatrical}, wantErr: ErrInvalidReleaseDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{releaseStorage: &memoryReleaseStorage{}}
+
+			release := tt.release
+			err := s.AddRelease(&release)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddRelease() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			switch {
+			case tt.wantMinAge < 0 && release.MinAge != nil:
+				t.Errorf("MinAge = %d, want none", *release.MinAge)
+			case tt.wantMinAge >= 0 && (release.MinAge == nil || *release.MinAge != tt.wantMinAge):
+				t.Errorf("MinAge = %v, want %d", release.MinAge, tt.wantMinAge)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+)
+
+var ErrInvalidRating = errors.New("rating must be between 0 and 10")
+
+type ReviewStorage interface {
+	UpsertReviewStorage(review *models.Review) error
+	GetReviewsStorage(movieID int64, limit, offset int) ([]*models.Review, int64, error)
+	SetReviewHiddenStorage(id int64, hidden bool) error
+	DeleteReviewStorage(id int64) error
+}
+
+// SubmitReview adds the user's rating and review of a movie, or replaces the one they already left.
+func (s *Service) SubmitReview(review *models.Review) error {
+	const op = "service.SubmitReview"
+
+	if review.Rating < 0 || review.Rating > 10 {
+		return fmt.Errorf("%s: %w", op, ErrInvalidRating)
+	}
+
+	err := s.reviewStorage.UpsertReviewStorage(review)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetReviews(movieID int64, page, limit int) (*models.ReviewsPage, error) {
+	const op = "service.GetReviews"
+
+	reviews, total, err := s.reviewStorage.GetReviewsStorage(movieID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &models.ReviewsPage{Reviews: reviews, Total: total, Page: page, Limit: limit}, nil
+}
+
+func (s *Service) ModerateReview(id int64, hidden bool) error {
+	const op = "service.ModerateReview"
+
+	err := s.reviewStorage.SetReviewHiddenStorage(id, hidden)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteReview(id int64) error {
+	const op = "service.DeleteReview"
+
+	err := s.reviewStorage.DeleteReviewStorage(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
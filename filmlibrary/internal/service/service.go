@@ -1,16 +1,81 @@
 package service
 
 import (
+	"filmlibrary/internal/storage/blob"
 	"log/slog"
 )
 
 type Service struct {
-	log          *slog.Logger
-	actorStorage ActorStorage
-	movieStorage MovieStorage
-	userStorage  UserStorage
+	log                   *slog.Logger
+	actorStorage          ActorStorage
+	movieStorage          MovieStorage
+	userStorage           UserStorage
+	genreStorage          GenreStorage
+	crewStorage           CrewStorage
+	reviewStorage         ReviewStorage
+	watchlistStorage      WatchlistStorage
+	collectionStorage     CollectionStorage
+	recommendationStorage RecommendationStorage
+	costarStorage         CostarStorage
+	imageStorage          ImageStorage
+	translationStorage    TranslationStorage
+	releaseStorage        ReleaseStorage
+	franchiseStorage      FranchiseStorage
+	awardStorage          AwardStorage
+	catalogStorage        CatalogStorage
+	blobStore             blob.BlobStore
+	scorer                Scorer
+	maxImageSize          int64
+	maxImagePixels        int64
+	maxBatchSize          int
+	costars               costarGraph
 }
 
-func New(log *slog.Logger, actorStorage ActorStorage, movieStorage MovieStorage, userStorage UserStorage) *Service {
-	return &Service{log: log, actorStorage: actorStorage, movieStorage: movieStorage, userStorage: userStorage}
+// Storage is everything the service keeps in the database, postgresql.Storage provides
+// all of it.
+type Storage interface {
+	ActorStorage
+	MovieStorage
+	UserStorage
+	GenreStorage
+	CrewStorage
+	ReviewStorage
+	WatchlistStorage
+	CollectionStorage
+	RecommendationStorage
+	CostarStorage
+	ImageStorage
+	TranslationStorage
+	ReleaseStorage
+	FranchiseStorage
+	AwardStorage
+	CatalogStorage
+}
+
+// New builds the service on the storage and keeps images in the blob store.
+func New(log *slog.Logger, storage Storage, blobStore blob.BlobStore) *Service {
+	return &Service{
+		log:                   log,
+		actorStorage:          storage,
+		movieStorage:          storage,
+		userStorage:           storage,
+		genreStorage:          storage,
+		crewStorage:           storage,
+		reviewStorage:         storage,
+		watchlistStorage:      storage,
+		collectionStorage:     storage,
+		recommendationStorage: storage,
+		costarStorage:         storage,
+		imageStorage:          storage,
+		translationStorage:    storage,
+		releaseStorage:        storage,
+		franchiseStorage:      storage,
+		awardStorage:          storage,
+		catalogStorage:        storage,
+		blobStore:             blobStore,
+		scorer:                WeightedScorer(DefaultScoreWeights),
+		maxImageSize:          DefaultMaxImageSize,
+		maxImagePixels:        DefaultMaxImagePixels,
+		maxBatchSize:          DefaultMaxBatchSize,
+	}
 }
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidLocale    = errors.New("locale must be a two or three letter ISO 639 language code")
+	ErrEmptyTranslation = errors.New("translation is empty")
+)
+
+var localeRegexp = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+type TranslationStorage interface {
+	UpsertMovieTranslationStorage(translation *models.MovieTranslation) error
+	GetMovieTranslationsStorage(movieID int64) ([]*models.MovieTranslation, error)
+	DeleteMovieTranslationStorage(movieID int64, locale string) error
+	UpsertActorTranslationStorage(translation *models.ActorTranslation) error
+	GetActorTranslationsStorage(actorID int64) ([]*models.ActorTranslation, error)
+	DeleteActorTranslationStorage(actorID int64, locale string) error
+}
+
+func (s *Service) TranslateMovie(translation *models.MovieTranslation) error {
+	const op = "service.TranslateMovie"
+
+	translation.Locale = strings.ToLower(strings.TrimSpace(translation.Locale))
+	if !localeRegexp.MatchString(translation.Locale) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidLocale)
+	}
+
+	translation.Title = strings.TrimSpace(translation.Title)
+	translation.Description = strings.TrimSpace(translation.Description)
+	if translation.Title == "" {
+		return fmt.Errorf("%s: %w", op, ErrEmptyTranslation)
+	}
+
+	err := s.translationStorage.UpsertMovieTranslationStorage(translation)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetMovieTranslations(movieID int64) ([]*models.MovieTranslation, error) {
+	const op = "service.GetMovieTranslations"
+
+	translations, err := s.translationStorage.GetMovieTranslationsStorage(movieID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return translations, nil
+}
+
+func (s *Service) DeleteMovieTranslation(movieID int64, locale string) error {
+	const op = "service.DeleteMovieTranslation"
+
+	err := s.translationStorage.DeleteMovieTranslationStorage(movieID, strings.ToLower(locale))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) TranslateActor(translation *models.ActorTranslation) error {
+	const op = "service.TranslateActor"
+
+	translation.Locale = strings.ToLower(strings.TrimSpace(translation.Locale))
+	if !localeRegexp.MatchString(translation.Locale) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidLocale)
+	}
+
+	translation.Name = strings.TrimSpace(translation.Name)
+	if translation.Name == "" {
+		return fmt.Errorf("%s: %w", op, ErrEmptyTranslation)
+	}
+
+	err := s.translationStorage.UpsertActorTranslationStorage(translation)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetActorTranslations(actorID int64) ([]*models.ActorTranslation, error) {
+	const op = "service.GetActorTranslations"
+
+	translations, err := s.translationStorage.GetActorTranslationsStorage(actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return translations, nil
+}
+
+func (s *Service) DeleteActorTranslation(actorID int64, locale string) error {
+	const op = "service.DeleteActorTranslation"
+
+	err := s.translationStorage.DeleteActorTranslationStorage(actorID, strings.ToLower(locale))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
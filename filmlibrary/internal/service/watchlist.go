@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidWatchlistOrder = errors.New("watchlist order must list each movie once")
+	ErrInvalidWatchDate      = errors.New("watch date cannot be in the future")
+)
+
+type WatchlistStorage interface {
+	AddToWatchlistStorage(userID int64, item *models.WatchlistItem) error
+	GetWatchlistStorage(userID int64) ([]*models.WatchlistItem, error)
+	ReorderWatchlistStorage(userID int64, movieIDs []int64) error
+	DeleteFromWatchlistStorage(userID, movieID int64) error
+	AddWatchedStorage(userID int64, entry *models.WatchedEntry) error
+	GetWatchedStorage(userID int64, limit, offset int) ([]*models.WatchedEntry, int64, error)
+	DeleteWatchedStorage(userID, id int64) error
+}
+
+func (s *Service) AddToWatchlist(userID int64, item *models.WatchlistItem) error {
+	const op = "service.AddToWatchlist"
+
+	err := s.watchlistStorage.AddToWatchlistStorage(userID, item)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetWatchlist(userID int64) ([]*models.WatchlistItem, error) {
+	const op = "service.GetWatchlist"
+
+	items, err := s.watchlistStorage.GetWatchlistStorage(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return items, nil
+}
+
+// ReorderWatchlist puts the listed movies first, in the given order.
+func (s *Service) ReorderWatchlist(userID int64, movieIDs []int64) error {
+	const op = "service.ReorderWatchlist"
+
+	if len(movieIDs) == 0 || hasDuplicates(movieIDs) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidWatchlistOrder)
+	}
+
+	err := s.watchlistStorage.ReorderWatchlistStorage(userID, movieIDs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteFromWatchlist(userID, movieID int64) error {
+	const op = "service.DeleteFromWatchlist"
+
+	err := s.watchlistStorage.DeleteFromWatchlistStorage(userID, movieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkWatched logs a watched movie, dated today unless a date is given.
+func (s *Service) MarkWatched(userID int64, entry *models.WatchedEntry) error {
+	const op = "service.MarkWatched"
+
+	now := time.Now()
+	if entry.WatchedAt.IsZero() {
+		entry.WatchedAt = now
+	}
+	if entry.WatchedAt.After(now) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidWatchDate)
+	}
+
+	err := s.watchlistStorage.AddWatchedStorage(userID, entry)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetWatched(userID int64, page, limit int) (*models.WatchedPage, error) {
+	const op = "service.GetWatched"
+
+	entries, total, err := s.watchlistStorage.GetWatchedStorage(userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &models.WatchedPage{Entries: entries, Total: total, Page: page, Limit: limit}, nil
+}
+
+func (s *Service) DeleteWatched(userID, id int64) error {
+	const op = "service.DeleteWatched"
+
+	err := s.watchlistStorage.DeleteWatchedStorage(userID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
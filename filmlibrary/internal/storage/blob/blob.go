@@ -0,0 +1,18 @@
+// Package blob keeps binary objects, such as images, outside the database.
+package blob
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore keeps objects under slash-separated keys like "images/1/original".
+type BlobStore interface {
+	Put(key string, data []byte, contentType string) error
+	// Get returns ErrNotFound when there is no object under the key.
+	Get(key string) (io.ReadCloser, error)
+	// Delete does nothing when there is no object under the key.
+	Delete(key string) error
+}
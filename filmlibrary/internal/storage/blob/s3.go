@@ -0,0 +1,109 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
+	"time"
+)
+
+const defaultS3Timeout = 30 * time.Second
+
+// S3Store keeps objects in a bucket of an S3-compatible service, such as AWS S3 or MinIO.
+// The bucket has to exist already.
+type S3Store struct {
+	client  *minio.Client
+	bucket  string
+	timeout time.Duration
+}
+
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	Timeout   time.Duration
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	const op = "storage.blob.NewS3Store"
+
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultS3Timeout
+	}
+
+	return &S3Store{client: client, bucket: cfg.Bucket, timeout: timeout}, nil
+}
+
+func (s *S3Store) Put(key string, data []byte, contentType string) error {
+	const op = "storage.blob.S3Store.Put"
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Get reads the whole object before returning, so the request timeout does not
+// cut off a slow reader halfway through.
+func (s *S3Store) Get(key string) (io.ReadCloser, error) {
+	const op = "storage.blob.S3Store.Get"
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, mapS3Error(err))
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, mapS3Error(err))
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *S3Store) Delete(key string) error {
+	const op = "storage.blob.S3Store.Delete"
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func mapS3Error(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+
+	return err
+}
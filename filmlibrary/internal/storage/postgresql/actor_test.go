@@ -0,0 +1,87 @@
+package postgresql
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_ActorProfile(t *testing.T) {
+	s := newTestStorage(t)
+
+	suffix := fmt.Sprint(time.Now().UnixNano() % 1e7)
+	died := time.Date(1997, 2, 20, 0, 0, 0, 0, time.UTC)
+	actor := &models.Actor{
+		Name:        "profile-" + suffix,
+		Sex:         "male",
+		Birthday:    time.Date(1951, 10, 5, 0, 0, 0, 0, time.UTC),
+		DeathDate:   &died,
+		Aliases:     []string{"alias-" + suffix},
+		ExternalIDs: &models.ExternalIDs{IMDb: "nm" + fmt.Sprintf("%07s", suffix)},
+	}
+	if err := s.AddActorStorage(actor); err != nil {
+		t.Fatalf("AddActorStorage() error = %v", err)
+	}
+
+	duplicate := &models.Actor{Name: "duplicate-" + suffix, ExternalIDs: actor.ExternalIDs}
+	if err := s.AddActorStorage(duplicate); !errors.Is(err, storage.ErrExternalIDExists) {
+		t.Errorf("AddActorStorage() duplicate error = %v, want %v", err, storage.ErrExternalIDExists)
+	}
+
+	version, err := s.EditActorStorage(actor.ID, 1, &models.ActorPatch{
+		Birthplace:  models.SetTo("Moscow"),
+		Aliases:     models.SetNull[[]string](),
+		ExternalIDs: models.SetTo(models.ExternalIDsPatch{Kinopoisk: models.SetTo(suffix)}),
+	})
+	if err != nil {
+		t.Fatalf("EditActorStorage() error = %v", err)
+	}
+
+	got, err := s.GetActorProfileStorage(actor.ID, nil)
+	if err != nil {
+		t.Fatalf("GetActorProfileStorage() error = %v", err)
+	}
+	if got.Birthplace != "Moscow" || len(got.Aliases) != 0 || got.DeathDate == nil || !got.DeathDate.Equal(died) {
+		t.Errorf("GetActorProfileStorage() = %+v", got)
+	}
+	if want := (models.ExternalIDs{IMDb: actor.ExternalIDs.IMDb, Kinopoisk: suffix}); got.ExternalIDs == nil || *got.ExternalIDs != want {
+		t.Errorf("ExternalIDs = %+v, want %+v", got.ExternalIDs, want)
+	}
+
+	version, err = s.EditActorStorage(actor.ID, version, &models.ActorPatch{DeathDate: models.SetNull[time.Time](), ExternalIDs: models.SetNull[models.ExternalIDsPatch]()})
+	if err != nil {
+		t.Fatalf("EditActorStorage() error = %v", err)
+	}
+
+	got, err = s.GetActorProfileStorage(actor.ID, nil)
+	if err != nil {
+		t.Fatalf("GetActorProfileStorage() error = %v", err)
+	}
+	if got.DeathDate != nil || got.ExternalIDs != nil || got.Birthplace != "Moscow" {
+		t.Errorf("GetActorProfileStorage() after clearing = %+v", got)
+	}
+
+	if got.Version != version {
+		t.Errorf("Version = %d, want %d", got.Version, version)
+	}
+
+	if _, err := s.EditActorStorage(actor.ID, 1, &models.ActorPatch{Name: models.SetTo("stale")}); !errors.Is(err, storage.ErrVersionMismatch) {
+		t.Errorf("EditActorStorage() stale error = %v, want %v", err, storage.ErrVersionMismatch)
+	}
+	if err := s.DeleteActorStorage(actor.ID, 1); !errors.Is(err, storage.ErrVersionMismatch) {
+		t.Errorf("DeleteActorStorage() stale error = %v, want %v", err, storage.ErrVersionMismatch)
+	}
+	if err := s.DeleteActorStorage(actor.ID, version); err != nil {
+		t.Fatalf("DeleteActorStorage() error = %v", err)
+	}
+	if err := s.DeleteActorStorage(actor.ID, version+1); !errors.Is(err, storage.ErrPersonNotFound) {
+		t.Errorf("DeleteActorStorage() deleted error = %v, want %v", err, storage.ErrPersonNotFound)
+	}
+
+	if _, err := s.EditActorStorage(-1, 1, &models.ActorPatch{Name: models.SetTo("nobody")}); !errors.Is(err, storage.ErrPersonNotFound) {
+		t.Errorf("EditActorStorage() error = %v, want %v", err, storage.ErrPersonNotFound)
+	}
+}
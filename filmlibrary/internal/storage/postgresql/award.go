@@ -0,0 +1,334 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"strings"
+)
+
+// nominationColumns maps the ways nominations are browsed to the column they are matched by.
+var nominationColumns = map[string]string{
+	models.NominationsOfMovie:    "n.movie_id",
+	models.NominationsOfPerson:   "n.person_id",
+	models.NominationsOfCeremony: "n.ceremony_id",
+}
+
+func (s *Storage) AddAwardStorage(award *models.Award) error {
+	const op = "storage.postgresql.AddAwardStorage"
+
+	err := sq.Insert("awards").
+		Columns("name").
+		Values(award.Name).
+		Suffix("RETURNING id").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&award.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrAwardExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteAwardStorage deletes an award together with its ceremonies, categories and nominations.
+func (s *Storage) DeleteAwardStorage(id int64) error {
+	const op = "storage.postgresql.DeleteAwardStorage"
+
+	res, err := sq.Delete("awards").
+		Where(sq.Eq{"id": id}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAwardNotFound)
+	}
+
+	return nil
+}
+
+// GetAwardsStorage returns every award by name with its ceremonies, latest first, and its categories.
+func (s *Storage) GetAwardsStorage() ([]*models.Award, error) {
+	const op = "storage.postgresql.GetAwardsStorage"
+
+	rows, err := s.db.Query("SELECT id, name FROM awards ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	awards := []*models.Award{}
+	byID := map[int64]*models.Award{}
+	for rows.Next() {
+		award := &models.Award{Ceremonies: []*models.Ceremony{}, Categories: []*models.AwardCategory{}}
+		if err := rows.Scan(&award.ID, &award.Name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		awards = append(awards, award)
+		byID[award.ID] = award
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ceremonies, err := s.db.Query("SELECT id, award_id, year FROM award_ceremonies ORDER BY year DESC")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer ceremonies.Close()
+
+	for ceremonies.Next() {
+		ceremony := &models.Ceremony{}
+		if err := ceremonies.Scan(&ceremony.ID, &ceremony.AwardID, &ceremony.Year); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if award, ok := byID[ceremony.AwardID]; ok {
+			award.Ceremonies = append(award.Ceremonies, ceremony)
+		}
+	}
+	if err := ceremonies.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	categories, err := s.db.Query("SELECT id, award_id, name FROM award_categories ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer categories.Close()
+
+	for categories.Next() {
+		category := &models.AwardCategory{}
+		if err := categories.Scan(&category.ID, &category.AwardID, &category.Name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if award, ok := byID[category.AwardID]; ok {
+			award.Categories = append(award.Categories, category)
+		}
+	}
+	if err := categories.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return awards, nil
+}
+
+func (s *Storage) AddCeremonyStorage(ceremony *models.Ceremony) error {
+	const op = "storage.postgresql.AddCeremonyStorage"
+
+	err := sq.Insert("award_ceremonies").
+		Columns("award_id", "year").
+		Select(sq.Select("a.id").
+			Column("?::int", ceremony.Year).
+			From("awards a").
+			Where(sq.Eq{"a.id": ceremony.AwardID})).
+		Suffix("RETURNING id").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&ceremony.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrAwardNotFound)
+		}
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrCeremonyExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) AddAwardCategoryStorage(category *models.AwardCategory) error {
+	const op = "storage.postgresql.AddAwardCategoryStorage"
+
+	err := sq.Insert("award_categories").
+		Columns("award_id", "name").
+		Select(sq.Select("a.id").
+			Column("?::text", category.Name).
+			From("awards a").
+			Where(sq.Eq{"a.id": category.AwardID})).
+		Suffix("RETURNING id").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&category.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrAwardNotFound)
+		}
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrCategoryExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpsertNominationStorage nominates a movie, and optionally a person, in a category
+// of a ceremony of the same award. Nominating them again updates the won flag.
+func (s *Storage) UpsertNominationStorage(nomination *models.Nomination) error {
+	const op = "storage.postgresql.UpsertNominationStorage"
+
+	err := sq.Insert("nominations").
+		Columns("ceremony_id", "category_id", "movie_id", "person_id", "won").
+		Select(sq.Select("ce.id", "ca.id", "m.id").
+			Column("?::int", nomination.PersonID).
+			Column("?::boolean", nomination.Won).
+			From("award_ceremonies ce").
+			Join("award_categories ca ON ca.award_id = ce.award_id").
+			Join("movies m ON m.deleted_at IS NULL").
+			Where(sq.Eq{"ce.id": nomination.CeremonyID, "ca.id": nomination.CategoryID, "m.id": nomination.MovieID}).
+			Where("(?::int IS NULL OR EXISTS (SELECT 1 FROM people p WHERE p.id = ? AND p.deleted_at IS NULL))", nomination.PersonID, nomination.PersonID)).
+		Suffix("ON CONFLICT (ceremony_id, category_id, movie_id, COALESCE(person_id, 0)) DO UPDATE SET won = EXCLUDED.won RETURNING id").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&nomination.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, s.nominationTargetError(nomination))
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// nominationTargetError tells which part of the nomination could not be found.
+func (s *Storage) nominationTargetError(nomination *models.Nomination) error {
+	var ceremonyAward, categoryAward sql.NullInt64
+	var movieExists, personExists bool
+	err := s.db.QueryRow(`SELECT
+		(SELECT award_id FROM award_ceremonies WHERE id = $1),
+		(SELECT award_id FROM award_categories WHERE id = $2),
+		EXISTS (SELECT 1 FROM movies WHERE id = $3 AND deleted_at IS NULL),
+		$4::int IS NULL OR EXISTS (SELECT 1 FROM people WHERE id = $4 AND deleted_at IS NULL)`,
+		nomination.CeremonyID, nomination.CategoryID, nomination.MovieID, nomination.PersonID,
+	).Scan(&ceremonyAward, &categoryAward, &movieExists, &personExists)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !ceremonyAward.Valid:
+		return storage.ErrCeremonyNotFound
+	case !categoryAward.Valid:
+		return storage.ErrCategoryNotFound
+	case ceremonyAward.Int64 != categoryAward.Int64:
+		return storage.ErrCategoryOfOtherAward
+	case !movieExists:
+		return storage.ErrMovieNotFound
+	case !personExists:
+		return storage.ErrPersonNotFound
+	}
+
+	return sql.ErrNoRows
+}
+
+func (s *Storage) DeleteNominationStorage(id int64) error {
+	const op = "storage.postgresql.DeleteNominationStorage"
+
+	res, err := sq.Delete("nominations").
+		Where(sq.Eq{"id": id}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrNominationNotFound)
+	}
+
+	return nil
+}
+
+// GetNominationsStorage returns the nominations of a movie, a person or a ceremony,
+// latest ceremony first and winners first within a category.
+func (s *Storage) GetNominationsStorage(of string, id int64, langs []string) ([]*models.NominationListing, error) {
+	const op = "storage.postgresql.GetNominationsStorage"
+
+	column, ok := nominationColumns[of]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown nominations of %q", op, of)
+	}
+
+	query, args, err := sq.Select("n.id", "aw.name", "ce.year", "ca.name", "m.id", "COALESCE(mt.title, m.title)", "a.id", "COALESCE(pt.name, a.name)", "n.won").
+		From("nominations n").
+		Join("award_ceremonies ce ON ce.id = n.ceremony_id").
+		Join("awards aw ON aw.id = ce.award_id").
+		Join("award_categories ca ON ca.id = n.category_id").
+		Join("movies m ON m.id = n.movie_id AND m.deleted_at IS NULL").
+		LeftJoin(movieTranslationJoin, langs, langs).
+		LeftJoin("people a ON a.id = n.person_id AND a.deleted_at IS NULL").
+		LeftJoin(personTranslationJoin, langs, langs).
+		Where(sq.Eq{column: id}).
+		OrderBy("ce.year DESC", "aw.name", "ca.name", "n.won DESC", "n.id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	nominations := []*models.NominationListing{}
+	for rows.Next() {
+		nomination := &models.NominationListing{Movie: &models.MovieRef{}}
+		var personID sql.NullInt64
+		var personName sql.NullString
+		err := rows.Scan(&nomination.ID, &nomination.Award, &nomination.Year, &nomination.Category,
+			&nomination.Movie.ID, &nomination.Movie.Title, &personID, &personName, &nomination.Won)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if personID.Valid {
+			nomination.Person = &models.ActorRef{ID: personID.Int64, Name: personName.String}
+		}
+		nominations = append(nominations, nomination)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nominations, nil
+}
+
+// filterAwards keeps the movies m of the listing that won the award of the filter.
+func filterAwards(listing sq.SelectBuilder, filter models.MovieFilter) sq.SelectBuilder {
+	if filter.WonAward == "" {
+		return listing
+	}
+
+	won := sq.Select("1").
+		From("nominations n").
+		Join("award_ceremonies ce ON ce.id = n.ceremony_id").
+		Join("awards aw ON aw.id = ce.award_id").
+		Where("n.movie_id = m.id AND n.won").
+		Where(sq.Eq{"LOWER(aw.name)": strings.ToLower(filter.WonAward)})
+
+	return listing.Where(sq.Expr("EXISTS (?)", won))
+}
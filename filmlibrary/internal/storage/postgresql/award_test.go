@@ -0,0 +1,87 @@
+package postgresql
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_Nominations(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("award-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 2, 0)
+
+	person := &models.Person{Name: prefix + " actor"}
+	if err := s.AddPersonStorage(person); err != nil {
+		t.Fatalf("AddPersonStorage() error = %v", err)
+	}
+
+	award, other := &models.Award{Name: prefix}, &models.Award{Name: prefix + " other"}
+	for _, a := range []*models.Award{award, other} {
+		if err := s.AddAwardStorage(a); err != nil {
+			t.Fatalf("AddAwardStorage() error = %v", err)
+		}
+	}
+
+	ceremony := &models.Ceremony{AwardID: award.ID, Year: 1995}
+	if err := s.AddCeremonyStorage(ceremony); err != nil {
+		t.Fatalf("AddCeremonyStorage() error = %v", err)
+	}
+	if err := s.AddCeremonyStorage(&models.Ceremony{AwardID: award.ID, Year: 1995}); !errors.Is(err, storage.ErrCeremonyExists) {
+		t.Errorf("AddCeremonyStorage() duplicate error = %v, want %v", err, storage.ErrCeremonyExists)
+	}
+
+	bestPicture := &models.AwardCategory{AwardID: award.ID, Name: "Best Picture"}
+	bestActor := &models.AwardCategory{AwardID: award.ID, Name: "Best Actor"}
+	otherCategory := &models.AwardCategory{AwardID: other.ID, Name: "Palme d'Or"}
+	for _, category := range []*models.AwardCategory{bestPicture, bestActor, otherCategory} {
+		if err := s.AddAwardCategoryStorage(category); err != nil {
+			t.Fatalf("AddAwardCategoryStorage() error = %v", err)
+		}
+	}
+
+	for _, nomination := range []*models.Nomination{
+		{CeremonyID: ceremony.ID, CategoryID: bestPicture.ID, MovieID: ids[0]},
+		{CeremonyID: ceremony.ID, CategoryID: bestPicture.ID, MovieID: ids[1], Won: true},
+		{CeremonyID: ceremony.ID, CategoryID: bestActor.ID, MovieID: ids[0], PersonID: &person.ID, Won: true},
+		// nominating again updates the won flag
+		{CeremonyID: ceremony.ID, CategoryID: bestPicture.ID, MovieID: ids[1]},
+	} {
+		if err := s.UpsertNominationStorage(nomination); err != nil {
+			t.Fatalf("UpsertNominationStorage() error = %v", err)
+		}
+	}
+
+	err := s.UpsertNominationStorage(&models.Nomination{CeremonyID: ceremony.ID, CategoryID: otherCategory.ID, MovieID: ids[0]})
+	if !errors.Is(err, storage.ErrCategoryOfOtherAward) {
+		t.Errorf("UpsertNominationStorage() error = %v, want %v", err, storage.ErrCategoryOfOtherAward)
+	}
+
+	nominations, err := s.GetNominationsStorage(models.NominationsOfPerson, person.ID, nil)
+	if err != nil {
+		t.Fatalf("GetNominationsStorage() error = %v", err)
+	}
+	if len(nominations) != 1 || nominations[0].Category != "Best Actor" || !nominations[0].Won {
+		t.Errorf("person nominations = %+v, want a won Best Actor nomination", nominations)
+	}
+
+	nominations, err = s.GetNominationsStorage(models.NominationsOfCeremony, ceremony.ID, nil)
+	if err != nil {
+		t.Fatalf("GetNominationsStorage() error = %v", err)
+	}
+	if len(nominations) != 3 {
+		t.Errorf("ceremony nominations = %d, want 3", len(nominations))
+	}
+
+	movies, err := s.GetMoviesSortedStorage("title", "ASC", models.MovieFilter{WonAward: prefix}, nil)
+	if err != nil {
+		t.Fatalf("GetMoviesSortedStorage() error = %v", err)
+	}
+	if len(movies) != 1 || movies[0].ID != ids[0] {
+		t.Errorf("movies that won = %+v, want only %d", movies, ids[0])
+	}
+}
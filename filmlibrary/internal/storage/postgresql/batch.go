@@ -0,0 +1,112 @@
+package postgresql
+
+import (
+	"database/sql"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+)
+
+// BatchMoviesStorage creates, updates and deletes movies in one transaction, see runBatch.
+func (s *Storage) BatchMoviesStorage(items []*models.MovieBatchItem, atomic bool) ([]*models.BatchResult, error) {
+	const op = "storage.postgresql.BatchMoviesStorage"
+
+	results, err := s.runBatch(len(items), atomic, func(tx *sql.Tx, i int) *models.BatchResult {
+		item := items[i]
+		result := &models.BatchResult{ID: item.ID}
+		switch item.Op {
+		case models.BatchCreate:
+			result.Version, result.Err = insertMovie(tx, item.Movie)
+			result.ID = item.Movie.ID
+		case models.BatchUpdate:
+			result.Version, result.Err = updateMovie(tx, item.ID, item.Version, item.Patch)
+		case models.BatchDelete:
+			result.Err = deleteMovie(tx, item.ID, item.Version)
+		default:
+			result.Err = fmt.Errorf("unknown batch operation %q", item.Op)
+		}
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// BatchActorsStorage creates, updates and deletes actors in one transaction, see runBatch.
+func (s *Storage) BatchActorsStorage(items []*models.ActorBatchItem, atomic bool) ([]*models.BatchResult, error) {
+	const op = "storage.postgresql.BatchActorsStorage"
+
+	results, err := s.runBatch(len(items), atomic, func(tx *sql.Tx, i int) *models.BatchResult {
+		item := items[i]
+		result := &models.BatchResult{ID: item.ID}
+		switch item.Op {
+		case models.BatchCreate:
+			result.Version, result.Err = insertActor(tx, item.Actor)
+			result.ID = item.Actor.ID
+		case models.BatchUpdate:
+			result.Version, result.Err = updateActor(tx, item.ID, item.Version, item.Patch)
+		case models.BatchDelete:
+			result.Err = deleteActor(tx, item.ID, item.Version)
+		default:
+			result.Err = fmt.Errorf("unknown batch operation %q", item.Op)
+		}
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// runBatch applies the n items of a batch in one transaction. An atomic batch stops
+// at the first item that fails and is rolled back as a whole, every other item then
+// fails with storage.ErrBatchRolledBack. Otherwise each item runs in a savepoint, so
+// a failed item is undone alone and the rest are committed.
+func (s *Storage) runBatch(n int, atomic bool, apply func(tx *sql.Tx, i int) *models.BatchResult) ([]*models.BatchResult, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.BatchResult, n)
+	for i := range results {
+		if !atomic {
+			if _, err := tx.Exec("SAVEPOINT batch_item"); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+
+		results[i] = apply(tx, i)
+
+		var err error
+		switch {
+		case atomic && results[i].Err != nil:
+			tx.Rollback()
+			for j := range results {
+				if j != i {
+					results[j] = &models.BatchResult{Err: storage.ErrBatchRolledBack}
+				}
+			}
+			return results, nil
+		case atomic:
+		case results[i].Err != nil:
+			_, err = tx.Exec("ROLLBACK TO SAVEPOINT batch_item")
+		default:
+			_, err = tx.Exec("RELEASE SAVEPOINT batch_item")
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
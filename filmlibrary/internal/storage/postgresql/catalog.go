@@ -0,0 +1,445 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"io"
+)
+
+// ImportCatalogStorage upserts the items next returns until io.EOF in one transaction,
+// which a dry run rolls back at the end. Every item runs in a savepoint, so an item
+// that fails is undone alone and reported with its error in its change. Any other
+// error of next aborts the whole import.
+func (s *Storage) ImportCatalogStorage(next func() (*models.ImportItem, error), dryRun bool) ([]*models.ImportChange, error) {
+	const op = "storage.postgresql.ImportCatalogStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var changes []*models.ImportChange
+	for {
+		item, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if _, err := tx.Exec("SAVEPOINT import_item"); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		var change *models.ImportChange
+		if item.Movie != nil {
+			change = importMovie(tx, item)
+		} else {
+			change = importActor(tx, item)
+		}
+
+		savepoint := "RELEASE SAVEPOINT import_item"
+		if change.Err != nil {
+			savepoint = "ROLLBACK TO SAVEPOINT import_item"
+		}
+		if _, err := tx.Exec(savepoint); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		changes = append(changes, change)
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// importMovie creates the movie of the item or, when a movie with one of its external
+// IDs or else with its title and release year is stored, updates the fields the item
+// gives. The cast is linked to the movie in both cases.
+func importMovie(db runner, item *models.ImportItem) *models.ImportChange {
+	movie := item.Movie
+	change := &models.ImportChange{Row: item.Row, Type: models.CatalogMovie, Name: movie.Title}
+
+	cast, err := castIDs(db, item.Cast)
+	if err != nil {
+		change.Err = err
+		return change
+	}
+
+	stored, version, err := findImportedMovie(db, movie)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		change.Action = models.ImportCreate
+		if _, change.Err = insertMovie(db, movie); change.Err == nil {
+			change.ID = movie.ID
+			_, change.Err = linkCast(db, movie.ID, cast)
+		}
+		return change
+	case err != nil:
+		change.Err = err
+		return change
+	}
+
+	change.ID = stored.ID
+	patch, fields := importedMoviePatch(stored, movie)
+	if len(fields) > 0 {
+		if _, err := updateMovie(db, stored.ID, version, patch); err != nil {
+			change.Err = err
+			return change
+		}
+	}
+
+	linked, err := linkCast(db, stored.ID, cast)
+	if err != nil {
+		change.Err = err
+		return change
+	}
+	if linked {
+		fields = append(fields, "cast")
+	}
+
+	change.Action, change.Fields = models.ImportUnchanged, fields
+	if len(fields) > 0 {
+		change.Action = models.ImportUpdate
+	}
+
+	return change
+}
+
+// importActor creates or updates the actor of the item like importMovie, an actor is
+// matched by one of its external IDs or else by its name and year of birth.
+func importActor(db runner, item *models.ImportItem) *models.ImportChange {
+	actor := item.Actor
+	change := &models.ImportChange{Row: item.Row, Type: models.CatalogActor, Name: actor.Name}
+
+	stored, version, err := findImportedActor(db, actor)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		change.Action = models.ImportCreate
+		if _, change.Err = insertActor(db, actor); change.Err == nil {
+			change.ID = actor.ID
+		}
+		return change
+	case err != nil:
+		change.Err = err
+		return change
+	}
+
+	change.ID = stored.ID
+	change.Action = models.ImportUnchanged
+
+	patch, fields := importedActorPatch(stored, actor)
+	if len(fields) > 0 {
+		if _, err := updateActor(db, stored.ID, version, patch); err != nil {
+			change.Err = err
+			return change
+		}
+		change.Action, change.Fields = models.ImportUpdate, fields
+	}
+
+	return change
+}
+
+func findImportedMovie(db runner, movie *models.Movie) (*models.Movie, int64, error) {
+	selectMovie := sq.Select("id", "title", "COALESCE(description, '')", "release_date", "rating",
+		"COALESCE(runtime, 0)", "COALESCE(original_language, '')", "COALESCE(imdb_id, '')", "COALESCE(kinopoisk_id, '')", "version").
+		From("movies").
+		Where(sq.Eq{"deleted_at": nil}).
+		OrderBy("id").
+		Limit(1)
+
+	byTitle := sq.And{sq.Expr("lower(title) = lower(?)", movie.Title), sq.Expr("EXTRACT(YEAR FROM release_date) = ?", movie.ReleaseDate.Year())}
+
+	var stored *models.Movie
+	var version int64
+	err := sql.ErrNoRows
+	for _, match := range []sq.Sqlizer{externalIDMatch(movie.ExternalIDs), byTitle} {
+		if match == nil {
+			continue
+		}
+
+		stored = &models.Movie{MovieFacts: models.MovieFacts{ExternalIDs: &models.ExternalIDs{}}}
+		err = selectMovie.Where(match).RunWith(db).PlaceholderFormat(sq.Dollar).
+			Scan(&stored.ID, &stored.Title, &stored.Description, &stored.ReleaseDate, &stored.Rating,
+				&stored.Runtime, &stored.OriginalLanguage, &stored.ExternalIDs.IMDb, &stored.ExternalIDs.Kinopoisk, &version)
+		if !errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+	}
+
+	return stored, version, err
+}
+
+func findImportedActor(db runner, actor *models.Actor) (*models.Actor, int64, error) {
+	selectActor := sq.Select("id", "name", "COALESCE(sex, '')", "birthday", "death_date", "COALESCE(birthplace, '')",
+		"COALESCE(imdb_id, '')", "COALESCE(kinopoisk_id, '')", "version").
+		From("people").
+		Where(sq.Eq{"deleted_at": nil}).
+		OrderBy("id").
+		Limit(1)
+
+	byName := sq.And{sq.Expr("lower(name) = lower(?)", actor.Name), sq.Expr("EXTRACT(YEAR FROM birthday) = ?", actor.Birthday.Year())}
+
+	var stored *models.Actor
+	var version int64
+	err := sql.ErrNoRows
+	for _, match := range []sq.Sqlizer{externalIDMatch(actor.ExternalIDs), byName} {
+		if match == nil {
+			continue
+		}
+
+		var birthday sql.NullTime
+		stored = &models.Actor{ExternalIDs: &models.ExternalIDs{}}
+		err = selectActor.Where(match).RunWith(db).PlaceholderFormat(sq.Dollar).
+			Scan(&stored.ID, &stored.Name, &stored.Sex, &birthday, &stored.DeathDate, &stored.Birthplace,
+				&stored.ExternalIDs.IMDb, &stored.ExternalIDs.Kinopoisk, &version)
+		stored.Birthday = birthday.Time
+		if !errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+	}
+
+	return stored, version, err
+}
+
+// externalIDMatch matches a row by any of the external IDs that are set, it is nil
+// when none is.
+func externalIDMatch(ids *models.ExternalIDs) sq.Sqlizer {
+	if ids == nil {
+		return nil
+	}
+
+	match := sq.Or{}
+	if ids.IMDb != "" {
+		match = append(match, sq.Eq{"imdb_id": ids.IMDb})
+	}
+	if ids.Kinopoisk != "" {
+		match = append(match, sq.Eq{"kinopoisk_id": ids.Kinopoisk})
+	}
+	if len(match) == 0 {
+		return nil
+	}
+
+	return match
+}
+
+// importedMoviePatch sets the fields the imported movie gives and the stored one
+// differs in, fields lists them. Fields the import leaves empty are kept.
+func importedMoviePatch(stored, movie *models.Movie) (*models.MoviePatch, []string) {
+	patch := &models.MoviePatch{}
+	var fields []string
+
+	if movie.Title != stored.Title {
+		patch.Title, fields = models.SetTo(movie.Title), append(fields, "title")
+	}
+	if movie.Description != "" && movie.Description != stored.Description {
+		patch.Description, fields = models.SetTo(movie.Description), append(fields, "description")
+	}
+	if !movie.ReleaseDate.Equal(stored.ReleaseDate) {
+		patch.ReleaseDate, fields = models.SetTo(movie.ReleaseDate), append(fields, "release_date")
+	}
+	if movie.Rating != nil && (stored.Rating == nil || *movie.Rating != *stored.Rating) {
+		patch.Rating, fields = models.SetTo(*movie.Rating), append(fields, "rating")
+	}
+	if movie.Runtime > 0 && movie.Runtime != stored.Runtime {
+		patch.Runtime, fields = models.SetTo(movie.Runtime), append(fields, "runtime")
+	}
+	if movie.OriginalLanguage != "" && movie.OriginalLanguage != stored.OriginalLanguage {
+		patch.OriginalLanguage, fields = models.SetTo(movie.OriginalLanguage), append(fields, "original_language")
+	}
+	if ids, ok := importedExternalIDs(stored.ExternalIDs, movie.ExternalIDs); ok {
+		patch.ExternalIDs, fields = models.SetTo(ids), append(fields, "external_ids")
+	}
+
+	return patch, fields
+}
+
+// importedActorPatch sets the fields of the actor like importedMoviePatch.
+func importedActorPatch(stored, actor *models.Actor) (*models.ActorPatch, []string) {
+	patch := &models.ActorPatch{}
+	var fields []string
+
+	if actor.Name != stored.Name {
+		patch.Name, fields = models.SetTo(actor.Name), append(fields, "name")
+	}
+	if actor.Sex != "" && actor.Sex != stored.Sex {
+		patch.Sex, fields = models.SetTo(actor.Sex), append(fields, "sex")
+	}
+	if !actor.Birthday.Equal(stored.Birthday) {
+		patch.Birthday, fields = models.SetTo(actor.Birthday), append(fields, "birthday")
+	}
+	if actor.DeathDate != nil && (stored.DeathDate == nil || !actor.DeathDate.Equal(*stored.DeathDate)) {
+		patch.DeathDate, fields = models.SetTo(*actor.DeathDate), append(fields, "death_date")
+	}
+	if actor.Birthplace != "" && actor.Birthplace != stored.Birthplace {
+		patch.Birthplace, fields = models.SetTo(actor.Birthplace), append(fields, "birthplace")
+	}
+	if ids, ok := importedExternalIDs(stored.ExternalIDs, actor.ExternalIDs); ok {
+		patch.ExternalIDs, fields = models.SetTo(ids), append(fields, "external_ids")
+	}
+
+	return patch, fields
+}
+
+// importedExternalIDs merges the imported external IDs that differ from the stored
+// ones, ok is false when none does.
+func importedExternalIDs(stored, imported *models.ExternalIDs) (models.ExternalIDsPatch, bool) {
+	var patch models.ExternalIDsPatch
+	if imported == nil {
+		return patch, false
+	}
+
+	if imported.IMDb != "" && imported.IMDb != stored.IMDb {
+		patch.IMDb = models.SetTo(imported.IMDb)
+	}
+	if imported.Kinopoisk != "" && imported.Kinopoisk != stored.Kinopoisk {
+		patch.Kinopoisk = models.SetTo(imported.Kinopoisk)
+	}
+
+	return patch, patch.IMDb.Set || patch.Kinopoisk.Set
+}
+
+// castIDs looks up the actors of a cast by their IMDb IDs.
+func castIDs(db runner, imdbIDs []string) ([]int64, error) {
+	if len(imdbIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := sq.Select("id", "imdb_id").
+		From("people").
+		Where(sq.Eq{"imdb_id": imdbIDs, "deleted_at": nil}).
+		RunWith(db).PlaceholderFormat(sq.Dollar).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]int64, len(imdbIDs))
+	for rows.Next() {
+		var id int64
+		var imdbID string
+		if err := rows.Scan(&id, &imdbID); err != nil {
+			return nil, err
+		}
+		found[imdbID] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(imdbIDs))
+	for _, imdbID := range imdbIDs {
+		id, ok := found[imdbID]
+		if !ok {
+			return nil, fmt.Errorf("cast member %s: %w", imdbID, storage.ErrPersonNotFound)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// linkCast credits the actors in the movie and reports whether any of them was not
+// credited yet.
+func linkCast(db runner, movieID int64, actorIDs []int64) (bool, error) {
+	if len(actorIDs) == 0 {
+		return false, nil
+	}
+
+	res, err := insertCredits([]int64{movieID}, actorIDs, models.RoleActor).
+		RunWith(db).PlaceholderFormat(sq.Dollar).Exec()
+	if err != nil {
+		return false, err
+	}
+
+	linked, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return linked > 0, nil
+}
+
+// ExportMoviesStorage calls each with the movies of the listing narrowed down by the
+// filter, in its order, as they are read from the database, so the catalog is never
+// held in memory. An error of each stops the export and is returned.
+func (s *Storage) ExportMoviesStorage(sortBy string, sortDirection string, filter models.MovieFilter, langs []string, each func(*models.MovieListing) error) error {
+	const op = "storage.postgresql.ExportMoviesStorage"
+
+	query, args, err := sortedMovieListings(sortBy, sortDirection, filter, langs).
+		OrderBy("m.id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.exportRows(query, args, func(rows *sql.Rows) error {
+		movie, err := scanMovieListing(rows)
+		if err != nil {
+			return err
+		}
+		return each(movie)
+	}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ExportActorsStorage calls each with the actors of the listing ordered by ID, like
+// ExportMoviesStorage.
+func (s *Storage) ExportActorsStorage(langs []string, each func(*models.ActorListing) error) error {
+	const op = "storage.postgresql.ExportActorsStorage"
+
+	query, args, err := selectActorListings(langs).
+		OrderBy("a.id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.exportRows(query, args, func(rows *sql.Rows) error {
+		actor, err := scanActorListing(rows)
+		if err != nil {
+			return err
+		}
+		return each(actor)
+	}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// exportRows runs the query and calls scan for each row while it is read.
+func (s *Storage) exportRows(query string, args []interface{}, scan func(rows *sql.Rows) error) error {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
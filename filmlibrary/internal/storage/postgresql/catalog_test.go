@@ -0,0 +1,131 @@
+package postgresql
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// importItems returns the next function of ImportCatalogStorage reading the items.
+func importItems(items []*models.ImportItem) func() (*models.ImportItem, error) {
+	return func() (*models.ImportItem, error) {
+		if len(items) == 0 {
+			return nil, io.EOF
+		}
+		item := items[0]
+		items = items[1:]
+		return item, nil
+	}
+}
+
+func TestStorage_ImportCatalog(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("import-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 1, 0)
+	imdb := fmt.Sprintf("nm%08d", time.Now().UnixNano()%100000000)
+	t.Cleanup(func() {
+		s.db.Exec("DELETE FROM movie_crew WHERE person_id IN (SELECT id FROM people WHERE imdb_id = $1)", imdb)
+		s.db.Exec("DELETE FROM people WHERE imdb_id = $1", imdb)
+		s.db.Exec("DELETE FROM movies WHERE title = $1", prefix+" new")
+	})
+
+	items := func() []*models.ImportItem {
+		return []*models.ImportItem{
+			{Row: 2, Actor: &models.Actor{Name: prefix + " actor", Sex: "male", Birthday: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExternalIDs: &models.ExternalIDs{IMDb: imdb}}},
+			{Row: 3, Movie: &models.Movie{Title: prefix + " new", ReleaseDate: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)}, Cast: []string{imdb}},
+			{Row: 4, Movie: &models.Movie{Title: prefix + " 0", ReleaseDate: time.Date(2000, 6, 1, 0, 0, 0, 0, time.UTC)}, Cast: []string{imdb}},
+			{Row: 5, Movie: &models.Movie{Title: prefix + " missing cast", ReleaseDate: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)}, Cast: []string{"nm0"}},
+		}
+	}
+	actions := func(changes []*models.ImportChange) []string {
+		var got []string
+		for _, change := range changes {
+			got = append(got, fmt.Sprintf("%d %s %v", change.Row, change.Action, change.Fields))
+		}
+		return got
+	}
+
+	changes, err := s.ImportCatalogStorage(importItems(items()), true)
+	if err != nil {
+		t.Fatalf("ImportCatalogStorage() dry run error = %v", err)
+	}
+	want := []string{"2 create []", "3 create []", "4 update [release_date cast]", "5  []"}
+	if got := actions(changes); !slices.Equal(got, want) {
+		t.Errorf("ImportCatalogStorage() dry run = %v, want %v", got, want)
+	}
+	if !errors.Is(changes[3].Err, storage.ErrPersonNotFound) {
+		t.Errorf("ImportCatalogStorage() missing cast error = %v, want %v", changes[3].Err, storage.ErrPersonNotFound)
+	}
+	if got, _ := s.GetMovieStorageByID(ids[0]); got.Version != 1 {
+		t.Errorf("Version = %d, want the dry run rolled back", got.Version)
+	}
+
+	if _, err := s.ImportCatalogStorage(importItems(items()), false); err != nil {
+		t.Fatalf("ImportCatalogStorage() error = %v", err)
+	}
+	changes, err = s.ImportCatalogStorage(importItems(items()), false)
+	if err != nil {
+		t.Fatalf("ImportCatalogStorage() again error = %v", err)
+	}
+	want = []string{"2 unchanged []", "3 unchanged []", "4 unchanged []", "5  []"}
+	if got := actions(changes); !slices.Equal(got, want) {
+		t.Errorf("ImportCatalogStorage() again = %v, want %v", got, want)
+	}
+}
+
+func TestStorage_ExportCatalog(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("export-%d", time.Now().UnixNano())
+	seedMovies(t, s, prefix, 3, 2)
+
+	var titles []string
+	err := s.ExportMoviesStorage("title", "ASC", models.MovieFilter{GrossCurrency: "USD"}, nil, func(movie *models.MovieListing) error {
+		if strings.HasPrefix(movie.Title, prefix) {
+			titles = append(titles, fmt.Sprintf("%s %d", movie.Title, len(movie.Actors)))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportMoviesStorage() error = %v", err)
+	}
+	want := []string{prefix + " 0 2", prefix + " 1 2", prefix + " 2 2"}
+	if !slices.Equal(titles, want) {
+		t.Errorf("exported movies %q, want %q", titles, want)
+	}
+
+	var actors int
+	err = s.ExportActorsStorage(nil, func(actor *models.ActorListing) error {
+		if strings.HasPrefix(actor.Name, prefix) {
+			actors++
+			if len(actor.Movies) != 1 {
+				t.Errorf("actor %s exported with movies %q", actor.Name, actor.Movies)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportActorsStorage() error = %v", err)
+	}
+	if actors != 6 {
+		t.Errorf("exported %d actors, want 6", actors)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = s.ExportMoviesStorage("", "", models.MovieFilter{GrossCurrency: "USD"}, nil, func(*models.MovieListing) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("ExportMoviesStorage() error = %v after %d calls, want %v after 1", err, calls, errStop)
+	}
+}
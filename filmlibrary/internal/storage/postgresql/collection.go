@@ -0,0 +1,378 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+func (s *Storage) AddCollectionStorage(collection *models.Collection) error {
+	const op = "storage.postgresql.AddCollectionStorage"
+
+	err := sq.Insert("collections").
+		Columns("owner_id", "title", "description", "slug", "public").
+		Values(collection.OwnerID, collection.Title, collection.Description, collection.Slug, collection.Public).
+		Suffix("RETURNING id, created_at, updated_at").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&collection.ID, &collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrCollectionExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// EditCollectionStorage updates the title, description and visibility of the owner's collection.
+// The slug stays the same, so links to the collection keep working.
+func (s *Storage) EditCollectionStorage(collection *models.Collection) error {
+	const op = "storage.postgresql.EditCollectionStorage"
+
+	err := sq.Update("collections").
+		Set("title", collection.Title).
+		Set("description", collection.Description).
+		Set("public", collection.Public).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": collection.ID, "owner_id": collection.OwnerID}).
+		Suffix("RETURNING slug, created_at, updated_at").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&collection.Slug, &collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrCollectionNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteCollectionStorage(ownerID, id int64) error {
+	const op = "storage.postgresql.DeleteCollectionStorage"
+
+	res, err := sq.Delete("collections").
+		Where(sq.Eq{"id": id, "owner_id": ownerID}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrCollectionNotFound)
+	}
+
+	return nil
+}
+
+// AddMoviesToCollectionStorage appends the movies to the end of the owner's collection
+// in the given order, movies that are already in the collection are skipped.
+func (s *Storage) AddMoviesToCollectionStorage(ownerID, collectionID int64, movieIDs []int64) error {
+	const op = "storage.postgresql.AddMoviesToCollectionStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = touchOwnedCollection(tx, ownerID, collectionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var found int
+	err = sq.Select("COUNT(*)").
+		From("movies").
+		Where(sq.Eq{"id": movieIDs}).
+		Where("deleted_at IS NULL").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&found)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if found != len(movieIDs) {
+		err = storage.ErrMovieNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = sq.Insert("collection_items").
+		Columns("collection_id", "movie_id", "position").
+		Select(sq.Select("c.id", "u.movie_id").
+			Column("(SELECT COALESCE(MAX(position), 0) FROM collection_items WHERE collection_id = c.id) + u.ord").
+			From("collections c").
+			CrossJoin("unnest(?::int[]) WITH ORDINALITY AS u(movie_id, ord)", movieIDs).
+			Where(sq.Eq{"c.id": collectionID})).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteMovieFromCollectionStorage(ownerID, collectionID, movieID int64) error {
+	const op = "storage.postgresql.DeleteMovieFromCollectionStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = touchOwnedCollection(tx, ownerID, collectionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := sq.Delete("collection_items").
+		Where(sq.Eq{"collection_id": collectionID, "movie_id": movieID}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		err = storage.ErrCollectionItemNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReorderCollectionStorage moves the given movies to the top of the owner's collection
+// in the given order, the rest of the items keep their relative order after them.
+func (s *Storage) ReorderCollectionStorage(ownerID, collectionID int64, movieIDs []int64) error {
+	const op = "storage.postgresql.ReorderCollectionStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = touchOwnedCollection(tx, ownerID, collectionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = sq.Update("collection_items").
+		Set("position", sq.Expr("position + ?", len(movieIDs))).
+		Where(sq.Eq{"collection_id": collectionID}).
+		Where(sq.NotEq{"movie_id": movieIDs}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := sq.Update("collection_items c").
+		Set("position", sq.Expr("o.ord")).
+		Suffix("FROM unnest(?::int[]) WITH ORDINALITY AS o(movie_id, ord) WHERE c.collection_id = ? AND c.movie_id = o.movie_id", movieIDs, collectionID).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if updated != int64(len(movieIDs)) {
+		err = storage.ErrCollectionItemNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetPublicCollectionsStorage returns a page of public collections, most recently
+// updated first, and the total number of public collections.
+func (s *Storage) GetPublicCollectionsStorage(limit, offset int) ([]*models.Collection, int64, error) {
+	const op = "storage.postgresql.GetPublicCollectionsStorage"
+
+	var total int64
+	err := sq.Select("COUNT(*)").
+		From("collections").
+		Where(sq.Eq{"public": true}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	collections, err := s.queryCollections(selectCollections().
+		Where(sq.Eq{"c.public": true}).
+		OrderBy("c.updated_at DESC", "c.id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)))
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collections, total, nil
+}
+
+// GetUserCollectionsStorage returns all collections of the owner, public and private.
+func (s *Storage) GetUserCollectionsStorage(ownerID int64) ([]*models.Collection, error) {
+	const op = "storage.postgresql.GetUserCollectionsStorage"
+
+	collections, err := s.queryCollections(selectCollections().
+		Where(sq.Eq{"c.owner_id": ownerID}).
+		OrderBy("c.updated_at DESC", "c.id DESC"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collections, nil
+}
+
+// GetCollectionStorage returns the collection with its items in order. Private
+// collections are only found for their owner, viewerID is 0 for anonymous users.
+func (s *Storage) GetCollectionStorage(slug string, viewerID int64) (*models.Collection, error) {
+	const op = "storage.postgresql.GetCollectionStorage"
+
+	collections, err := s.queryCollections(selectCollections().
+		Where(sq.Eq{"c.slug": slug}).
+		Where(sq.Or{sq.Eq{"c.public": true}, sq.Eq{"c.owner_id": viewerID}}))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(collections) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrCollectionNotFound)
+	}
+	collection := collections[0]
+
+	query, args, err := sq.Select("i.movie_id", "m.title", "m.release_date", "i.position").
+		From("collection_items i").
+		Join("movies m ON m.id = i.movie_id").
+		Where(sq.Eq{"i.collection_id": collection.ID}).
+		Where("m.deleted_at IS NULL").
+		OrderBy("i.position").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	collection.Items = []*models.CollectionItem{}
+	for rows.Next() {
+		item := &models.CollectionItem{}
+		err := rows.Scan(&item.MovieID, &item.Title, &item.ReleaseDate, &item.Position)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		collection.Items = append(collection.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collection, nil
+}
+
+func selectCollections() sq.SelectBuilder {
+	return sq.Select("c.id", "c.owner_id", "c.title", "COALESCE(c.description, '')", "c.slug", "c.public", "c.created_at", "c.updated_at").
+		Column("(SELECT COUNT(*) FROM collection_items i JOIN movies m ON m.id = i.movie_id WHERE i.collection_id = c.id AND m.deleted_at IS NULL)").
+		From("collections c").
+		PlaceholderFormat(sq.Dollar)
+}
+
+func (s *Storage) queryCollections(builder sq.SelectBuilder) ([]*models.Collection, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := []*models.Collection{}
+	for rows.Next() {
+		c := &models.Collection{}
+		err := rows.Scan(&c.ID, &c.OwnerID, &c.Title, &c.Description, &c.Slug, &c.Public, &c.CreatedAt, &c.UpdatedAt, &c.ItemsCount)
+		if err != nil {
+			return nil, err
+		}
+
+		collections = append(collections, c)
+	}
+
+	return collections, rows.Err()
+}
+
+// touchOwnedCollection bumps the collection's updated_at and locks it until the
+// transaction ends. It fails with ErrCollectionNotFound unless ownerID owns the collection.
+func touchOwnedCollection(tx *sql.Tx, ownerID, collectionID int64) error {
+	var id int64
+	err := sq.Update("collections").
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": collectionID, "owner_id": ownerID}).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrCollectionNotFound
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package postgresql
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_Collection(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("collection-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 3, 0)
+	owner, other := seedUser(t, s), seedUser(t, s)
+
+	collection := &models.Collection{OwnerID: owner, Title: prefix, Slug: prefix}
+	if err := s.AddCollectionStorage(collection); err != nil {
+		t.Fatalf("AddCollectionStorage() error = %v", err)
+	}
+
+	if err := s.AddCollectionStorage(&models.Collection{OwnerID: other, Title: prefix, Slug: prefix}); !errors.Is(err, storage.ErrCollectionExists) {
+		t.Errorf("AddCollectionStorage() with taken slug error = %v, want %v", err, storage.ErrCollectionExists)
+	}
+
+	if err := s.AddMoviesToCollectionStorage(other, collection.ID, ids); !errors.Is(err, storage.ErrCollectionNotFound) {
+		t.Errorf("AddMoviesToCollectionStorage() by another user error = %v, want %v", err, storage.ErrCollectionNotFound)
+	}
+	if err := s.AddMoviesToCollectionStorage(owner, collection.ID, ids); err != nil {
+		t.Fatalf("AddMoviesToCollectionStorage() error = %v", err)
+	}
+	if err := s.ReorderCollectionStorage(owner, collection.ID, []int64{ids[2], ids[0]}); err != nil {
+		t.Fatalf("ReorderCollectionStorage() error = %v", err)
+	}
+
+	if _, err := s.GetCollectionStorage(prefix, other); !errors.Is(err, storage.ErrCollectionNotFound) {
+		t.Errorf("GetCollectionStorage() of a private collection error = %v, want %v", err, storage.ErrCollectionNotFound)
+	}
+
+	got, err := s.GetCollectionStorage(prefix, owner)
+	if err != nil {
+		t.Fatalf("GetCollectionStorage() error = %v", err)
+	}
+	want := []int64{ids[2], ids[0], ids[1]}
+	if got.ItemsCount != 3 || len(got.Items) != 3 {
+		t.Fatalf("GetCollectionStorage() items = %v, want movies %v", got.Items, want)
+	}
+	for i, item := range got.Items {
+		if item.MovieID != want[i] {
+			t.Errorf("item %d: got movie %d, want %d", i, item.MovieID, want[i])
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetActorCreditsStorage returns every actor credit of the catalog, leaving out
+// deleted actors and movies.
+func (s *Storage) GetActorCreditsStorage() ([]*models.ActorCredit, error) {
+	const op = "storage.postgresql.GetActorCreditsStorage"
+
+	query, args, err := sq.Select("p.id", "p.name", "m.id", "m.title").
+		From("movie_crew c").
+		Join("people p ON p.id = c.person_id").
+		Join("movies m ON m.id = c.movie_id").
+		Where(sq.Eq{"c.role": models.RoleActor}).
+		Where("p.deleted_at IS NULL").
+		Where("m.deleted_at IS NULL").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	credits := []*models.ActorCredit{}
+	for rows.Next() {
+		credit := &models.ActorCredit{}
+		err := rows.Scan(&credit.PersonID, &credit.PersonName, &credit.MovieID, &credit.MovieTitle)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		credits = append(credits, credit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return credits, nil
+}
@@ -0,0 +1,199 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+func (s *Storage) AddPersonStorage(person *models.Person) error {
+	const op = "storage.postgresql.AddPersonStorage"
+
+	var birthday interface{}
+	if !person.Birthday.IsZero() {
+		birthday = person.Birthday
+	}
+
+	err := sq.Insert("people").
+		Columns("name", "sex", "birthday").
+		Values(person.Name, person.Sex, birthday).
+		Suffix("RETURNING id").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&person.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetFilmographyStorage returns the person with all of their credits, newest movies first.
+func (s *Storage) GetFilmographyStorage(personID int64) (*models.Filmography, error) {
+	const op = "storage.postgresql.GetFilmographyStorage"
+
+	query, args, err := sq.Select("id", "name", "COALESCE(sex, '')", "birthday").
+		From("people").
+		Where(sq.Eq{"id": personID}).
+		Where("deleted_at IS NULL").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	filmography := &models.Filmography{Credits: []*models.Credit{}}
+	var birthday sql.NullTime
+
+	err = s.db.QueryRow(query, args...).Scan(&filmography.ID, &filmography.Name, &filmography.Sex, &birthday)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	filmography.Birthday = birthday.Time
+
+	query, args, err = sq.Select("m.id", "m.title", "m.release_date", "c.role").
+		From("movie_crew c").
+		Join("movies m ON m.id = c.movie_id").
+		Where(sq.Eq{"c.person_id": personID}).
+		Where("m.deleted_at IS NULL").
+		OrderBy("m.release_date DESC", "m.id", "c.role").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		credit := &models.Credit{}
+		if err := rows.Scan(&credit.MovieID, &credit.Title, &credit.ReleaseDate, &credit.Role); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		filmography.Credits = append(filmography.Credits, credit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return filmography, nil
+}
+
+func (s *Storage) GetMovieCrewStorage(movieID int64) ([]*models.CrewMember, error) {
+	const op = "storage.postgresql.GetMovieCrewStorage"
+
+	query, args, err := sq.Select("p.id", "p.name", "c.role").
+		From("movie_crew c").
+		Join("people p ON p.id = c.person_id").
+		Join("movies m ON m.id = c.movie_id").
+		Where(sq.Eq{"c.movie_id": movieID}).
+		Where("p.deleted_at IS NULL AND m.deleted_at IS NULL").
+		OrderBy("c.role", "p.name").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	crew := []*models.CrewMember{}
+	for rows.Next() {
+		member := &models.CrewMember{}
+		if err := rows.Scan(&member.PersonID, &member.Name, &member.Role); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		crew = append(crew, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return crew, nil
+}
+
+func (s *Storage) AddCrewToMovieStorage(movieID int64, crew []models.CrewRole) error {
+	const op = "storage.postgresql.AddCrewToMovieStorage"
+
+	personIDs := make([]int64, 0, len(crew))
+	roles := make([]string, 0, len(crew))
+	for _, member := range crew {
+		personIDs = append(personIDs, member.PersonID)
+		roles = append(roles, member.Role)
+	}
+
+	_, err := sq.Insert("movie_crew").
+		Columns("movie_id", "person_id", "role").
+		Select(sq.Select().Column("?::int", movieID).Column("unnest(?::int[])", personIDs).Column("unnest(?::text[])", roles)).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteCrewFromMovieStorage(movieID, personID int64, role string) error {
+	const op = "storage.postgresql.DeleteCrewFromMovieStorage"
+
+	res, err := sq.Delete("movie_crew").
+		Where(sq.Eq{"movie_id": movieID, "person_id": personID, "role": role}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrCreditNotFound)
+	}
+
+	return nil
+}
+
+// insertCredits links every given movie with every given person in the role,
+// skipping links that already exist.
+func insertCredits(movieIDs, personIDs []int64, role string) sq.InsertBuilder {
+	return sq.Insert("movie_crew").
+		Columns("movie_id", "person_id", "role").
+		Select(sq.Select("m.movie_id", "p.person_id").
+			Column("?::text", role).
+			FromSelect(sq.Select().Column("unnest(?::int[]) AS movie_id", movieIDs), "m").
+			CrossJoin("unnest(?::int[]) AS p(person_id)", personIDs)).
+		Suffix("ON CONFLICT DO NOTHING")
+}
+
+func toInt64s(ids []int) []int64 {
+	res := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, int64(id))
+	}
+
+	return res
+}
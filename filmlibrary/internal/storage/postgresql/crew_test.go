@@ -0,0 +1,69 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_CrewAndFilmography(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("crew-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 2, 1)
+
+	person := &models.Person{Name: prefix + " director"}
+	if err := s.AddPersonStorage(person); err != nil {
+		t.Fatalf("AddPersonStorage() error = %v", err)
+	}
+	t.Cleanup(func() {
+		s.db.Exec("DELETE FROM movie_crew WHERE person_id = $1", person.ID)
+		s.db.Exec("DELETE FROM people WHERE id = $1", person.ID)
+	})
+
+	err := s.AddCrewToMovieStorage(ids[0], []models.CrewRole{
+		{PersonID: person.ID, Role: models.RoleDirector},
+		{PersonID: person.ID, Role: models.RoleWriter},
+	})
+	if err != nil {
+		t.Fatalf("AddCrewToMovieStorage() error = %v", err)
+	}
+	if err := s.AddMoviesToActorStorage(person.ID, []int64{ids[1]}); err != nil {
+		t.Fatalf("AddMoviesToActorStorage() error = %v", err)
+	}
+
+	crew, err := s.GetMovieCrewStorage(ids[0])
+	if err != nil {
+		t.Fatalf("GetMovieCrewStorage() error = %v", err)
+	}
+	if len(crew) != 3 {
+		t.Errorf("GetMovieCrewStorage() returned %d members, want 3", len(crew))
+	}
+
+	filmography, err := s.GetFilmographyStorage(person.ID)
+	if err != nil {
+		t.Fatalf("GetFilmographyStorage() error = %v", err)
+	}
+	roles := map[string]bool{}
+	for _, credit := range filmography.Credits {
+		roles[credit.Role] = true
+	}
+	for _, role := range []string{models.RoleActor, models.RoleDirector, models.RoleWriter} {
+		if !roles[role] {
+			t.Errorf("filmography has no %s credit", role)
+		}
+	}
+
+	movie, err := s.GetMovieStorageByID(ids[1])
+	if err != nil {
+		t.Fatalf("GetMovieStorageByID() error = %v", err)
+	}
+	if len(movie.Actors) != 2 {
+		t.Errorf("movie cast = %v, want the seeded actor and %s", movie.Actors, person.Name)
+	}
+
+	if err := s.DeleteCrewFromMovieStorage(ids[0], person.ID, models.RoleWriter); err != nil {
+		t.Fatalf("DeleteCrewFromMovieStorage() error = %v", err)
+	}
+}
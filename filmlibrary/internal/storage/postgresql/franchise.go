@@ -0,0 +1,504 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// sequelEdges lists every sequel and prequel relation of the relations query as a pair
+// of movies where before_id comes before after_id in the story.
+const sequelEdges = `SELECT movie_id AS before_id, related_movie_id AS after_id FROM relations WHERE relation_type = 'sequel'
+	UNION SELECT related_movie_id, movie_id FROM relations WHERE relation_type = 'prequel'`
+
+func (s *Storage) AddFranchiseStorage(franchise *models.Franchise) error {
+	const op = "storage.postgresql.AddFranchiseStorage"
+
+	err := sq.Insert("franchises").
+		Columns("name", "description").
+		Values(franchise.Name, franchise.Description).
+		Suffix("RETURNING id").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&franchise.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrFranchiseExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) EditFranchiseStorage(franchise *models.Franchise) error {
+	const op = "storage.postgresql.EditFranchiseStorage"
+
+	res, err := sq.Update("franchises").
+		Set("name", franchise.Name).
+		Set("description", franchise.Description).
+		Where(sq.Eq{"id": franchise.ID}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrFranchiseExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrFranchiseNotFound)
+	}
+
+	return nil
+}
+
+// DeleteFranchiseStorage deletes the franchise, its movies are kept.
+func (s *Storage) DeleteFranchiseStorage(id int64) error {
+	const op = "storage.postgresql.DeleteFranchiseStorage"
+
+	res, err := sq.Delete("franchises").
+		Where(sq.Eq{"id": id}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrFranchiseNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetFranchisesStorage() ([]*models.Franchise, error) {
+	const op = "storage.postgresql.GetFranchisesStorage"
+
+	query, args, err := sq.Select("f.id", "f.name", "f.description").
+		Column("(SELECT COUNT(*) FROM franchise_movies fm JOIN movies m ON m.id = fm.movie_id WHERE fm.franchise_id = f.id AND m.deleted_at IS NULL)").
+		From("franchises f").
+		OrderBy("f.name").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	franchises := []*models.Franchise{}
+	for rows.Next() {
+		franchise := &models.Franchise{}
+		err := rows.Scan(&franchise.ID, &franchise.Name, &franchise.Description, &franchise.MoviesCount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		franchises = append(franchises, franchise)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return franchises, nil
+}
+
+// GetFranchiseStorage returns the franchise with its movies in order.
+func (s *Storage) GetFranchiseStorage(id int64) (*models.Franchise, error) {
+	const op = "storage.postgresql.GetFranchiseStorage"
+
+	franchise := &models.Franchise{Movies: []*models.FranchiseMovie{}}
+	err := sq.Select("id", "name", "description").
+		From("franchises").
+		Where(sq.Eq{"id": id}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&franchise.ID, &franchise.Name, &franchise.Description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrFranchiseNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err := sq.Select("fm.movie_id", "m.title", "m.release_date", "fm.position").
+		From("franchise_movies fm").
+		Join("movies m ON m.id = fm.movie_id").
+		Where(sq.Eq{"fm.franchise_id": id}).
+		Where("m.deleted_at IS NULL").
+		OrderBy("fm.position").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movie := &models.FranchiseMovie{}
+		err := rows.Scan(&movie.MovieID, &movie.Title, &movie.ReleaseDate, &movie.Position)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		franchise.Movies = append(franchise.Movies, movie)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	franchise.MoviesCount = int64(len(franchise.Movies))
+
+	return franchise, nil
+}
+
+// SetFranchiseMoviesStorage replaces the movies of the franchise with the given ones
+// in the given order. A movie belongs to one franchise at most.
+func (s *Storage) SetFranchiseMoviesStorage(franchiseID int64, movieIDs []int64) error {
+	const op = "storage.postgresql.SetFranchiseMoviesStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var id int64
+	err = sq.Select("id").
+		From("franchises").
+		Where(sq.Eq{"id": franchiseID}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = storage.ErrFranchiseNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var found int
+	err = sq.Select("COUNT(*)").
+		From("movies").
+		Where(sq.Eq{"id": movieIDs}).
+		Where("deleted_at IS NULL").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&found)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if found != len(movieIDs) {
+		err = storage.ErrMovieNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = sq.Delete("franchise_movies").
+		Where(sq.Eq{"franchise_id": franchiseID}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(movieIDs) == 0 {
+		return nil
+	}
+
+	_, err = sq.Insert("franchise_movies").
+		Columns("franchise_id", "movie_id", "position").
+		Select(sq.Select("f.id", "u.movie_id", "u.ord").
+			From("franchises f").
+			CrossJoin("unnest(?::int[]) WITH ORDINALITY AS u(movie_id, ord)", movieIDs).
+			Where(sq.Eq{"f.id": franchiseID})).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		if isUniqueViolation(err) {
+			err = storage.ErrMovieInOtherFranchise
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpsertMovieRelationStorage relates two existing movies, a pair of movies has one
+// relation so an existing one changes its type. Before the relation is saved, check
+// gets a lookup of the movies following a movie through a chain of sequels and
+// prequels, leaving out the relation being replaced. No other relation is written
+// between the check and the save.
+func (s *Storage) UpsertMovieRelationStorage(relation *models.MovieRelation, check func(later func(movieID int64) ([]int64, error)) error) error {
+	const op = "storage.postgresql.UpsertMovieRelationStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// the mode conflicts with itself and with every write, reads go on as usual
+	_, err = tx.Exec("LOCK TABLE movie_relations IN SHARE ROW EXCLUSIVE MODE")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = check(func(movieID int64) ([]int64, error) {
+		return laterMovies(tx, movieID, relation)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := sq.Insert("movie_relations").
+		Columns("movie_id", "related_movie_id", "relation_type").
+		Select(sq.Select("m.id", "r.id").
+			Column("?::text", relation.Type).
+			From("movies m").
+			Join("movies r ON r.id = ? AND r.deleted_at IS NULL", relation.RelatedMovieID).
+			Where(sq.Eq{"m.id": relation.MovieID}).
+			Where("m.deleted_at IS NULL")).
+		Suffix("ON CONFLICT (movie_id, related_movie_id) DO UPDATE SET relation_type = EXCLUDED.relation_type").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		err = storage.ErrMovieNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteMovieRelationStorage(movieID, relatedMovieID int64) error {
+	const op = "storage.postgresql.DeleteMovieRelationStorage"
+
+	res, err := sq.Delete("movie_relations").
+		Where(sq.Eq{"movie_id": movieID, "related_movie_id": relatedMovieID}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrRelationNotFound)
+	}
+
+	return nil
+}
+
+// laterMovies returns the IDs of every movie that follows the movie through a chain
+// of sequels and prequels. The relation the pair of movies in replaced has now is
+// left out, as it is about to change.
+func laterMovies(tx *sql.Tx, movieID int64, replaced *models.MovieRelation) ([]int64, error) {
+	rows, err := tx.Query(`WITH RECURSIVE relations AS (
+		SELECT * FROM movie_relations WHERE (movie_id, related_movie_id) <> ($2, $3)
+	),
+	edges AS (`+sequelEdges+`),
+	later (id) AS (
+		SELECT after_id FROM edges WHERE before_id = $1
+		UNION SELECT e.after_id FROM edges e JOIN later l ON e.before_id = l.id
+	)
+	SELECT id FROM later`, movieID, replaced.MovieID, replaced.RelatedMovieID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GetMovieDetailStorage returns the movie listing together with its place in the
+// franchise and the related movies, translated like selectMovieListings.
+func (s *Storage) GetMovieDetailStorage(id int64, langs []string) (*models.MovieDetail, error) {
+	const op = "storage.postgresql.GetMovieDetailStorage"
+
+	query, args, err := selectMovieListings(langs).
+		Where(sq.Eq{"m.id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	movies, err := s.queryMovieListings(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(movies) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrMovieNotFound)
+	}
+
+	detail := &models.MovieDetail{MovieListing: movies[0]}
+
+	detail.Franchise, err = s.getMovieFranchise(id, langs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	detail.Related, err = s.getRelatedMovies(id, langs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return detail, nil
+}
+
+// getMovieFranchise returns the franchise of the movie with the movies right before
+// and after it, or nil when the movie is not in a franchise.
+func (s *Storage) getMovieFranchise(movieID int64, langs []string) (*models.MovieFranchise, error) {
+	neighbour := func(compare, order string) sq.SelectBuilder {
+		return sq.Select("n.id").
+			Column(translatedTitle("n"), langs, langs).
+			From("franchise_movies o").
+			Join("movies n ON n.id = o.movie_id AND n.deleted_at IS NULL").
+			Where("o.franchise_id = fm.franchise_id AND o.position " + compare + " fm.position").
+			OrderBy("o.position " + order).
+			Limit(1)
+	}
+
+	query, args, err := sq.Select("f.id", "f.name", "fm.position", "prev.id", "prev.title", "next.id", "next.title").
+		From("franchise_movies fm").
+		Join("franchises f ON f.id = fm.franchise_id").
+		JoinClause(neighbour("<", "DESC").Prefix("LEFT JOIN LATERAL (").Suffix(") prev (id, title) ON true")).
+		JoinClause(neighbour(">", "ASC").Prefix("LEFT JOIN LATERAL (").Suffix(") next (id, title) ON true")).
+		Where(sq.Eq{"fm.movie_id": movieID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	franchise := &models.MovieFranchise{}
+	var prevID, nextID sql.NullInt64
+	var prevTitle, nextTitle sql.NullString
+	err = s.db.QueryRow(query, args...).Scan(&franchise.ID, &franchise.Name, &franchise.Position, &prevID, &prevTitle, &nextID, &nextTitle)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if prevID.Valid {
+		franchise.Previous = &models.MovieRef{ID: prevID.Int64, Title: prevTitle.String}
+	}
+	if nextID.Valid {
+		franchise.Next = &models.MovieRef{ID: nextID.Int64, Title: nextTitle.String}
+	}
+
+	return franchise, nil
+}
+
+// getRelatedMovies returns the movies related to the movie in either direction. For
+// the relations set on the other movie the type is turned around, so the sequel of
+// a movie lists it as its prequel and a remake lists it as the original.
+func (s *Storage) getRelatedMovies(movieID int64, langs []string) ([]*models.RelatedMovie, error) {
+	outgoing := sq.Select("r.relation_type", "m.id").
+		Column(translatedTitle("m"), langs, langs).
+		From("movie_relations r").
+		Join("movies m ON m.id = r.related_movie_id AND m.deleted_at IS NULL").
+		Where(sq.Eq{"r.movie_id": movieID})
+
+	incoming := sq.Select().
+		Column(sq.Expr("CASE r.relation_type WHEN ? THEN ?::text WHEN ? THEN ?::text ELSE ?::text END",
+			models.RelationSequel, models.RelationPrequel,
+			models.RelationPrequel, models.RelationSequel,
+			models.RelationOriginal)).
+		Column("m.id").
+		Column(translatedTitle("m"), langs, langs).
+		From("movie_relations r").
+		Join("movies m ON m.id = r.movie_id AND m.deleted_at IS NULL").
+		Where(sq.Eq{"r.related_movie_id": movieID})
+
+	query, args, err := outgoing.
+		SuffixExpr(incoming.Prefix("UNION")).
+		Suffix("ORDER BY 1, 2").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	related := []*models.RelatedMovie{}
+	for rows.Next() {
+		movie := &models.RelatedMovie{Movie: &models.MovieRef{}}
+		if err := rows.Scan(&movie.Type, &movie.Movie.ID, &movie.Movie.Title); err != nil {
+			return nil, err
+		}
+		related = append(related, movie)
+	}
+
+	return related, rows.Err()
+}
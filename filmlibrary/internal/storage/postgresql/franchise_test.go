@@ -0,0 +1,108 @@
+package postgresql
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_Franchise(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("franchise-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 4, 0)
+
+	franchise := &models.Franchise{Name: prefix}
+	if err := s.AddFranchiseStorage(franchise); err != nil {
+		t.Fatalf("AddFranchiseStorage() error = %v", err)
+	}
+	if err := s.AddFranchiseStorage(&models.Franchise{Name: prefix}); !errors.Is(err, storage.ErrFranchiseExists) {
+		t.Errorf("AddFranchiseStorage() duplicate error = %v, want %v", err, storage.ErrFranchiseExists)
+	}
+
+	if err := s.SetFranchiseMoviesStorage(franchise.ID, []int64{ids[2], ids[0], ids[1]}); err != nil {
+		t.Fatalf("SetFranchiseMoviesStorage() error = %v", err)
+	}
+
+	other := &models.Franchise{Name: prefix + " other"}
+	if err := s.AddFranchiseStorage(other); err != nil {
+		t.Fatalf("AddFranchiseStorage() error = %v", err)
+	}
+	if err := s.SetFranchiseMoviesStorage(other.ID, []int64{ids[0]}); !errors.Is(err, storage.ErrMovieInOtherFranchise) {
+		t.Errorf("SetFranchiseMoviesStorage() error = %v, want %v", err, storage.ErrMovieInOtherFranchise)
+	}
+
+	got, err := s.GetFranchiseStorage(franchise.ID)
+	if err != nil {
+		t.Fatalf("GetFranchiseStorage() error = %v", err)
+	}
+	var order []int64
+	for _, movie := range got.Movies {
+		order = append(order, movie.MovieID)
+	}
+	if want := []int64{ids[2], ids[0], ids[1]}; fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("franchise movies = %v, want %v", order, want)
+	}
+
+	for _, relation := range []*models.MovieRelation{
+		{MovieID: ids[2], RelatedMovieID: ids[0], Type: models.RelationSequel},
+		{MovieID: ids[1], RelatedMovieID: ids[0], Type: models.RelationPrequel},
+		{MovieID: ids[3], RelatedMovieID: ids[0], Type: models.RelationRemake},
+	} {
+		if err := s.UpsertMovieRelationStorage(relation, noCheck); err != nil {
+			t.Fatalf("UpsertMovieRelationStorage() error = %v", err)
+		}
+	}
+
+	var later, withoutPair []int64
+	err = s.UpsertMovieRelationStorage(&models.MovieRelation{MovieID: ids[2], RelatedMovieID: ids[0], Type: models.RelationSequel},
+		func(lookup func(movieID int64) ([]int64, error)) error {
+			later, err = lookup(ids[0])
+			if err != nil {
+				return err
+			}
+			withoutPair, err = lookup(ids[2])
+			return err
+		})
+	if err != nil {
+		t.Fatalf("UpsertMovieRelationStorage() error = %v", err)
+	}
+	if want := []int64{ids[1]}; fmt.Sprint(later) != fmt.Sprint(want) {
+		t.Errorf("later movies of %d = %v, want %v", ids[0], later, want)
+	}
+	// the sequel from ids[2] to ids[0] is the relation being replaced
+	if len(withoutPair) != 0 {
+		t.Errorf("later movies of %d = %v, want none", ids[2], withoutPair)
+	}
+
+	rejected := errors.New("rejected")
+	err = s.UpsertMovieRelationStorage(&models.MovieRelation{MovieID: ids[2], RelatedMovieID: ids[0], Type: models.RelationRemake},
+		func(func(movieID int64) ([]int64, error)) error { return rejected })
+	if !errors.Is(err, rejected) {
+		t.Errorf("UpsertMovieRelationStorage() error = %v, want %v", err, rejected)
+	}
+
+	detail, err := s.GetMovieDetailStorage(ids[0], nil)
+	if err != nil {
+		t.Fatalf("GetMovieDetailStorage() error = %v", err)
+	}
+	if detail.Franchise == nil || detail.Franchise.Previous.ID != ids[2] || detail.Franchise.Next.ID != ids[1] {
+		t.Errorf("franchise = %+v, want between %d and %d", detail.Franchise, ids[2], ids[1])
+	}
+
+	types := map[int64]string{}
+	for _, related := range detail.Related {
+		types[related.Movie.ID] = related.Type
+	}
+	want := map[int64]string{ids[2]: models.RelationPrequel, ids[1]: models.RelationSequel, ids[3]: models.RelationOriginal}
+	if fmt.Sprint(types) != fmt.Sprint(want) {
+		t.Errorf("related = %v, want %v", types, want)
+	}
+}
+
+func noCheck(func(movieID int64) ([]int64, error)) error {
+	return nil
+}
@@ -0,0 +1,220 @@
+package postgresql
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const uniqueViolationCode = "23505"
+
+func (s *Storage) AddGenreStorage(genre *models.Genre) error {
+	const op = "storage.postgresql.AddGenreStorage"
+
+	err := sq.Insert("genres").
+		Columns("name").
+		Values(genre.Name).
+		Suffix("RETURNING id").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&genre.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrGenreExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) EditGenreStorage(genre *models.Genre) error {
+	const op = "storage.postgresql.EditGenreStorage"
+
+	query, args, err := sq.Update("genres").
+		Set("name", genre.Name).
+		Where(sq.Eq{"id": genre.ID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := s.db.Exec(query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrGenreExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrGenreNotFound)
+	}
+
+	return nil
+}
+
+// DeleteGenreStorage removes the genre and unlinks it from every movie.
+func (s *Storage) DeleteGenreStorage(id int64) error {
+	const op = "storage.postgresql.DeleteGenreStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	moviesUpdate := sq.Update("movies").
+		Set("genres_id", sq.Expr("array_remove(genres_id, ?)", id)).
+		Where(sq.Expr("? = ANY(genres_id)", id))
+
+	_, err = moviesUpdate.RunWith(tx).PlaceholderFormat(sq.Dollar).Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := sq.Delete("genres").
+		Where(sq.Eq{"id": id}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		err = storage.ErrGenreNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetGenresStorage returns every genre with the number of listed movies in it.
+func (s *Storage) GetGenresStorage() ([]*models.GenreListing, error) {
+	const op = "storage.postgresql.GetGenresStorage"
+
+	query, args, err := sq.
+		Select("g.id, g.name, COUNT(m.id) AS movies_count").
+		From("genres g").
+		LeftJoin("movies m ON g.id = ANY(m.genres_id) AND m.deleted_at IS NULL").
+		GroupBy("g.id, g.name").
+		OrderBy("g.name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	genres, err := s.queryGenreListings(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return genres, nil
+}
+
+// GetGenreFacetsStorage returns every genre with the number of movies in it among the
+// movies the listing keeps with the filter.
+func (s *Storage) GetGenreFacetsStorage(filter models.MovieFilter) ([]*models.GenreListing, error) {
+	const op = "storage.postgresql.GetGenreFacetsStorage"
+
+	listed, listedArgs, err := filterMovies(sq.Select("m.genres_id").From("movies m").Where("m.deleted_at IS NULL"), filter).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err := sq.
+		Select("g.id, g.name, COUNT(f.genres_id) AS movies_count").
+		From("genres g").
+		LeftJoin("("+listed+") f ON g.id = ANY(f.genres_id)", listedArgs...).
+		GroupBy("g.id, g.name").
+		OrderBy("g.name").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	genres, err := s.queryGenreListings(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return genres, nil
+}
+
+func (s *Storage) queryGenreListings(query string, args ...interface{}) ([]*models.GenreListing, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var genres []*models.GenreListing
+	for rows.Next() {
+		genre := &models.GenreListing{}
+		if err := rows.Scan(&genre.ID, &genre.Name, &genre.MoviesCount); err != nil {
+			return nil, err
+		}
+
+		genres = append(genres, genre)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+func (s *Storage) AddGenresToMovieStorage(movieID int64, genres []int64) error {
+	const op = "storage.postgresql.AddGenresToMovieStorage"
+
+	query, args, err := sq.Update("movies").
+		Set("genres_id", sq.Expr("ARRAY(SELECT DISTINCT unnest(array_cat(genres_id, ?)))", genres)).
+		Where(sq.Eq{"id": movieID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := s.db.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrMovieNotFound)
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,91 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_GenreFilterAndFacets(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("genre-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 2, 1)
+
+	genre := &models.Genre{Name: prefix}
+	if err := s.AddGenreStorage(genre); err != nil {
+		t.Fatalf("AddGenreStorage() error = %v", err)
+	}
+	t.Cleanup(func() { s.DeleteGenreStorage(genre.ID) })
+
+	if err := s.AddGenresToMovieStorage(ids[0], []int64{genre.ID, genre.ID}); err != nil {
+		t.Fatalf("AddGenresToMovieStorage() error = %v", err)
+	}
+
+	movies, err := s.GetMoviesSortedStorage("title", "ASC", models.MovieFilter{Genre: prefix}, nil)
+	if err != nil {
+		t.Fatalf("GetMoviesSortedStorage() error = %v", err)
+	}
+	if len(movies) != 1 || movies[0].ID != ids[0] {
+		t.Fatalf("GetMoviesSortedStorage() = %v, want only movie %d", movies, ids[0])
+	}
+	if len(movies[0].Genres) != 1 || movies[0].Genres[0] != prefix {
+		t.Errorf("movie genres = %v, want [%s]", movies[0].Genres, prefix)
+	}
+
+	genres, err := s.GetGenresStorage()
+	if err != nil {
+		t.Fatalf("GetGenresStorage() error = %v", err)
+	}
+	for _, g := range genres {
+		if g.ID == genre.ID && g.MoviesCount != 1 {
+			t.Errorf("genre movies count = %d, want 1", g.MoviesCount)
+		}
+	}
+}
+
+func TestStorage_GetGenreFacets(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("facet-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 2, 0)
+
+	genre := &models.Genre{Name: prefix}
+	if err := s.AddGenreStorage(genre); err != nil {
+		t.Fatalf("AddGenreStorage() error = %v", err)
+	}
+	t.Cleanup(func() { s.DeleteGenreStorage(genre.ID) })
+
+	for _, id := range ids {
+		if err := s.AddGenresToMovieStorage(id, []int64{genre.ID}); err != nil {
+			t.Fatalf("AddGenresToMovieStorage() error = %v", err)
+		}
+	}
+	if _, err := s.db.Exec("UPDATE movies SET runtime = 120 WHERE id = $1", ids[0]); err != nil {
+		t.Fatalf("failed to set the runtime: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter models.MovieFilter
+		want   int64
+	}{
+		{name: "Unfiltered", filter: models.MovieFilter{GrossCurrency: "USD"}, want: 2},
+		{name: "Runtime", filter: models.MovieFilter{MinRuntime: 100, GrossCurrency: "USD"}, want: 1},
+		{name: "Nothing listed", filter: models.MovieFilter{MaxRuntime: 60, GrossCurrency: "USD"}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genres, err := s.GetGenreFacetsStorage(tt.filter)
+			if err != nil {
+				t.Fatalf("GetGenreFacetsStorage() error = %v", err)
+			}
+			for _, g := range genres {
+				if g.ID == genre.ID && g.MoviesCount != tt.want {
+					t.Errorf("genre movies count = %d, want %d", g.MoviesCount, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// imageOwners maps image owner types to the image column and table of the owner.
+var imageOwners = map[string]struct {
+	column   string
+	table    string
+	notFound error
+}{
+	models.ImageOwnerMovie: {column: "movie_id", table: "movies", notFound: storage.ErrMovieNotFound},
+	models.ImageOwnerActor: {column: "person_id", table: "people", notFound: storage.ErrPersonNotFound},
+}
+
+// AddImageStorage saves the metadata of an image attached to an existing movie or actor.
+func (s *Storage) AddImageStorage(image *models.Image) error {
+	const op = "storage.postgresql.AddImageStorage"
+
+	owner, ok := imageOwners[image.OwnerType]
+	if !ok {
+		return fmt.Errorf("%s: unknown image owner %q", op, image.OwnerType)
+	}
+
+	err := sq.Insert("images").
+		Columns(owner.column, "content_type", "size", "width", "height").
+		Select(sq.Select("o.id").
+			Column("?::text", image.ContentType).
+			Column("?::int", image.Size).
+			Column("?::int", image.Width).
+			Column("?::int", image.Height).
+			From(owner.table+" o").
+			Where(sq.Eq{"o.id": image.OwnerID}).
+			Where("o.deleted_at IS NULL")).
+		Suffix("RETURNING id, created_at").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&image.ID, &image.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, owner.notFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetImageStorage(id int64) (*models.Image, error) {
+	const op = "storage.postgresql.GetImageStorage"
+
+	images, err := s.queryImages(selectImages().Where(sq.Eq{"id": id}))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(images) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrImageNotFound)
+	}
+
+	return images[0], nil
+}
+
+// GetImagesStorage returns the images of a movie or an actor, oldest first.
+func (s *Storage) GetImagesStorage(ownerType string, ownerID int64) ([]*models.Image, error) {
+	const op = "storage.postgresql.GetImagesStorage"
+
+	owner, ok := imageOwners[ownerType]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown image owner %q", op, ownerType)
+	}
+
+	images, err := s.queryImages(selectImages().Where(sq.Eq{owner.column: ownerID}).OrderBy("created_at", "id"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return images, nil
+}
+
+func (s *Storage) DeleteImageStorage(id int64) error {
+	const op = "storage.postgresql.DeleteImageStorage"
+
+	res, err := sq.Delete("images").
+		Where(sq.Eq{"id": id}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrImageNotFound)
+	}
+
+	return nil
+}
+
+func selectImages() sq.SelectBuilder {
+	return sq.Select("id", "movie_id", "person_id", "content_type", "size", "width", "height", "created_at").
+		From("images").
+		PlaceholderFormat(sq.Dollar)
+}
+
+func (s *Storage) queryImages(builder sq.SelectBuilder) ([]*models.Image, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images := []*models.Image{}
+	for rows.Next() {
+		image := &models.Image{}
+		var movieID, personID sql.NullInt64
+		err := rows.Scan(&image.ID, &movieID, &personID, &image.ContentType, &image.Size, &image.Width, &image.Height, &image.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if movieID.Valid {
+			image.OwnerType, image.OwnerID = models.ImageOwnerMovie, movieID.Int64
+		} else {
+			image.OwnerType, image.OwnerID = models.ImageOwnerActor, personID.Int64
+		}
+
+		images = append(images, image)
+	}
+
+	return images, rows.Err()
+}
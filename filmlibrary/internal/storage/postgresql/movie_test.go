@@ -0,0 +1,184 @@
+package postgresql
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestStorage_MovieFacts(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("facts-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 3, 0)
+	imdb := fmt.Sprintf("tt%08d", time.Now().UnixNano()%100000000)
+
+	for i, patch := range []models.MoviePatch{
+		{
+			Runtime:          models.SetTo(142),
+			Budget:           models.SetTo(models.Money{Amount: 25000000, Currency: "USD"}),
+			Gross:            models.SetTo(models.Money{Amount: 73300000, Currency: "USD"}),
+			OriginalLanguage: models.SetTo("en"),
+			SpokenLanguages:  models.SetTo([]string{"en", "fr"}),
+			Countries:        models.SetTo([]string{"US"}),
+			ExternalIDs:      models.SetTo(models.ExternalIDsPatch{IMDb: models.SetTo(imdb)}),
+		},
+		{Runtime: models.SetTo(95), Gross: models.SetTo(models.Money{Amount: 1000000, Currency: "USD"})},
+		{Runtime: models.SetTo(120), Gross: models.SetTo(models.Money{Amount: 90000000, Currency: "EUR"})},
+	} {
+		if _, err := s.EditMovieStorage(ids[i], 1, &patch); err != nil {
+			t.Fatalf("EditMovieStorage() error = %v", err)
+		}
+	}
+
+	_, err := s.EditMovieStorage(ids[1], 2, &models.MoviePatch{ExternalIDs: models.SetTo(models.ExternalIDsPatch{IMDb: models.SetTo(imdb)})})
+	if !errors.Is(err, storage.ErrExternalIDExists) {
+		t.Errorf("EditMovieStorage() error = %v, want %v", err, storage.ErrExternalIDExists)
+	}
+
+	movies, err := s.GetMoviesSortedStorage("title", "ASC", models.MovieFilter{MinRuntime: 140, GrossCurrency: "USD"}, nil)
+	if err != nil {
+		t.Fatalf("GetMoviesSortedStorage() error = %v", err)
+	}
+	var first *models.MovieListing
+	for _, movie := range movies {
+		if movie.ID == ids[0] {
+			first = movie
+		}
+	}
+	if first == nil {
+		t.Fatalf("GetMoviesSortedStorage() did not return movie %d", ids[0])
+	}
+	if first.Runtime != 142 || *first.Budget != (models.Money{Amount: 25000000, Currency: "USD"}) ||
+		!slices.Equal(first.SpokenLanguages, []string{"en", "fr"}) || !slices.Equal(first.Countries, []string{"US"}) ||
+		first.ExternalIDs == nil || first.ExternalIDs.IMDb != imdb {
+		t.Errorf("GetMoviesSortedStorage() facts = %+v", first.MovieFacts)
+	}
+
+	tests := []struct {
+		name   string
+		sortBy string
+		filter models.MovieFilter
+		want   []int64
+	}{
+		{name: "Runtime range", sortBy: "title", filter: models.MovieFilter{MinRuntime: 100, MaxRuntime: 130, GrossCurrency: "USD"}, want: ids[2:]},
+		{name: "Gross in dollars", sortBy: "title", filter: models.MovieFilter{MinGross: 5000000, GrossCurrency: "USD"}, want: ids[:1]},
+		{name: "Gross in euros", sortBy: "title", filter: models.MovieFilter{MinGross: 5000000, GrossCurrency: "EUR"}, want: ids[2:]},
+		{name: "Sorted by gross in dollars", sortBy: "gross", filter: models.MovieFilter{GrossCurrency: "USD"}, want: []int64{ids[0], ids[1], ids[2]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movies, err := s.GetMoviesSortedStorage(tt.sortBy, "DESC", tt.filter, nil)
+			if err != nil {
+				t.Fatalf("GetMoviesSortedStorage() error = %v", err)
+			}
+
+			var got []int64
+			for _, movie := range movies {
+				if slices.Contains(ids, movie.ID) {
+					got = append(got, movie.ID)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("GetMoviesSortedStorage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorage_EditMovie_MergePatch(t *testing.T) {
+	s := newTestStorage(t)
+
+	ids := seedMovies(t, s, fmt.Sprintf("patch-%d", time.Now().UnixNano()), 1, 0)
+
+	version, err := s.EditMovieStorage(ids[0], 1, &models.MoviePatch{
+		Description: models.SetTo("Before"),
+		Rating:      models.SetTo(7.25),
+		Budget:      models.SetTo(models.Money{Amount: 1000, Currency: "USD"}),
+	})
+	if err != nil {
+		t.Fatalf("EditMovieStorage() error = %v", err)
+	}
+
+	version, err = s.EditMovieStorage(ids[0], version, &models.MoviePatch{Description: models.SetNull[string](), Rating: models.SetNull[float64]()})
+	if err != nil {
+		t.Fatalf("EditMovieStorage() error = %v", err)
+	}
+
+	got, err := s.GetMovieStorageByID(ids[0])
+	if err != nil {
+		t.Fatalf("GetMovieStorageByID() error = %v", err)
+	}
+	if got.Description != "" || got.Rating != nil {
+		t.Errorf("GetMovieStorageByID() = %+v, want description and rating cleared", got)
+	}
+	if got.Budget == nil || *got.Budget != (models.Money{Amount: 1000, Currency: "USD"}) {
+		t.Errorf("Budget = %+v, want it left unchanged", got.Budget)
+	}
+
+	if got.Version != version {
+		t.Errorf("Version = %d, want %d", got.Version, version)
+	}
+
+	if version, err = s.EditMovieStorage(ids[0], version, &models.MoviePatch{}); err != nil || version != got.Version+1 {
+		t.Errorf("EditMovieStorage() empty patch = %d, %v, want version %d", version, err, got.Version+1)
+	}
+	if _, err := s.EditMovieStorage(ids[0], got.Version, &models.MoviePatch{Title: models.SetTo("stale")}); !errors.Is(err, storage.ErrVersionMismatch) {
+		t.Errorf("EditMovieStorage() stale error = %v, want %v", err, storage.ErrVersionMismatch)
+	}
+	if err := s.DeleteMovieStorage(ids[0], version); err != nil {
+		t.Fatalf("DeleteMovieStorage() error = %v", err)
+	}
+	if _, err := s.EditMovieStorage(-1, 1, &models.MoviePatch{}); !errors.Is(err, storage.ErrMovieNotFound) {
+		t.Errorf("EditMovieStorage() error = %v, want %v", err, storage.ErrMovieNotFound)
+	}
+}
+
+func TestStorage_BatchMovies(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("batch-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 2, 0)
+	items := []*models.MovieBatchItem{
+		{Op: models.BatchCreate, Movie: &models.Movie{Title: prefix + " new", ReleaseDate: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{Op: models.BatchUpdate, ID: ids[0], Version: 1, Patch: &models.MoviePatch{Runtime: models.SetTo(100)}},
+		{Op: models.BatchDelete, ID: ids[1], Version: 5},
+	}
+	t.Cleanup(func() {
+		s.db.Exec("DELETE FROM movies WHERE title = $1", prefix+" new")
+	})
+
+	results, err := s.BatchMoviesStorage(items, true)
+	if err != nil {
+		t.Fatalf("BatchMoviesStorage() atomic error = %v", err)
+	}
+	for i, wantErr := range []error{storage.ErrBatchRolledBack, storage.ErrBatchRolledBack, storage.ErrVersionMismatch} {
+		if !errors.Is(results[i].Err, wantErr) {
+			t.Errorf("BatchMoviesStorage() atomic item %d error = %v, want %v", i, results[i].Err, wantErr)
+		}
+	}
+	if got, _ := s.GetMovieStorageByID(ids[0]); got.Version != 1 {
+		t.Errorf("Version = %d, want the update rolled back", got.Version)
+	}
+
+	results, err = s.BatchMoviesStorage(items, false)
+	if err != nil {
+		t.Fatalf("BatchMoviesStorage() partial error = %v", err)
+	}
+	if results[0].Err != nil || results[0].ID == 0 || results[0].Version != 1 {
+		t.Errorf("BatchMoviesStorage() create = %+v, want the new movie", results[0])
+	}
+	if results[1].Err != nil || results[1].Version != 2 {
+		t.Errorf("BatchMoviesStorage() update = %+v, want version 2", results[1])
+	}
+	if !errors.Is(results[2].Err, storage.ErrVersionMismatch) {
+		t.Errorf("BatchMoviesStorage() delete error = %v, want %v", results[2].Err, storage.ErrVersionMismatch)
+	}
+	if got, _ := s.GetMovieStorageByID(ids[1]); got.Version != 1 {
+		t.Errorf("Version = %d, want the failed delete undone", got.Version)
+	}
+}
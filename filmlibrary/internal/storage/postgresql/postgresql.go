@@ -17,6 +17,13 @@ type Storage struct {
 	db *sql.DB
 }
 
+// runner runs queries on the database or in a transaction.
+type runner interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
 func New(dataSourceName string) (*Storage, error) {
 	const op = "storage.postgresql.New"
 
@@ -58,22 +65,7 @@ func (s *Storage) AddMovieStorage(movie *models.Movie) error {
 		}
 	}()
 
-	movieInsert := sq.Insert("movies").
-		Columns("title", "description", "release_date", "rating", "actors_id").
-		Values(movie.Title, movie.Description, movie.ReleaseDate, movie.Rating, movie.ActorsID).
-		Suffix("RETURNING id")
-
-	var movieID int64
-	err = movieInsert.RunWith(tx).PlaceholderFormat(sq.Dollar).Scan(&movieID)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, storage.ErrMovieExists)
-	}
-
-	actorUpdate := sq.Update("actors").
-		Set("movies_id", sq.Expr("array_append(movies_id, ?)", movieID)).
-		Where(sq.Expr("id = ANY(?)", movie.ActorsID))
-
-	_, err = actorUpdate.RunWith(tx).PlaceholderFormat(sq.Dollar).Exec()
+	_, err = insertMovie(tx, movie)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -81,133 +73,181 @@ func (s *Storage) AddMovieStorage(movie *models.Movie) error {
 	return nil
 }
 
-func (s *Storage) GetMoviesSortedStorage(sortBy string, sortDirection string) ([]*models.MovieListing, error) {
-	const op = "storage.postgresql.GetMoviesSorted"
-
-	sortColumn := "rating"
-
-	switch sortBy {
-	case "title":
-		sortColumn = "title"
-	case "release_date":
-		sortColumn = "release_date"
+// insertMovie adds the movie with its cast, sets its ID and returns its version.
+func insertMovie(db runner, movie *models.Movie) (int64, error) {
+	if movie.GenresID == nil {
+		movie.GenresID = []int{}
 	}
 
-	if sortDirection != "ASC" && sortDirection != "DESC" {
-		sortDirection = "DESC"
+	if movie.SpokenLanguages == nil {
+		movie.SpokenLanguages = []string{}
+	}
+	if movie.Countries == nil {
+		movie.Countries = []string{}
+	}
+	budgetAmount, budgetCurrency := moneyColumns(movie.Budget)
+	grossAmount, grossCurrency := moneyColumns(movie.Gross)
+	externalIDs := movie.ExternalIDs
+	if externalIDs == nil {
+		externalIDs = &models.ExternalIDs{}
 	}
 
-	query, args, err := sq.
-		Select("m.id AS movie_id, m.title AS movie_title, m.description AS movie_description, m.release_date AS release_date, m.rating AS movie_rating, json_agg(a.name) AS actors").
-		From("movies m").
-		Join("actors a ON a.id = ANY(m.actors_id)").
-		Where("m.deleted_at IS NULL AND a.deleted_at IS NULL").
-		GroupBy("m.id, m.title, m.description, m.release_date, m.rating").
-		OrderBy(sortColumn + " " + sortDirection).
-		ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+	var runtime interface{}
+	if movie.Runtime > 0 {
+		runtime = movie.Runtime
 	}
 
-	rows, err := s.db.Query(query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+	movieInsert := sq.Insert("movies").
+		Columns("title", "description", "release_date", "rating", "genres_id",
+			"runtime", "budget_amount", "budget_currency", "gross_amount", "gross_currency",
+			"original_language", "spoken_languages", "production_countries", "imdb_id", "kinopoisk_id").
+		Values(movie.Title, movie.Description, movie.ReleaseDate, movie.Rating, movie.GenresID,
+			runtime, budgetAmount, budgetCurrency, grossAmount, grossCurrency,
+			nullIfEmpty(movie.OriginalLanguage), movie.SpokenLanguages, movie.Countries,
+			nullIfEmpty(externalIDs.IMDb), nullIfEmpty(externalIDs.Kinopoisk)).
+		Suffix("RETURNING id, version")
+
+	var version int64
+	err := movieInsert.RunWith(db).PlaceholderFormat(sq.Dollar).Scan(&movie.ID, &version)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, storage.ErrExternalIDExists
+		}
+		return 0, storage.ErrMovieExists
 	}
-	defer rows.Close()
 
-	var movies []*models.MovieListing
-	for rows.Next() {
-		movie := &models.MovieListing{}
-		var actors sql.NullString
-		err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &actors)
+	if len(movie.ActorsID) > 0 {
+		_, err = insertCredits([]int64{movie.ID}, toInt64s(movie.ActorsID), models.RoleActor).
+			RunWith(db).PlaceholderFormat(sq.Dollar).Exec()
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-		var newActors *[]string
-		if actors.Valid {
-			if err := json.Unmarshal([]byte(actors.String), &newActors); err != nil {
-				return nil, fmt.Errorf("%s: %w", op, err)
-			}
+			return 0, err
 		}
+	}
 
-		movie.Actors = *newActors
+	return version, nil
+}
 
-		movies = append(movies, movie)
+func (s *Storage) GetMoviesSortedStorage(sortBy string, sortDirection string, filter models.MovieFilter, langs []string) ([]*models.MovieListing, error) {
+	const op = "storage.postgresql.GetMoviesSorted"
+
+	query, args, err := sortedMovieListings(sortBy, sortDirection, filter, langs).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := rows.Err(); err != nil {
+	movies, err := s.queryMovieListings(query, args...)
+	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return movies, nil
 }
 
-func (s *Storage) EditMovieStorage(movie *models.Movie) error {
-	const op = "storage.postgresql.EditMovieStorage"
-
-	updateBuilder := sq.Update("movies").Where(sq.Eq{"ID": &movie.ID})
+// sortedMovieListings is the query of the movie listing narrowed down by the filter
+// and sorted by rating, title, release date or gross, rating when sortBy is unknown.
+func sortedMovieListings(sortBy string, sortDirection string, filter models.MovieFilter, langs []string) sq.SelectBuilder {
+	sortColumn := "rating"
 
-	if movie.Title != "" {
-		updateBuilder = updateBuilder.Set("title", movie.Title)
-	}
-	if movie.Description != "" {
-		updateBuilder = updateBuilder.Set("description", movie.Description)
-	}
-	if !movie.ReleaseDate.IsZero() {
-		updateBuilder = updateBuilder.Set("release_date", movie.ReleaseDate)
+	switch sortBy {
+	case "title":
+		sortColumn = "movie_title"
+	case "release_date":
+		sortColumn = "release_date"
 	}
-	if movie.Rating != nil && *movie.Rating >= 0 && *movie.Rating <= 10 {
-		roundedRating := math.Round(*movie.Rating*10) / 10
-		updateBuilder = updateBuilder.Set("rating", roundedRating)
+
+	if sortDirection != "ASC" && sortDirection != "DESC" {
+		sortDirection = "DESC"
 	}
 
-	updateBuilder = updateBuilder.PlaceholderFormat(sq.Dollar)
+	listing := filterMovies(selectMovieListings(langs), filter)
 
-	sqlStr, args, err := updateBuilder.ToSql()
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	// grosses only compare in one currency, movies grossed in another one or
+	// with an unknown gross come last either way
+	if sortBy == "gross" {
+		return listing.OrderByClause("CASE WHEN m.gross_currency = ? THEN m.gross_amount END "+sortDirection+" NULLS LAST", filter.GrossCurrency)
 	}
 
-	_, err = s.db.Exec(sqlStr, args...)
+	return listing.OrderBy(sortColumn + " " + sortDirection)
+}
+
+// EditMovieStorage applies the merge patch to the movie when it is still at the version
+// and returns its new version. Null members set the columns to NULL and the lists to
+// empty ones.
+func (s *Storage) EditMovieStorage(id int64, version int64, patch *models.MoviePatch) (int64, error) {
+	const op = "storage.postgresql.EditMovieStorage"
+
+	version, err := updateMovie(s.db, id, version, patch)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return version, nil
 }
 
-func (s *Storage) DeleteMovieStorage(id int64) error {
+func updateMovie(db runner, id int64, version int64, patch *models.MoviePatch) (int64, error) {
+	columns := map[string]interface{}{}
+	patchColumn(columns, "title", patch.Title)
+	patchColumn(columns, "description", patch.Description)
+	patchColumn(columns, "release_date", patch.ReleaseDate)
+	if patch.Rating.Changed() {
+		patch.Rating.Value = math.Round(patch.Rating.Value*10) / 10
+	}
+	patchColumn(columns, "rating", patch.Rating)
+	patchColumn(columns, "runtime", patch.Runtime)
+	patchMoneyColumns(columns, "budget", patch.Budget)
+	patchMoneyColumns(columns, "gross", patch.Gross)
+	patchColumn(columns, "original_language", patch.OriginalLanguage)
+	patchListColumn(columns, "spoken_languages", patch.SpokenLanguages)
+	patchListColumn(columns, "production_countries", patch.Countries)
+	patchExternalIDColumns(columns, patch.ExternalIDs)
+
+	version, err := patchRow(db, "movies", id, version, columns)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, storage.ErrMovieNotFound
+	}
+
+	return version, err
+}
+
+// DeleteMovieStorage soft deletes the movie when it is still at the version.
+func (s *Storage) DeleteMovieStorage(id int64, version int64) error {
 	const op = "storage.postgresql.DeleteMovieStorage"
 
-	query, args, err := sq.Update("movies").
-		Set("deleted_at", sq.Expr("CURRENT_TIMESTAMP")).
-		Where(sq.Eq{"id": id}).
-		ToSql()
+	err := deleteMovie(s.db, id, version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.db.Exec(query, args...)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	return nil
+}
+
+func deleteMovie(db runner, id int64, version int64) error {
+	_, err := patchRow(db, "movies", id, version, map[string]interface{}{"deleted_at": sq.Expr("CURRENT_TIMESTAMP")})
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrMovieNotFound
 	}
 
-	return nil
+	return err
 }
 
 func (s *Storage) AddActorStorage(actor *models.Actor) error {
 	const op = "storage.postgresql.AddActor"
 
-	query, args, err := sq.Insert("actors").
-		Columns("name", "sex", "birthday", "movies_id").
-		Values(&actor.Name, &actor.Sex, &actor.Birthday, &actor.MoviesID).
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
+	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
 
-	_, err = s.db.Exec(query, args...)
+	_, err = insertActor(tx, actor)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -215,45 +255,130 @@ func (s *Storage) AddActorStorage(actor *models.Actor) error {
 	return nil
 }
 
-func (s *Storage) EditActorStorage(actor *models.Actor) error {
-	const op = "storage.postgresql.EditActorStorage"
+// insertActor adds the actor with the movies they played in, sets its ID and returns
+// its version.
+func insertActor(db runner, actor *models.Actor) (int64, error) {
+	if actor.Aliases == nil {
+		actor.Aliases = []string{}
+	}
+	externalIDs := actor.ExternalIDs
+	if externalIDs == nil {
+		externalIDs = &models.ExternalIDs{}
+	}
 
-	updateBuilder := sq.Update("actors").Where(sq.Eq{"ID": &actor.ID})
+	var version int64
+	err := sq.Insert("people").
+		Columns("name", "sex", "birthday", "death_date", "birthplace", "biography", "aliases", "imdb_id", "kinopoisk_id").
+		Values(actor.Name, actor.Sex, actor.Birthday, actor.DeathDate, nullIfEmpty(actor.Birthplace), nullIfEmpty(actor.Biography),
+			actor.Aliases, nullIfEmpty(externalIDs.IMDb), nullIfEmpty(externalIDs.Kinopoisk)).
+		Suffix("RETURNING id, version").
+		RunWith(db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&actor.ID, &version)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, storage.ErrExternalIDExists
+		}
+		return 0, err
+	}
 
-	if actor.Name != "" {
-		updateBuilder = updateBuilder.Set("name", actor.Name)
+	if len(actor.MoviesID) > 0 {
+		_, err = insertCredits(toInt64s(actor.MoviesID), []int64{actor.ID}, models.RoleActor).
+			RunWith(db).PlaceholderFormat(sq.Dollar).Exec()
+		if err != nil {
+			return 0, err
+		}
 	}
-	if actor.Sex != "" {
-		updateBuilder = updateBuilder.Set("sex", actor.Sex)
+
+	return version, nil
+}
+
+// EditActorStorage applies the merge patch to the actor like EditMovieStorage.
+func (s *Storage) EditActorStorage(id int64, version int64, patch *models.ActorPatch) (int64, error) {
+	const op = "storage.postgresql.EditActorStorage"
+
+	version, err := updateActor(s.db, id, version, patch)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
-	if !actor.Birthday.IsZero() {
-		updateBuilder = updateBuilder.Set("birthday", actor.Birthday)
+
+	return version, nil
+}
+
+func updateActor(db runner, id int64, version int64, patch *models.ActorPatch) (int64, error) {
+	columns := map[string]interface{}{}
+	patchColumn(columns, "name", patch.Name)
+	patchColumn(columns, "sex", patch.Sex)
+	patchColumn(columns, "birthday", patch.Birthday)
+	patchColumn(columns, "death_date", patch.DeathDate)
+	patchColumn(columns, "birthplace", patch.Birthplace)
+	patchColumn(columns, "biography", patch.Biography)
+	patchListColumn(columns, "aliases", patch.Aliases)
+	patchExternalIDColumns(columns, patch.ExternalIDs)
+
+	version, err := patchRow(db, "people", id, version, columns)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, storage.ErrPersonNotFound
 	}
 
-	updateBuilder = updateBuilder.PlaceholderFormat(sq.Dollar)
+	return version, err
+}
 
-	sqlStr, args, err := updateBuilder.ToSql()
+// GetActorProfileStorage returns the profile of an actor with the IDs of the movies
+// they played in, the name is translated like in GetActorsStorage.
+func (s *Storage) GetActorProfileStorage(id int64, langs []string) (*models.Actor, error) {
+	const op = "storage.postgresql.GetActorProfileStorage"
+
+	query, args, err := sq.Select("a.id", "COALESCE(pt.name, a.name)", "COALESCE(a.sex, '')", "a.birthday", "a.death_date",
+		"COALESCE(a.birthplace, '')", "COALESCE(a.biography, '')", "to_json(a.aliases)", "COALESCE(a.imdb_id, '')", "COALESCE(a.kinopoisk_id, '')", "a.version").
+		Column("COALESCE((SELECT json_agg(c.movie_id ORDER BY c.movie_id) FROM movie_crew c WHERE c.person_id = a.id AND c.role = ?), '[]')", models.RoleActor).
+		From("people a").
+		LeftJoin(personTranslationJoin, langs, langs).
+		Where(sq.Eq{"a.id": id}).
+		Where("a.deleted_at IS NULL").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.db.Exec(sqlStr, args...)
+	actor := &models.Actor{ExternalIDs: &models.ExternalIDs{}}
+	var birthday, deathDate sql.NullTime
+	var aliases, movies []byte
+	err = s.db.QueryRow(query, args...).Scan(&actor.ID, &actor.Name, &actor.Sex, &birthday, &deathDate,
+		&actor.Birthplace, &actor.Biography, &aliases, &actor.ExternalIDs.IMDb, &actor.ExternalIDs.Kinopoisk, &actor.Version, &movies)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	if err := json.Unmarshal(aliases, &actor.Aliases); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := json.Unmarshal(movies, &actor.MoviesID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	actor.Birthday = birthday.Time
+	if deathDate.Valid {
+		actor.DeathDate = &deathDate.Time
+	}
+	if *actor.ExternalIDs == (models.ExternalIDs{}) {
+		actor.ExternalIDs = nil
+	}
+
+	return actor, nil
 }
 
-func (s *Storage) GetActorsStorage() ([]*models.ActorListing, error) {
+// GetActorsStorage lists people credited as actors, together with the people
+// that have no credits yet, so newly added actors show up before being cast.
+func (s *Storage) GetActorsStorage(langs []string) ([]*models.ActorListing, error) {
 	const op = "storage.postgresql.GetActorsStorage"
 
-	query, args, err := sq.
-		Select("a.id AS actor_id, a.name AS actor_name, a.sex AS actor_sex, a.birthday AS actor_birthday, json_agg(m.title) AS movies").
-		From("actors a").
-		LeftJoin("movies m ON a.id = ANY(m.actors_id) AND m.deleted_at IS NULL").
-		Where("a.deleted_at IS NULL").
-		GroupBy("a.id, a.name, a.sex, a.birthday").
+	query, args, err := selectActorListings(langs).
+		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -267,20 +392,10 @@ func (s *Storage) GetActorsStorage() ([]*models.ActorListing, error) {
 
 	var actors []*models.ActorListing
 	for rows.Next() {
-		actor := &models.ActorListing{}
-		var movies sql.NullString
-		err := rows.Scan(&actor.ID, &actor.Name, &actor.Sex, &actor.Birthday, &movies)
+		actor, err := scanActorListing(rows)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-		var newMovies *[]string
-		if movies.Valid {
-			if err := json.Unmarshal([]byte(movies.String), &newMovies); err != nil {
-				return nil, fmt.Errorf("%s: %w", op, err)
-			}
-
-		}
-		actor.Movies = *newMovies
 
 		actors = append(actors, actor)
 	}
@@ -292,215 +407,272 @@ func (s *Storage) GetActorsStorage() ([]*models.ActorListing, error) {
 	return actors, nil
 }
 
+// selectActorListings is the query of the actor listing, actors with the titles of the
+// movies they played in, translated like selectMovieListings.
+func selectActorListings(langs []string) sq.SelectBuilder {
+	return sq.
+		Select("a.id AS actor_id, COALESCE(pt.name, a.name) AS actor_name, COALESCE(a.sex, '') AS actor_sex, a.birthday AS actor_birthday, a.death_date AS actor_death_date, COALESCE(json_agg(COALESCE(mt.title, m.title)) FILTER (WHERE m.id IS NOT NULL), '[]') AS movies").
+		From("people a").
+		LeftJoin(personTranslationJoin, langs, langs).
+		LeftJoin("movie_crew c ON c.person_id = a.id AND c.role = ?", models.RoleActor).
+		LeftJoin("movies m ON m.id = c.movie_id AND m.deleted_at IS NULL").
+		LeftJoin(movieTranslationJoin, langs, langs).
+		Where("a.deleted_at IS NULL").
+		Where(sq.Or{
+			sq.Expr("EXISTS (SELECT 1 FROM movie_crew ac WHERE ac.person_id = a.id AND ac.role = ?)", models.RoleActor),
+			sq.Expr("NOT EXISTS (SELECT 1 FROM movie_crew ac WHERE ac.person_id = a.id)"),
+		}).
+		GroupBy("a.id, a.name, a.sex, a.birthday, a.death_date, pt.name")
+}
+
+func scanActorListing(rows *sql.Rows) (*models.ActorListing, error) {
+	actor := &models.ActorListing{}
+	var birthday, deathDate sql.NullTime
+	var movies []byte
+	err := rows.Scan(&actor.ID, &actor.Name, &actor.Sex, &birthday, &deathDate, &movies)
+	if err != nil {
+		return nil, err
+	}
+	actor.Birthday = birthday.Time
+	if deathDate.Valid {
+		actor.DeathDate = &deathDate.Time
+	}
+
+	if err := json.Unmarshal(movies, &actor.Movies); err != nil {
+		return nil, err
+	}
+
+	return actor, nil
+}
+
 func (s *Storage) GetActorStorage(actorName string) (*models.Actor, error) {
 	const op = "storage.postgresql.GetActor"
 
 	var actor models.Actor
 
-	query, args, err := sq.Select("id", "name", "sex", "birthday", "movies_id", "deleted_at").
-		From("actors").
-		Where(sq.Eq{"name": actorName}).
+	query, args, err := sq.Select("a.id", "a.name", "COALESCE(a.sex, '')", "a.birthday", "COALESCE(array_agg(c.movie_id) FILTER (WHERE c.movie_id IS NOT NULL), '{}')", "a.deleted_at").
+		From("people a").
+		LeftJoin("movie_crew c ON c.person_id = a.id AND c.role = ?", models.RoleActor).
+		Where(sq.Eq{"a.name": actorName}).
+		GroupBy("a.id").
+		Limit(1).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	//stmt, err := s.db.Prepare("SELECT id, name, sex, birthday, movies_id, deleted_at FROM actors WHERE name = $1 LIMIT 1")
+	var birthday, deletedAt sql.NullTime
 
 	row := s.db.QueryRow(query, args...)
-	err = row.Scan(&actor.ID, &actor.Name, &actor.Sex, &actor.Birthday, &actor.MoviesID, &actor.DeletedAt)
+	err = row.Scan(&actor.ID, &actor.Name, &actor.Sex, &birthday, &actor.MoviesID, &deletedAt)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	actor.Birthday = birthday.Time
+	actor.DeletedAt = deletedAt.Time
 
 	return &actor, nil
 }
 
-func (s *Storage) DeleteActorStorage(id int64) error {
+// DeleteActorStorage soft deletes the actor when it is still at the version.
+func (s *Storage) DeleteActorStorage(id int64, version int64) error {
 	const op = "storage.postgresql.DeleteActorStorage"
 
-	query, args, err := sq.Update("actors").
-		Set("deleted_at", sq.Expr("CURRENT_TIMESTAMP")).
-		Where(sq.Eq{"id": id}).
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
+	err := deleteActor(s.db, id, version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.db.Exec(query, args...)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	return nil
+}
+
+func deleteActor(db runner, id int64, version int64) error {
+	_, err := patchRow(db, "people", id, version, map[string]interface{}{"deleted_at": sq.Expr("CURRENT_TIMESTAMP")})
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrPersonNotFound
 	}
 
-	return nil
+	return err
 }
 
 func (s *Storage) AddActorsToMovieStorage(movieID int64, actors []int64) error {
 	const op = "storage.postgresql.AddActorsToMovieStorage"
 
-	tx, err := s.db.Begin()
+	_, err := insertCredits([]int64{movieID}, actors, models.RoleActor).
+		RunWith(s.db).PlaceholderFormat(sq.Dollar).Exec()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-		}
-	}()
 
-	moviessUpdate := sq.Update("movies").
-		Set("actors_id", sq.Expr("array_cat(actors_id, ?)", actors)).
-		Where(sq.Eq{"id": movieID})
+	return nil
+}
+
+func (s *Storage) AddMoviesToActorStorage(actorID int64, movies []int64) error {
+	const op = "storage.postgresql.AddMoviesToActorStorage"
 
-	_, err = moviessUpdate.RunWith(tx).PlaceholderFormat(sq.Dollar).Exec()
+	_, err := insertCredits(movies, []int64{actorID}, models.RoleActor).
+		RunWith(s.db).PlaceholderFormat(sq.Dollar).Exec()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	for _, actorID := range actors {
-		movieUpdate := sq.Update("actors").
-			Set("movies_id", sq.Expr("array_append(movies_id, ?)", movieID)).
-			Where(sq.Eq{"id": actorID})
-
-		_, err = movieUpdate.RunWith(tx).PlaceholderFormat(sq.Dollar).Exec()
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
-	}
-
 	return nil
 }
 
-func (s *Storage) AddMoviesToActorStorage(actorID int64, movies []int64) error {
-	const op = "storage.postgresql.AddMoviesToActorStorage"
+// GetMovieStorage finds movies by a substring of their title or of the name of an actor
+// in the original or in any of the translations.
+func (s *Storage) GetMovieStorage(input string, langs []string) ([]*models.MovieListing, error) {
+	const op = "storage.postgresql.GetMovieStorage"
 
-	tx, err := s.db.Begin()
+	inputLower := strings.ToLower(input)
+
+	matched := sq.Select("m.id").
+		From("movies m").
+		LeftJoin("movie_crew c ON c.movie_id = m.id AND c.role = ?", models.RoleActor).
+		LeftJoin("people a ON a.id = c.person_id AND a.deleted_at IS NULL").
+		Where(sq.Or{
+			sq.Expr("LOWER(m.title) LIKE ?", "%"+inputLower+"%"),
+			sq.Expr("LOWER(a.name) LIKE ?", "%"+inputLower+"%"),
+			sq.Expr("EXISTS (SELECT 1 FROM movie_translations t WHERE t.movie_id = m.id AND LOWER(t.title) LIKE ?)", "%"+inputLower+"%"),
+			sq.Expr("EXISTS (SELECT 1 FROM person_translations t WHERE t.person_id = a.id AND LOWER(t.name) LIKE ?)", "%"+inputLower+"%"),
+		}).
+		Where("m.deleted_at IS NULL")
+
+	query, args, err := selectMovieListings(langs).
+		Where(sq.Expr("m.id IN (?)", matched)).
+		OrderBy("m.id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-		}
-	}()
-
-	actorsUpdate := sq.Update("actors").
-		Set("movies_id", sq.Expr("array_cat(movies_id, ?)", movies)).
-		Where(sq.Eq{"id": actorID})
 
-	_, err = actorsUpdate.RunWith(tx).PlaceholderFormat(sq.Dollar).Exec()
+	movies, err := s.queryMovieListings(query, args...)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	for _, movieID := range movies {
-		actorUpdate := sq.Update("movies").
-			Set("actors_id", sq.Expr("array_append(actors_id, ?)", actorID)).
-			Where(sq.Eq{"id": movieID})
+	return movies, nil
+}
 
-		_, err = actorUpdate.RunWith(tx).PlaceholderFormat(sq.Dollar).Exec()
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
+func (s *Storage) GetMovieStorageByID(id int64) (*models.MovieListing, error) {
+	const op = "storage.postgresql.GetMovieStorageByID"
+
+	movies, err := s.GetMoviesStorageByIDs([]int64{id})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	if len(movies) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrMovieNotFound)
+	}
+
+	return movies[0], nil
 }
 
-func (s *Storage) GetMovieStorage(input string) ([]*models.MovieListing, error) {
-	const op = "storage.postgresql.GetMovieStorage"
+// GetMoviesStorageByIDs loads the listings of all given movies with a single query.
+// Movies that do not exist or are deleted are skipped.
+func (s *Storage) GetMoviesStorageByIDs(ids []int64) ([]*models.MovieListing, error) {
+	const op = "storage.postgresql.GetMoviesStorageByIDs"
 
-	inputLower := strings.ToLower(input)
+	if len(ids) == 0 {
+		return nil, nil
+	}
 
-	query, args, err := sq.Select("m.id AS movie_id").
-		From("movies m").
-		Join("actors a ON a.id = ANY(m.actors_id)").
-		Where(sq.Or{sq.Expr("LOWER(m.title) LIKE ?", "%"+inputLower+"%"), sq.Expr("LOWER(a.name) LIKE ?", "%"+inputLower+"%")}).
-		Where("m.deleted_at IS NULL AND a.deleted_at IS NULL").
-		GroupBy("m.id").
+	query, args, err := selectMovieListings(nil).
+		Where(sq.Expr("m.id = ANY(?)", ids)).
 		OrderBy("m.id").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
-
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	rows, err := s.db.Query(query, args...)
+	movies, err := s.queryMovieListings(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer rows.Close()
 
-	var moviesID []int64
-	for rows.Next() {
-		var movie int64
-		err := rows.Scan(&movie)
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-		moviesID = append(moviesID, movie)
-	}
+	return movies, nil
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+// selectMovieListings is the base query shared by every method returning models.MovieListing.
+// Titles, descriptions and actor names are translated to the first of langs they have
+// a translation in, nil langs keep the originals.
+func selectMovieListings(langs []string) sq.SelectBuilder {
+	return sq.
+		Select("m.id AS movie_id, COALESCE(mt.title, m.title) AS movie_title, COALESCE(NULLIF(mt.description, ''), m.description, '') AS movie_description, m.release_date AS release_date, m.rating AS movie_rating, m.user_rating, m.votes_count, COALESCE(json_agg(COALESCE(pt.name, a.name)) FILTER (WHERE a.id IS NOT NULL), '[]') AS actors").
+		Column("(SELECT COALESCE(json_agg(g.name ORDER BY g.name), '[]') FROM genres g WHERE g.id = ANY(m.genres_id)) AS genres").
+		Columns("m.runtime", "m.budget_amount", "m.budget_currency", "m.gross_amount", "m.gross_currency",
+			"COALESCE(m.original_language, '')", "to_json(m.spoken_languages)", "to_json(m.production_countries)",
+			"COALESCE(m.imdb_id, '')", "COALESCE(m.kinopoisk_id, '')", "m.version").
+		From("movies m").
+		LeftJoin(movieTranslationJoin, langs, langs).
+		LeftJoin("movie_crew c ON c.movie_id = m.id AND c.role = ?", models.RoleActor).
+		LeftJoin("people a ON a.id = c.person_id AND a.deleted_at IS NULL").
+		LeftJoin(personTranslationJoin, langs, langs).
+		Where("m.deleted_at IS NULL").
+		GroupBy("m.id", "mt.title", "mt.description")
+}
+
+func (s *Storage) queryMovieListings(query string, args ...interface{}) ([]*models.MovieListing, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
 	var movies []*models.MovieListing
-	for _, tempMovie := range moviesID {
-		movie, err := s.GetMovieStorageByID(tempMovie)
+	for rows.Next() {
+		movie, err := scanMovieListing(rows)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, err
 		}
 
 		movies = append(movies, movie)
 	}
 
-	return movies, nil
-
-}
-
-func (s *Storage) GetMovieStorageByID(id int64) (*models.MovieListing, error) {
-	const op = "storage.postgresql.GetMovieStorage"
-
-	query, args, err := sq.
-		Select("m.id AS movie_id, m.title AS movie_title, m.description AS movie_description, m.release_date AS release_date, m.rating AS movie_rating, json_agg(a.name) AS actors").
-		From("movies m").
-		Join("actors a ON a.id = ANY(m.actors_id)").
-		Where(sq.And{sq.Eq{"m.id": id}, sq.Expr("m.deleted_at IS NULL"), sq.Expr("a.deleted_at IS NULL")}).
-		GroupBy("m.id, m.title, m.description, m.release_date, m.rating").
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	row := s.db.QueryRow(query, args...)
+	return movies, nil
+}
 
+// scanMovieListing scans a row of a query built on selectMovieListings.
+func scanMovieListing(rows *sql.Rows) (*models.MovieListing, error) {
 	movie := &models.MovieListing{}
-	var actors sql.NullString
-	err = row.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &actors)
+	var actors, genres, spokenLanguages, countries []byte
+	var runtime, budgetAmount, grossAmount sql.NullInt64
+	var budgetCurrency, grossCurrency sql.NullString
+	externalIDs := models.ExternalIDs{}
+	err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.UserRating, &movie.VotesCount, &actors, &genres,
+		&runtime, &budgetAmount, &budgetCurrency, &grossAmount, &grossCurrency,
+		&movie.OriginalLanguage, &spokenLanguages, &countries, &externalIDs.IMDb, &externalIDs.Kinopoisk, &movie.Version)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("%s: %w", op, storage.ErrMovieNotFound)
-		}
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, err
 	}
 
-	var newActors []string
-	if actors.Valid {
-		if err := json.Unmarshal([]byte(actors.String), &newActors); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
+	movie.Runtime = int(runtime.Int64)
+	movie.Budget = moneyOf(budgetAmount, budgetCurrency)
+	movie.Gross = moneyOf(grossAmount, grossCurrency)
+	if externalIDs != (models.ExternalIDs{}) {
+		movie.ExternalIDs = &externalIDs
+	}
+	if err := json.Unmarshal(spokenLanguages, &movie.SpokenLanguages); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(countries, &movie.Countries); err != nil {
+		return nil, err
 	}
 
-	movie.Actors = newActors
+	if err := json.Unmarshal(actors, &movie.Actors); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(genres, &movie.Genres); err != nil {
+		return nil, err
+	}
 
 	return movie, nil
 }
@@ -550,3 +722,169 @@ func (s *Storage) GetUserStorage(email string) (*models.User, error) {
 
 	return user, nil
 }
+
+// patchRow updates the columns of the row with the ID when it is still at the version
+// and returns the version it moves to, an empty patch only moves the version. It
+// returns sql.ErrNoRows when the row does not exist and storage.ErrVersionMismatch
+// when another update has moved its version.
+func patchRow(db runner, table string, id int64, version int64, columns map[string]interface{}) (int64, error) {
+	columns["version"] = sq.Expr("version + 1")
+
+	where := sq.Eq{"id": id}
+	if version != storage.AnyVersion {
+		where["version"] = version
+	}
+
+	query, args, err := sq.Update(table).
+		SetMap(columns).
+		Where(where).
+		Where("deleted_at IS NULL").
+		Suffix("RETURNING version").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = db.QueryRow(query, args...).Scan(&version)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, storage.ErrExternalIDExists
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, versionConflict(db, table, id)
+		}
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// versionConflict tells why patchRow matched no row, the row is either gone or at
+// another version.
+func versionConflict(db runner, table string, id int64) error {
+	query, args, err := sq.Select("1").
+		From(table).
+		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NULL").
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	var exists bool
+	err = db.QueryRow(query, args...).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sql.ErrNoRows
+	}
+
+	return storage.ErrVersionMismatch
+}
+
+// patchColumn sets the column to the member of a merge patch when it is present.
+func patchColumn[T any](columns map[string]interface{}, column string, field models.Field[T]) {
+	if !field.Set {
+		return
+	}
+	if field.Null {
+		columns[column] = nil
+		return
+	}
+	columns[column] = field.Value
+}
+
+// patchListColumn sets an array column, null empties it as the arrays are NOT NULL.
+func patchListColumn(columns map[string]interface{}, column string, field models.Field[[]string]) {
+	if field.Null || field.Changed() && field.Value == nil {
+		field = models.SetTo([]string{})
+	}
+	patchColumn(columns, column, field)
+}
+
+// patchMoneyColumns sets the amount and currency columns of the money named prefix.
+func patchMoneyColumns(columns map[string]interface{}, prefix string, field models.Field[models.Money]) {
+	if !field.Set {
+		return
+	}
+
+	var money *models.Money
+	if !field.Null {
+		money = &field.Value
+	}
+	columns[prefix+"_amount"], columns[prefix+"_currency"] = moneyColumns(money)
+}
+
+// patchExternalIDColumns merges the external IDs, null clears all of them.
+func patchExternalIDColumns(columns map[string]interface{}, field models.Field[models.ExternalIDsPatch]) {
+	if field.Null {
+		columns["imdb_id"], columns["kinopoisk_id"] = nil, nil
+		return
+	}
+	patchColumn(columns, "imdb_id", field.Value.IMDb)
+	patchColumn(columns, "kinopoisk_id", field.Value.Kinopoisk)
+}
+
+// nullIfEmpty stores an empty string as NULL.
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// moneyColumns splits money into its amount and currency columns, both NULL when it is unknown.
+func moneyColumns(money *models.Money) (interface{}, interface{}) {
+	if money == nil {
+		return nil, nil
+	}
+	return money.Amount, money.Currency
+}
+
+func moneyOf(amount sql.NullInt64, currency sql.NullString) *models.Money {
+	if !amount.Valid {
+		return nil
+	}
+	return &models.Money{Amount: amount.Int64, Currency: currency.String}
+}
+
+// filterMovies narrows down a query of movies m by every filter of the movie listing.
+func filterMovies(listing sq.SelectBuilder, filter models.MovieFilter) sq.SelectBuilder {
+	if filter.Genre != "" {
+		listing = listing.Where(sq.Expr(
+			"EXISTS (SELECT 1 FROM genres g WHERE g.id = ANY(m.genres_id) AND LOWER(g.name) = ?)",
+			strings.ToLower(filter.Genre),
+		))
+	}
+
+	listing = filterReleases(listing, filter)
+	listing = filterAwards(listing, filter)
+	return filterFacts(listing, filter)
+}
+
+// filterFacts applies the runtime and box office filters of the movie listing to the movies m.
+func filterFacts(listing sq.SelectBuilder, filter models.MovieFilter) sq.SelectBuilder {
+	if filter.MinRuntime > 0 {
+		listing = listing.Where(sq.GtOrEq{"m.runtime": filter.MinRuntime})
+	}
+	if filter.MaxRuntime > 0 {
+		listing = listing.Where(sq.LtOrEq{"m.runtime": filter.MaxRuntime})
+	}
+
+	if filter.MinGross > 0 || filter.MaxGross > 0 {
+		listing = listing.Where(sq.Eq{"m.gross_currency": filter.GrossCurrency})
+		if filter.MinGross > 0 {
+			listing = listing.Where(sq.GtOrEq{"m.gross_amount": filter.MinGross})
+		}
+		if filter.MaxGross > 0 {
+			listing = listing.Where(sq.LtOrEq{"m.gross_amount": filter.MaxGross})
+		}
+	}
+
+	return listing
+}
@@ -0,0 +1,191 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"os"
+	"testing"
+	"time"
+)
+
+// newTestStorage connects to the database from TEST_DATA_SOURCE_NAME,
+// the schema is expected to be created from sql/create-tables.sql.
+func newTestStorage(tb testing.TB) *Storage {
+	tb.Helper()
+
+	dataSourceName := os.Getenv("TEST_DATA_SOURCE_NAME")
+	if dataSourceName == "" {
+		tb.Skip("TEST_DATA_SOURCE_NAME is not set")
+	}
+
+	s, err := New(dataSourceName)
+	if err != nil {
+		tb.Fatalf("failed to connect to test database: %v", err)
+	}
+	tb.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+// seedMovies inserts movies titled "<prefix> <n>", each with its own cast of
+// actorsPerMovie actors, and removes them when the test finishes.
+func seedMovies(tb testing.TB, s *Storage, prefix string, movies, actorsPerMovie int) []int64 {
+	tb.Helper()
+
+	var movieIDs, actorIDs []int64
+	tb.Cleanup(func() {
+		s.db.Exec("DELETE FROM movie_crew WHERE movie_id = ANY($1)", movieIDs)
+		s.db.Exec("DELETE FROM reviews WHERE movie_id = ANY($1)", movieIDs)
+		s.db.Exec("DELETE FROM movies WHERE id = ANY($1)", movieIDs)
+		s.db.Exec("DELETE FROM people WHERE id = ANY($1)", actorIDs)
+	})
+
+	for i := 0; i < movies; i++ {
+		var movieID int64
+		err := sq.Insert("movies").
+			Columns("title", "description", "release_date", "rating").
+			Values(fmt.Sprintf("%s %d", prefix, i), "seeded", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 5.0).
+			Suffix("RETURNING id").
+			RunWith(s.db).PlaceholderFormat(sq.Dollar).
+			Scan(&movieID)
+		if err != nil {
+			tb.Fatalf("failed to seed movie: %v", err)
+		}
+		movieIDs = append(movieIDs, movieID)
+
+		cast := make([]int64, 0, actorsPerMovie)
+		for j := 0; j < actorsPerMovie; j++ {
+			var actorID int64
+			err := sq.Insert("people").
+				Columns("name", "sex", "birthday").
+				Values(fmt.Sprintf("%s actor %d-%d", prefix, i, j), "male", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)).
+				Suffix("RETURNING id").
+				RunWith(s.db).PlaceholderFormat(sq.Dollar).
+				Scan(&actorID)
+			if err != nil {
+				tb.Fatalf("failed to seed actor: %v", err)
+			}
+			cast = append(cast, actorID)
+			actorIDs = append(actorIDs, actorID)
+		}
+
+		if err := s.AddActorsToMovieStorage(movieID, cast); err != nil {
+			tb.Fatalf("failed to seed cast: %v", err)
+		}
+	}
+
+	return movieIDs
+}
+
+func TestStorage_GetMovieStorage(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("search-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 5, 2)
+
+	movies, err := s.GetMovieStorage(prefix, nil)
+	if err != nil {
+		t.Fatalf("GetMovieStorage() error = %v", err)
+	}
+
+	if len(movies) != len(ids) {
+		t.Fatalf("GetMovieStorage() returned %d movies, want %d", len(movies), len(ids))
+	}
+	for i, movie := range movies {
+		if movie.ID != ids[i] {
+			t.Errorf("movie %d: got id %d, want %d", i, movie.ID, ids[i])
+		}
+		if len(movie.Actors) != 2 {
+			t.Errorf("movie %d: got %d actors, want 2", i, len(movie.Actors))
+		}
+	}
+}
+
+func BenchmarkGetMovieStorage(b *testing.B) {
+	s := newTestStorage(b)
+
+	prefix := fmt.Sprintf("bench-%d", time.Now().UnixNano())
+	seedMovies(b, s, prefix, 200, 5)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.GetMovieStorage(prefix, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetMovieStorageByIDLoop loads the same listings one query per movie,
+// the way search used to, as a baseline for BenchmarkGetMovieStorage.
+func BenchmarkGetMovieStorageByIDLoop(b *testing.B) {
+	s := newTestStorage(b)
+
+	prefix := fmt.Sprintf("bench-%d", time.Now().UnixNano())
+	ids := seedMovies(b, s, prefix, 200, 5)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if _, err := s.GetMovieStorageByID(id); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestStorage_MovieListingsWithoutCast(t *testing.T) {
+	s := newTestStorage(t)
+
+	tests := []struct {
+		name           string
+		actorsPerMovie int
+		deleteCast     bool
+	}{
+		{name: "empty cast", actorsPerMovie: 0},
+		{name: "soft-deleted cast", actorsPerMovie: 2, deleteCast: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := fmt.Sprintf("no-cast-%d", time.Now().UnixNano())
+			ids := seedMovies(t, s, prefix, 1, tt.actorsPerMovie)
+
+			if tt.deleteCast {
+				_, err := s.db.Exec("UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE name LIKE $1", prefix+"%")
+				if err != nil {
+					t.Fatalf("failed to delete cast: %v", err)
+				}
+			}
+
+			movie, err := s.GetMovieStorageByID(ids[0])
+			if err != nil {
+				t.Fatalf("GetMovieStorageByID() error = %v", err)
+			}
+			if movie.Actors == nil || len(movie.Actors) != 0 {
+				t.Errorf("GetMovieStorageByID() actors = %#v, want empty", movie.Actors)
+			}
+
+			found, err := s.GetMovieStorage(prefix, nil)
+			if err != nil {
+				t.Fatalf("GetMovieStorage() error = %v", err)
+			}
+			if len(found) != 1 || found[0].ID != ids[0] {
+				t.Errorf("GetMovieStorage() = %v, want movie %d", found, ids[0])
+			}
+
+			sorted, err := s.GetMoviesSortedStorage("title", "ASC", models.MovieFilter{}, nil)
+			if err != nil {
+				t.Fatalf("GetMoviesSortedStorage() error = %v", err)
+			}
+			listed := false
+			for _, m := range sorted {
+				if m.ID == ids[0] {
+					listed = true
+				}
+			}
+			if !listed {
+				t.Errorf("GetMoviesSortedStorage() does not list movie %d", ids[0])
+			}
+		})
+	}
+}
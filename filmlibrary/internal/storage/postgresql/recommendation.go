@@ -0,0 +1,105 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetSimilarityCandidatesStorage pairs every source movie with the movies that share
+// an actor or a genre with it. Movies from exclude are never returned as candidates.
+func (s *Storage) GetSimilarityCandidatesStorage(sourceIDs, exclude []int64) ([]*models.SimilarityCandidate, error) {
+	const op = "storage.postgresql.GetSimilarityCandidatesStorage"
+
+	query, args, err := sq.Select("src.id", "m.id", "shared.actors").
+		Column("cardinality(ARRAY(SELECT unnest(m.genres_id) INTERSECT SELECT unnest(src.genres_id)))").
+		Column("ABS(m.release_date - src.release_date)").
+		Column("COALESCE(m.rating, 0)").
+		Column("m.user_rating").
+		From("movies src").
+		Join("movies m ON m.deleted_at IS NULL AND m.id <> ALL(?::int[])", exclude).
+		CrossJoin(`LATERAL (
+			SELECT COUNT(*) AS actors
+			FROM movie_crew sc
+			JOIN movie_crew mc ON mc.person_id = sc.person_id AND mc.role = sc.role
+			JOIN people p ON p.id = sc.person_id AND p.deleted_at IS NULL
+			WHERE sc.movie_id = src.id AND mc.movie_id = m.id AND sc.role = ?
+		) shared`, models.RoleActor).
+		Where(sq.Expr("src.id = ANY(?::int[])", sourceIDs)).
+		Where("src.deleted_at IS NULL").
+		Where("m.id <> src.id").
+		Where("(shared.actors > 0 OR m.genres_id && src.genres_id)").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	candidates := []*models.SimilarityCandidate{}
+	for rows.Next() {
+		c := &models.SimilarityCandidate{}
+		err := rows.Scan(&c.SourceID, &c.MovieID, &c.SharedActors, &c.SharedGenres, &c.DaysApart, &c.Rating, &c.UserRating)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return candidates, nil
+}
+
+// GetTasteSeedsStorage returns the movies the user rated or watched, most recent first.
+func (s *Storage) GetTasteSeedsStorage(userID int64, limit int) ([]*models.TasteSeed, error) {
+	const op = "storage.postgresql.GetTasteSeedsStorage"
+
+	query, args, err := sq.Select("t.movie_id", "MAX(t.rating)", "bool_or(t.watched)").
+		FromSelect(sq.Select("movie_id", "rating", "FALSE AS watched", "updated_at AS at").
+			From("reviews").
+			Where(sq.Eq{"user_id": userID}).
+			Suffix("UNION ALL").
+			SuffixExpr(sq.Select("movie_id", "NULL", "TRUE", "watched_at::timestamp").
+				From("watched_movies").
+				Where(sq.Eq{"user_id": userID})), "t").
+		GroupBy("t.movie_id").
+		OrderBy("MAX(t.at) DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	seeds := []*models.TasteSeed{}
+	for rows.Next() {
+		seed := &models.TasteSeed{}
+		err := rows.Scan(&seed.MovieID, &seed.Rating, &seed.Watched)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		seeds = append(seeds, seed)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return seeds, nil
+}
@@ -0,0 +1,43 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_GetSimilarityCandidatesStorage(t *testing.T) {
+	s := newTestStorage(t)
+
+	ids := seedMovies(t, s, fmt.Sprintf("similar-%d", time.Now().UnixNano()), 3, 2)
+
+	var sharedActor int64
+	err := s.db.QueryRow("SELECT person_id FROM movie_crew WHERE movie_id = $1 LIMIT 1", ids[0]).Scan(&sharedActor)
+	if err != nil {
+		t.Fatalf("failed to find an actor: %v", err)
+	}
+	if err := s.AddActorsToMovieStorage(ids[1], []int64{sharedActor}); err != nil {
+		t.Fatalf("AddActorsToMovieStorage() error = %v", err)
+	}
+
+	candidates, err := s.GetSimilarityCandidatesStorage([]int64{ids[0]}, []int64{ids[0]})
+	if err != nil {
+		t.Fatalf("GetSimilarityCandidatesStorage() error = %v", err)
+	}
+
+	var found bool
+	for _, c := range candidates {
+		if c.MovieID == ids[2] {
+			t.Errorf("movie %d shares nothing with %d but is a candidate", ids[2], ids[0])
+		}
+		if c.MovieID == ids[1] {
+			found = true
+			if c.SourceID != ids[0] || c.SharedActors != 1 || c.DaysApart != 0 {
+				t.Errorf("candidate %d = %+v, want one shared actor released the same day", ids[1], c)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("GetSimilarityCandidatesStorage() does not return movie %d", ids[1])
+	}
+}
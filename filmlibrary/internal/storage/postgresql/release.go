@@ -0,0 +1,147 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpsertReleaseStorage adds the release of an existing movie, a movie has one release
+// of each type per country so an existing one is replaced.
+func (s *Storage) UpsertReleaseStorage(release *models.Release) error {
+	const op = "storage.postgresql.UpsertReleaseStorage"
+
+	var certification interface{}
+	if release.Certification != "" {
+		certification = release.Certification
+	}
+
+	err := sq.Insert("movie_releases").
+		Columns("movie_id", "country", "release_type", "release_date", "certification", "min_age").
+		Select(sq.Select("m.id").
+			Column("?::text", release.Country).
+			Column("?::text", release.Type).
+			Column("?::date", release.ReleaseDate).
+			Column("?::text", certification).
+			Column("?::int", release.MinAge).
+			From("movies m").
+			Where(sq.Eq{"m.id": release.MovieID}).
+			Where("m.deleted_at IS NULL")).
+		Suffix("ON CONFLICT (movie_id, country, release_type) DO UPDATE SET release_date = EXCLUDED.release_date, certification = EXCLUDED.certification, min_age = EXCLUDED.min_age RETURNING id").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&release.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrMovieNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetReleasesStorage returns the releases of a movie by country, earliest first.
+func (s *Storage) GetReleasesStorage(movieID int64) ([]*models.Release, error) {
+	const op = "storage.postgresql.GetReleasesStorage"
+
+	query, args, err := sq.Select("id", "movie_id", "country", "release_type", "release_date", "COALESCE(certification, '')", "min_age").
+		From("movie_releases").
+		Where(sq.Eq{"movie_id": movieID}).
+		OrderBy("country", "release_date", "release_type").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	releases := []*models.Release{}
+	for rows.Next() {
+		release := &models.Release{}
+		var minAge sql.NullInt64
+		err := rows.Scan(&release.ID, &release.MovieID, &release.Country, &release.Type, &release.ReleaseDate, &release.Certification, &minAge)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if minAge.Valid {
+			age := int(minAge.Int64)
+			release.MinAge = &age
+		}
+		releases = append(releases, release)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return releases, nil
+}
+
+func (s *Storage) DeleteReleaseStorage(id int64) error {
+	const op = "storage.postgresql.DeleteReleaseStorage"
+
+	res, err := sq.Delete("movie_releases").
+		Where(sq.Eq{"id": id}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrReleaseNotFound)
+	}
+
+	return nil
+}
+
+// filterReleases applies the release filters of the movie listing to the movies m.
+func filterReleases(listing sq.SelectBuilder, filter models.MovieFilter) sq.SelectBuilder {
+	matchRelease := func(alias string) sq.And {
+		match := sq.And{sq.Expr(alias + ".movie_id = m.id")}
+		if filter.Country != "" {
+			match = append(match, sq.Eq{alias + ".country": filter.Country})
+		}
+		if filter.ReleaseType != "" {
+			match = append(match, sq.Eq{alias + ".release_type": filter.ReleaseType})
+		}
+		return match
+	}
+
+	if !filter.ReleasedBefore.IsZero() || !filter.ReleasedAfter.IsZero() || filter.Country != "" || filter.ReleaseType != "" {
+		released := matchRelease("r")
+		if !filter.ReleasedBefore.IsZero() {
+			released = append(released, sq.Lt{"r.release_date": filter.ReleasedBefore})
+		}
+		if !filter.ReleasedAfter.IsZero() {
+			released = append(released, sq.Gt{"r.release_date": filter.ReleasedAfter})
+		}
+		listing = listing.Where(sq.Expr("EXISTS (?)", sq.Select("1").From("movie_releases r").Where(released)))
+	}
+
+	// a movie is suitable when it is certified for the age and no certification
+	// of the matched releases is stricter
+	if filter.SuitableForAge != nil {
+		certified := append(matchRelease("r"), sq.LtOrEq{"r.min_age": *filter.SuitableForAge})
+		stricter := append(matchRelease("r"), sq.Gt{"r.min_age": *filter.SuitableForAge})
+		listing = listing.
+			Where(sq.Expr("EXISTS (?)", sq.Select("1").From("movie_releases r").Where(certified))).
+			Where(sq.Expr("NOT EXISTS (?)", sq.Select("1").From("movie_releases r").Where(stricter)))
+	}
+
+	return listing
+}
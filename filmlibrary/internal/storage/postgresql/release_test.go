@@ -0,0 +1,58 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_ReleaseFilters(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("release-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 3, 0)
+
+	six, twelve, eighteen := 6, 12, 18
+	for _, release := range []*models.Release{
+		{MovieID: ids[0], Country: "RU", Type: models.ReleaseTheatrical, ReleaseDate: time.Date(2010, 5, 1, 0, 0, 0, 0, time.UTC), Certification: "6+", MinAge: &six},
+		{MovieID: ids[0], Country: "US", Type: models.ReleaseTheatrical, ReleaseDate: time.Date(2009, 5, 1, 0, 0, 0, 0, time.UTC), Certification: "R", MinAge: &eighteen},
+		{MovieID: ids[1], Country: "RU", Type: models.ReleaseStreaming, ReleaseDate: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), Certification: "12+", MinAge: &twelve},
+		{MovieID: ids[2], Country: "US", Type: models.ReleaseFestival, ReleaseDate: time.Date(2015, 5, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		if err := s.UpsertReleaseStorage(release); err != nil {
+			t.Fatalf("UpsertReleaseStorage() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter models.MovieFilter
+		want   []int64
+	}{
+		{name: "Released in RU before 2020", filter: models.MovieFilter{Country: "RU", ReleasedBefore: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, want: ids[:1]},
+		{name: "Streaming releases", filter: models.MovieFilter{ReleaseType: models.ReleaseStreaming}, want: ids[1:2]},
+		{name: "Suitable for 12 in RU", filter: models.MovieFilter{Country: "RU", SuitableForAge: &twelve}, want: ids[:2]},
+		{name: "Suitable for 12 anywhere", filter: models.MovieFilter{SuitableForAge: &twelve}, want: ids[1:2]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movies, err := s.GetMoviesSortedStorage("title", "ASC", tt.filter, nil)
+			if err != nil {
+				t.Fatalf("GetMoviesSortedStorage() error = %v", err)
+			}
+
+			var got []int64
+			for _, movie := range movies {
+				for _, id := range ids {
+					if movie.ID == id {
+						got = append(got, id)
+					}
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("GetMoviesSortedStorage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
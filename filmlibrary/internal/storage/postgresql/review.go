@@ -0,0 +1,248 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpsertReviewStorage saves the user's review of a movie, replacing the previous
+// one, and recalculates the movie's average user rating in the same transaction.
+func (s *Storage) UpsertReviewStorage(review *models.Review) error {
+	const op = "storage.postgresql.UpsertReviewStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = lockMovie(tx, review.MovieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = sq.Insert("reviews").
+		Columns("movie_id", "user_id", "rating", "text").
+		Values(review.MovieID, review.UserID, review.Rating, review.Text).
+		Suffix("ON CONFLICT (movie_id, user_id) DO UPDATE SET rating = EXCLUDED.rating, text = EXCLUDED.text, updated_at = CURRENT_TIMESTAMP").
+		Suffix("RETURNING id, hidden, created_at, updated_at").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&review.ID, &review.Hidden, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = refreshUserRating(tx, review.MovieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetReviewsStorage returns a page of visible reviews of the movie, newest first,
+// and the total number of visible reviews.
+func (s *Storage) GetReviewsStorage(movieID int64, limit, offset int) ([]*models.Review, int64, error) {
+	const op = "storage.postgresql.GetReviewsStorage"
+
+	visible := sq.And{sq.Eq{"movie_id": movieID}, sq.Eq{"hidden": false}}
+
+	var total int64
+	err := sq.Select("COUNT(*)").
+		From("reviews").
+		Where(visible).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err := sq.Select("id", "movie_id", "user_id", "rating", "COALESCE(text, '')", "created_at", "updated_at").
+		From("reviews").
+		Where(visible).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	reviews := []*models.Review{}
+	for rows.Next() {
+		review := &models.Review{}
+		err := rows.Scan(&review.ID, &review.MovieID, &review.UserID, &review.Rating, &review.Text, &review.CreatedAt, &review.UpdatedAt)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, total, nil
+}
+
+// SetReviewHiddenStorage hides or restores a review. Hidden reviews do not count
+// towards the movie's user rating.
+func (s *Storage) SetReviewHiddenStorage(id int64, hidden bool) error {
+	const op = "storage.postgresql.SetReviewHiddenStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	movieID, err := lockReviewedMovie(tx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = sq.Update("reviews").
+		Set("hidden", hidden).
+		Where(sq.Eq{"id": id}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = refreshUserRating(tx, movieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteReviewStorage(id int64) error {
+	const op = "storage.postgresql.DeleteReviewStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	movieID, err := lockReviewedMovie(tx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = sq.Delete("reviews").
+		Where(sq.Eq{"id": id}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = refreshUserRating(tx, movieID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// lockMovie takes a row lock on the movie, so concurrent rating updates of the
+// same movie are applied one after another.
+func lockMovie(tx *sql.Tx, movieID int64) error {
+	var id int64
+	err := sq.Select("id").
+		From("movies").
+		Where(sq.Eq{"id": movieID}).
+		Where("deleted_at IS NULL").
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrMovieNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func lockReviewedMovie(tx *sql.Tx, reviewID int64) (int64, error) {
+	var movieID int64
+	err := sq.Select("movie_id").
+		From("reviews").
+		Where(sq.Eq{"id": reviewID}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&movieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, storage.ErrReviewNotFound
+		}
+		return 0, err
+	}
+
+	_, err = sq.Select("id").
+		From("movies").
+		Where(sq.Eq{"id": movieID}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return movieID, nil
+}
+
+func refreshUserRating(tx *sql.Tx, movieID int64) error {
+	visible := sq.And{sq.Eq{"r.movie_id": movieID}, sq.Eq{"r.hidden": false}}
+
+	_, err := sq.Update("movies").
+		Set("user_rating", sq.Select("ROUND(AVG(r.rating)::numeric, 1)::float").From("reviews r").Where(visible)).
+		Set("votes_count", sq.Select("COUNT(*)").From("reviews r").Where(visible)).
+		Where(sq.Eq{"id": movieID}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,77 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// seedUser creates a user with a unique email and removes it with its reviews when the test finishes.
+func seedUser(tb testing.TB, s *Storage) int64 {
+	tb.Helper()
+
+	email := fmt.Sprintf("u%d@test.io", time.Now().UnixNano()%1e12)
+	if err := s.CreateUserStorage(email, "user", []byte("hash")); err != nil {
+		tb.Fatalf("failed to seed user: %v", err)
+	}
+
+	user, err := s.GetUserStorage(email)
+	if err != nil {
+		tb.Fatalf("failed to seed user: %v", err)
+	}
+	tb.Cleanup(func() {
+		s.db.Exec("DELETE FROM reviews WHERE user_id = $1", user.ID)
+		s.db.Exec("DELETE FROM watchlist_items WHERE user_id = $1", user.ID)
+		s.db.Exec("DELETE FROM watched_movies WHERE user_id = $1", user.ID)
+		s.db.Exec("DELETE FROM collections WHERE owner_id = $1", user.ID)
+		s.db.Exec("DELETE FROM users WHERE id = $1", user.ID)
+	})
+
+	return user.ID
+}
+
+func TestStorage_ReviewsUpdateUserRating(t *testing.T) {
+	s := newTestStorage(t)
+
+	ids := seedMovies(t, s, fmt.Sprintf("review-%d", time.Now().UnixNano()), 1, 0)
+	first, second := seedUser(t, s), seedUser(t, s)
+
+	for _, review := range []*models.Review{
+		{MovieID: ids[0], UserID: first, Rating: 4},
+		{MovieID: ids[0], UserID: second, Rating: 9},
+		{MovieID: ids[0], UserID: first, Rating: 6, Text: "changed my mind"},
+	} {
+		if err := s.UpsertReviewStorage(review); err != nil {
+			t.Fatalf("UpsertReviewStorage() error = %v", err)
+		}
+	}
+
+	movie, err := s.GetMovieStorageByID(ids[0])
+	if err != nil {
+		t.Fatalf("GetMovieStorageByID() error = %v", err)
+	}
+	if movie.VotesCount != 2 || movie.UserRating == nil || *movie.UserRating != 7.5 {
+		t.Fatalf("user rating = %v from %d votes, want 7.5 from 2", movie.UserRating, movie.VotesCount)
+	}
+
+	reviews, total, err := s.GetReviewsStorage(ids[0], 1, 0)
+	if err != nil {
+		t.Fatalf("GetReviewsStorage() error = %v", err)
+	}
+	if total != 2 || len(reviews) != 1 {
+		t.Fatalf("GetReviewsStorage() = %d reviews of %d, want 1 of 2", len(reviews), total)
+	}
+
+	if err := s.SetReviewHiddenStorage(reviews[0].ID, true); err != nil {
+		t.Fatalf("SetReviewHiddenStorage() error = %v", err)
+	}
+
+	movie, err = s.GetMovieStorageByID(ids[0])
+	if err != nil {
+		t.Fatalf("GetMovieStorageByID() error = %v", err)
+	}
+	if movie.VotesCount != 1 {
+		t.Errorf("votes count after hiding = %d, want 1", movie.VotesCount)
+	}
+}
@@ -0,0 +1,205 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// movieTranslationJoin and personTranslationJoin pick the translation of the movie m
+// and the person a in the first of the languages it exists in. Both take the languages
+// twice, and find nothing when they are nil.
+const (
+	movieTranslationJoin  = "LATERAL (SELECT t.title, t.description FROM movie_translations t WHERE t.movie_id = m.id AND t.locale = ANY(?::text[]) ORDER BY array_position(?::text[], t.locale::text) LIMIT 1) mt ON true"
+	personTranslationJoin = "LATERAL (SELECT t.name FROM person_translations t WHERE t.person_id = a.id AND t.locale = ANY(?::text[]) ORDER BY array_position(?::text[], t.locale::text) LIMIT 1) pt ON true"
+)
+
+// UpsertMovieTranslationStorage adds the translation of an existing movie or replaces
+// the one it already has in the locale.
+func (s *Storage) UpsertMovieTranslationStorage(translation *models.MovieTranslation) error {
+	const op = "storage.postgresql.UpsertMovieTranslationStorage"
+
+	res, err := sq.Insert("movie_translations").
+		Columns("movie_id", "locale", "title", "description").
+		Select(sq.Select("m.id").
+			Column("?::text", translation.Locale).
+			Column("?::text", translation.Title).
+			Column("?::text", translation.Description).
+			From("movies m").
+			Where(sq.Eq{"m.id": translation.MovieID}).
+			Where("m.deleted_at IS NULL")).
+		Suffix("ON CONFLICT (movie_id, locale) DO UPDATE SET title = EXCLUDED.title, description = EXCLUDED.description").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrMovieNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetMovieTranslationsStorage(movieID int64) ([]*models.MovieTranslation, error) {
+	const op = "storage.postgresql.GetMovieTranslationsStorage"
+
+	query, args, err := sq.Select("movie_id", "locale", "title", "description").
+		From("movie_translations").
+		Where(sq.Eq{"movie_id": movieID}).
+		OrderBy("locale").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	translations := []*models.MovieTranslation{}
+	for rows.Next() {
+		translation := &models.MovieTranslation{}
+		err := rows.Scan(&translation.MovieID, &translation.Locale, &translation.Title, &translation.Description)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		translations = append(translations, translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return translations, nil
+}
+
+func (s *Storage) DeleteMovieTranslationStorage(movieID int64, locale string) error {
+	const op = "storage.postgresql.DeleteMovieTranslationStorage"
+
+	res, err := sq.Delete("movie_translations").
+		Where(sq.Eq{"movie_id": movieID, "locale": locale}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTranslationNotFound)
+	}
+
+	return nil
+}
+
+// UpsertActorTranslationStorage adds the translation of an existing actor's name or
+// replaces the one they already have in the locale.
+func (s *Storage) UpsertActorTranslationStorage(translation *models.ActorTranslation) error {
+	const op = "storage.postgresql.UpsertActorTranslationStorage"
+
+	res, err := sq.Insert("person_translations").
+		Columns("person_id", "locale", "name").
+		Select(sq.Select("p.id").
+			Column("?::text", translation.Locale).
+			Column("?::text", translation.Name).
+			From("people p").
+			Where(sq.Eq{"p.id": translation.ActorID}).
+			Where("p.deleted_at IS NULL")).
+		Suffix("ON CONFLICT (person_id, locale) DO UPDATE SET name = EXCLUDED.name").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetActorTranslationsStorage(actorID int64) ([]*models.ActorTranslation, error) {
+	const op = "storage.postgresql.GetActorTranslationsStorage"
+
+	query, args, err := sq.Select("person_id", "locale", "name").
+		From("person_translations").
+		Where(sq.Eq{"person_id": actorID}).
+		OrderBy("locale").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	translations := []*models.ActorTranslation{}
+	for rows.Next() {
+		translation := &models.ActorTranslation{}
+		err := rows.Scan(&translation.ActorID, &translation.Locale, &translation.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		translations = append(translations, translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return translations, nil
+}
+
+func (s *Storage) DeleteActorTranslationStorage(actorID int64, locale string) error {
+	const op = "storage.postgresql.DeleteActorTranslationStorage"
+
+	res, err := sq.Delete("person_translations").
+		Where(sq.Eq{"person_id": actorID, "locale": locale}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTranslationNotFound)
+	}
+
+	return nil
+}
+
+// translatedTitle selects the title of the movie with the alias in the first of the
+// languages it is translated to, falling back to the original. It takes the languages twice.
+func translatedTitle(alias string) string {
+	return "COALESCE((SELECT t.title FROM movie_translations t WHERE t.movie_id = " + alias + ".id AND t.locale = ANY(?::text[]) ORDER BY array_position(?::text[], t.locale::text) LIMIT 1), " + alias + ".title)"
+}
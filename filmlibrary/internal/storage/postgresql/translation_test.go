@@ -0,0 +1,69 @@
+package postgresql
+
+import (
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_Translations(t *testing.T) {
+	s := newTestStorage(t)
+
+	prefix := fmt.Sprintf("translation-%d", time.Now().UnixNano())
+	ids := seedMovies(t, s, prefix, 2, 1)
+
+	for _, translation := range []*models.MovieTranslation{
+		{MovieID: ids[0], Locale: "de", Title: prefix + " de"},
+		{MovieID: ids[0], Locale: "ru", Title: prefix + " черновик"},
+		{MovieID: ids[0], Locale: "ru", Title: prefix + " русский", Description: "описание"},
+	} {
+		if err := s.UpsertMovieTranslationStorage(translation); err != nil {
+			t.Fatalf("UpsertMovieTranslationStorage() error = %v", err)
+		}
+	}
+
+	translations, err := s.GetMovieTranslationsStorage(ids[0])
+	if err != nil {
+		t.Fatalf("GetMovieTranslationsStorage() error = %v", err)
+	}
+	if len(translations) != 2 || translations[1].Title != prefix+" русский" {
+		t.Fatalf("GetMovieTranslationsStorage() = %v, want de and the updated ru", translations)
+	}
+
+	movies, err := s.GetMovieStorage(prefix, []string{"ru", "de"})
+	if err != nil {
+		t.Fatalf("GetMovieStorage() error = %v", err)
+	}
+	if len(movies) != 2 {
+		t.Fatalf("GetMovieStorage() returned %d movies, want 2", len(movies))
+	}
+	if movies[0].Title != prefix+" русский" || movies[0].Description != "описание" {
+		t.Errorf("translated movie = %q %q, want the ru translation", movies[0].Title, movies[0].Description)
+	}
+	if movies[1].Title != prefix+" 1" {
+		t.Errorf("untranslated movie title = %q, want the original", movies[1].Title)
+	}
+
+	found, err := s.GetMovieStorage("русский", nil)
+	if err != nil {
+		t.Fatalf("GetMovieStorage() error = %v", err)
+	}
+	if len(found) != 1 || found[0].ID != ids[0] || found[0].Title != prefix+" 0" {
+		t.Errorf("GetMovieStorage() by translated title = %v, want the original of movie %d", found, ids[0])
+	}
+
+	err = s.UpsertMovieTranslationStorage(&models.MovieTranslation{MovieID: -1, Locale: "ru", Title: "нет"})
+	if !errors.Is(err, storage.ErrMovieNotFound) {
+		t.Errorf("UpsertMovieTranslationStorage() of a missing movie error = %v, want ErrMovieNotFound", err)
+	}
+
+	if err := s.DeleteMovieTranslationStorage(ids[0], "de"); err != nil {
+		t.Fatalf("DeleteMovieTranslationStorage() error = %v", err)
+	}
+	if err := s.DeleteMovieTranslationStorage(ids[0], "de"); !errors.Is(err, storage.ErrTranslationNotFound) {
+		t.Errorf("DeleteMovieTranslationStorage() twice error = %v, want ErrTranslationNotFound", err)
+	}
+}
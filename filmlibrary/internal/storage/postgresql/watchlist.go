@@ -0,0 +1,276 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"filmlibrary/internal/domain/models"
+	"filmlibrary/internal/storage"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AddToWatchlistStorage puts the movie at the end of the user's watchlist.
+// A movie that is already on the list keeps its position and gets the new note.
+func (s *Storage) AddToWatchlistStorage(userID int64, item *models.WatchlistItem) error {
+	const op = "storage.postgresql.AddToWatchlistStorage"
+
+	err := sq.Insert("watchlist_items").
+		Columns("user_id", "movie_id", "position", "note").
+		Select(sq.Select().
+			Column("?::int", userID).
+			Column("m.id").
+			Column("(SELECT COALESCE(MAX(position), 0) + 1 FROM watchlist_items WHERE user_id = ?)", userID).
+			Column("NULLIF(?::text, '')", item.Note).
+			From("movies m").
+			Where(sq.Eq{"m.id": item.MovieID}).
+			Where("m.deleted_at IS NULL")).
+		Suffix("ON CONFLICT (user_id, movie_id) DO UPDATE SET note = EXCLUDED.note").
+		Suffix("RETURNING position, added_at").
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&item.Position, &item.AddedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrMovieNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetWatchlistStorage(userID int64) ([]*models.WatchlistItem, error) {
+	const op = "storage.postgresql.GetWatchlistStorage"
+
+	query, args, err := sq.Select("w.movie_id", "m.title", "w.position", "COALESCE(w.note, '')", "w.added_at").
+		From("watchlist_items w").
+		Join("movies m ON m.id = w.movie_id").
+		Where(sq.Eq{"w.user_id": userID}).
+		Where("m.deleted_at IS NULL").
+		OrderBy("w.position", "w.added_at").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	items := []*models.WatchlistItem{}
+	for rows.Next() {
+		item := &models.WatchlistItem{}
+		err := rows.Scan(&item.MovieID, &item.Title, &item.Position, &item.Note, &item.AddedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return items, nil
+}
+
+// ReorderWatchlistStorage moves the given movies to the top of the user's watchlist
+// in the given order, the rest of the list keeps its relative order after them.
+func (s *Storage) ReorderWatchlistStorage(userID int64, movieIDs []int64) error {
+	const op = "storage.postgresql.ReorderWatchlistStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = sq.Update("watchlist_items").
+		Set("position", sq.Expr("position + ?", len(movieIDs))).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.NotEq{"movie_id": movieIDs}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := sq.Update("watchlist_items w").
+		Set("position", sq.Expr("o.ord")).
+		Suffix("FROM unnest(?::int[]) WITH ORDINALITY AS o(movie_id, ord) WHERE w.user_id = ? AND w.movie_id = o.movie_id", movieIDs, userID).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if updated != int64(len(movieIDs)) {
+		err = storage.ErrWatchlistItemNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteFromWatchlistStorage(userID, movieID int64) error {
+	const op = "storage.postgresql.DeleteFromWatchlistStorage"
+
+	res, err := sq.Delete("watchlist_items").
+		Where(sq.Eq{"user_id": userID, "movie_id": movieID}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrWatchlistItemNotFound)
+	}
+
+	return nil
+}
+
+// AddWatchedStorage logs that the user watched the movie and takes it off their watchlist.
+func (s *Storage) AddWatchedStorage(userID int64, entry *models.WatchedEntry) error {
+	const op = "storage.postgresql.AddWatchedStorage"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = sq.Insert("watched_movies").
+		Columns("user_id", "movie_id", "watched_at").
+		Select(sq.Select().
+			Column("?::int", userID).
+			Column("m.id").
+			Column("?::date", entry.WatchedAt).
+			From("movies m").
+			Where(sq.Eq{"m.id": entry.MovieID}).
+			Where("m.deleted_at IS NULL")).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&entry.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = storage.ErrMovieNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = sq.Delete("watchlist_items").
+		Where(sq.Eq{"user_id": userID, "movie_id": entry.MovieID}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetWatchedStorage returns a page of the user's watch log, most recently watched first,
+// and the total number of entries.
+func (s *Storage) GetWatchedStorage(userID int64, limit, offset int) ([]*models.WatchedEntry, int64, error) {
+	const op = "storage.postgresql.GetWatchedStorage"
+
+	var total int64
+	err := sq.Select("COUNT(*)").
+		From("watched_movies").
+		Where(sq.Eq{"user_id": userID}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err := sq.Select("w.id", "w.movie_id", "m.title", "w.watched_at").
+		From("watched_movies w").
+		Join("movies m ON m.id = w.movie_id").
+		Where(sq.Eq{"w.user_id": userID}).
+		OrderBy("w.watched_at DESC", "w.id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	entries := []*models.WatchedEntry{}
+	for rows.Next() {
+		entry := &models.WatchedEntry{}
+		err := rows.Scan(&entry.ID, &entry.MovieID, &entry.Title, &entry.WatchedAt)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, total, nil
+}
+
+func (s *Storage) DeleteWatchedStorage(userID, id int64) error {
+	const op = "storage.postgresql.DeleteWatchedStorage"
+
+	res, err := sq.Delete("watched_movies").
+		Where(sq.Eq{"id": id, "user_id": userID}).
+		RunWith(s.db).
+		PlaceholderFormat(sq.Dollar).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrWatchedEntryNotFound)
+	}
+
+	return nil
+}
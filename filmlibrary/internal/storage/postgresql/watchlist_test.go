@@ -0,0 +1,46 @@
+package postgresql
+
+import (
+	"filmlibrary/internal/domain/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorage_Watchlist(t *testing.T) {
+	s := newTestStorage(t)
+
+	ids := seedMovies(t, s, fmt.Sprintf("watchlist-%d", time.Now().UnixNano()), 3, 0)
+	userID := seedUser(t, s)
+
+	for _, id := range ids {
+		if err := s.AddToWatchlistStorage(userID, &models.WatchlistItem{MovieID: id}); err != nil {
+			t.Fatalf("AddToWatchlistStorage() error = %v", err)
+		}
+	}
+
+	if err := s.ReorderWatchlistStorage(userID, []int64{ids[2]}); err != nil {
+		t.Fatalf("ReorderWatchlistStorage() error = %v", err)
+	}
+
+	entry := &models.WatchedEntry{MovieID: ids[0], WatchedAt: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)}
+	if err := s.AddWatchedStorage(userID, entry); err != nil {
+		t.Fatalf("AddWatchedStorage() error = %v", err)
+	}
+
+	items, err := s.GetWatchlistStorage(userID)
+	if err != nil {
+		t.Fatalf("GetWatchlistStorage() error = %v", err)
+	}
+	if len(items) != 2 || items[0].MovieID != ids[2] || items[1].MovieID != ids[1] {
+		t.Errorf("GetWatchlistStorage() = %v, want movies %d, %d", items, ids[2], ids[1])
+	}
+
+	watched, total, err := s.GetWatchedStorage(userID, 10, 0)
+	if err != nil {
+		t.Fatalf("GetWatchedStorage() error = %v", err)
+	}
+	if total != 1 || len(watched) != 1 || watched[0].MovieID != ids[0] {
+		t.Errorf("GetWatchedStorage() = %v, %d, want movie %d", watched, total, ids[0])
+	}
+}
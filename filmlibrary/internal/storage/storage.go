@@ -3,7 +3,38 @@ package storage
 import "errors"
 
 var (
-	ErrUserNotFound  = errors.New("user not found")
-	ErrMovieNotFound = errors.New("movie not found")
-	ErrMovieExists   = errors.New("movie exists")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrMovieNotFound          = errors.New("movie not found")
+	ErrMovieExists            = errors.New("movie exists")
+	ErrGenreNotFound          = errors.New("genre not found")
+	ErrGenreExists            = errors.New("genre exists")
+	ErrPersonNotFound         = errors.New("person not found")
+	ErrCreditNotFound         = errors.New("credit not found")
+	ErrReviewNotFound         = errors.New("review not found")
+	ErrWatchlistItemNotFound  = errors.New("watchlist item not found")
+	ErrWatchedEntryNotFound   = errors.New("watched entry not found")
+	ErrCollectionNotFound     = errors.New("collection not found")
+	ErrCollectionExists       = errors.New("collection exists")
+	ErrCollectionItemNotFound = errors.New("collection item not found")
+	ErrImageNotFound          = errors.New("image not found")
+	ErrTranslationNotFound    = errors.New("translation not found")
+	ErrReleaseNotFound        = errors.New("release not found")
+	ErrFranchiseNotFound      = errors.New("franchise not found")
+	ErrFranchiseExists        = errors.New("franchise exists")
+	ErrMovieInOtherFranchise  = errors.New("movie belongs to another franchise")
+	ErrRelationNotFound       = errors.New("relation not found")
+	ErrAwardNotFound          = errors.New("award not found")
+	ErrAwardExists            = errors.New("award exists")
+	ErrCeremonyNotFound       = errors.New("ceremony not found")
+	ErrCeremonyExists         = errors.New("ceremony exists")
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrCategoryExists         = errors.New("category exists")
+	ErrCategoryOfOtherAward   = errors.New("category belongs to another award")
+	ErrNominationNotFound     = errors.New("nomination not found")
+	ErrExternalIDExists       = errors.New("external ID belongs to another person")
+	ErrVersionMismatch        = errors.New("version has changed")
+	ErrBatchRolledBack        = errors.New("rolled back with the rest of the batch")
 )
+
+// AnyVersion edits or deletes a movie or an actor at whatever version it is.
+const AnyVersion int64 = -1
@@ -4,22 +4,206 @@ CREATE TABLE movies (
     description VARCHAR(1000),
     release_date DATE NOT NULL,
     rating FLOAT CHECK (rating >= 0 AND rating <= 10),
-    actors_id INT[] NOT NULL,
+    genres_id INT[] NOT NULL DEFAULT '{}',
+    user_rating FLOAT,
+    votes_count INT NOT NULL DEFAULT 0,
+    runtime INT CHECK (runtime > 0),
+    budget_amount BIGINT CHECK (budget_amount >= 0),
+    budget_currency CHAR(3),
+    gross_amount BIGINT CHECK (gross_amount >= 0),
+    gross_currency CHAR(3),
+    original_language VARCHAR(3),
+    spoken_languages VARCHAR(3)[] NOT NULL DEFAULT '{}',
+    production_countries CHAR(2)[] NOT NULL DEFAULT '{}',
+    imdb_id VARCHAR(12) UNIQUE,
+    kinopoisk_id VARCHAR(12) UNIQUE,
+    version INT NOT NULL DEFAULT 1,
     deleted_at DATE
 );
 
-CREATE TABLE actors (
+CREATE INDEX movies_gross_idx ON movies (gross_currency, gross_amount);
+
+CREATE TABLE people (
     id SERIAL PRIMARY KEY,
     name VARCHAR(100) NOT NULL,
-    sex VARCHAR(10) NOT NULL,
-    birthday DATE NOT NULL,
-    movies_id INT[],
+    sex VARCHAR(10),
+    birthday DATE,
+    death_date DATE,
+    birthplace VARCHAR(150),
+    biography VARCHAR(5000),
+    aliases VARCHAR(100)[] NOT NULL DEFAULT '{}',
+    imdb_id VARCHAR(12) UNIQUE,
+    kinopoisk_id VARCHAR(12) UNIQUE,
+    version INT NOT NULL DEFAULT 1,
     deleted_at DATE
 );
 
+CREATE TABLE movie_crew (
+    movie_id INT NOT NULL REFERENCES movies (id),
+    person_id INT NOT NULL REFERENCES people (id),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('actor', 'director', 'writer', 'composer', 'producer')),
+    PRIMARY KEY (movie_id, person_id, role)
+);
+
+CREATE INDEX movie_crew_person_id_idx ON movie_crew (person_id);
+
+CREATE TABLE genres (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL
+);
+
 CREATE TABLE users (
     id SERIAL PRIMARY KEY,
     email VARCHAR(30) UNIQUE NOT NULL,
     role VARCHAR(10) NOT NULL,
     password_hash VARCHAR(60) NOT NULL
-);
\ No newline at end of file
+);
+
+CREATE TABLE reviews (
+    id SERIAL PRIMARY KEY,
+    movie_id INT NOT NULL REFERENCES movies (id),
+    user_id INT NOT NULL REFERENCES users (id),
+    rating SMALLINT NOT NULL CHECK (rating >= 0 AND rating <= 10),
+    text VARCHAR(5000),
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (movie_id, user_id)
+);
+
+CREATE TABLE watchlist_items (
+    user_id INT NOT NULL REFERENCES users (id),
+    movie_id INT NOT NULL REFERENCES movies (id),
+    position INT NOT NULL,
+    note VARCHAR(500),
+    added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, movie_id)
+);
+
+CREATE TABLE watched_movies (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id),
+    movie_id INT NOT NULL REFERENCES movies (id),
+    watched_at DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX watched_movies_user_id_idx ON watched_movies (user_id, watched_at);
+
+CREATE TABLE collections (
+    id SERIAL PRIMARY KEY,
+    owner_id INT NOT NULL REFERENCES users (id),
+    title VARCHAR(150) NOT NULL,
+    description VARCHAR(1000),
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX collections_owner_id_idx ON collections (owner_id);
+
+CREATE TABLE collection_items (
+    collection_id INT NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    movie_id INT NOT NULL REFERENCES movies (id),
+    position INT NOT NULL,
+    PRIMARY KEY (collection_id, movie_id)
+);
+
+CREATE TABLE images (
+    id SERIAL PRIMARY KEY,
+    movie_id INT REFERENCES movies (id),
+    person_id INT REFERENCES people (id),
+    content_type VARCHAR(50) NOT NULL,
+    size INT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((movie_id IS NULL) <> (person_id IS NULL))
+);
+
+CREATE INDEX images_movie_id_idx ON images (movie_id);
+CREATE INDEX images_person_id_idx ON images (person_id);
+
+CREATE TABLE movie_translations (
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    locale VARCHAR(3) NOT NULL,
+    title VARCHAR(150) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    PRIMARY KEY (movie_id, locale)
+);
+
+CREATE TABLE person_translations (
+    person_id INT NOT NULL REFERENCES people (id) ON DELETE CASCADE,
+    locale VARCHAR(3) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    PRIMARY KEY (person_id, locale)
+);
+
+CREATE TABLE movie_releases (
+    id SERIAL PRIMARY KEY,
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    country CHAR(2) NOT NULL,
+    release_type VARCHAR(20) NOT NULL,
+    release_date DATE NOT NULL,
+    certification VARCHAR(10),
+    min_age INT,
+    UNIQUE (movie_id, country, release_type)
+);
+
+CREATE INDEX movie_releases_country_idx ON movie_releases (country, release_date);
+
+CREATE TABLE franchises (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(150) NOT NULL UNIQUE,
+    description VARCHAR(1000) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE franchise_movies (
+    franchise_id INT NOT NULL REFERENCES franchises (id) ON DELETE CASCADE,
+    movie_id INT NOT NULL UNIQUE REFERENCES movies (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (franchise_id, movie_id)
+);
+
+CREATE TABLE movie_relations (
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    related_movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    relation_type VARCHAR(20) NOT NULL,
+    PRIMARY KEY (movie_id, related_movie_id),
+    CHECK (movie_id <> related_movie_id)
+);
+
+CREATE INDEX movie_relations_related_movie_id_idx ON movie_relations (related_movie_id);
+
+CREATE TABLE awards (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE
+);
+
+CREATE TABLE award_ceremonies (
+    id SERIAL PRIMARY KEY,
+    award_id INT NOT NULL REFERENCES awards (id) ON DELETE CASCADE,
+    year INT NOT NULL,
+    UNIQUE (award_id, year)
+);
+
+CREATE TABLE award_categories (
+    id SERIAL PRIMARY KEY,
+    award_id INT NOT NULL REFERENCES awards (id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    UNIQUE (award_id, name)
+);
+
+CREATE TABLE nominations (
+    id SERIAL PRIMARY KEY,
+    ceremony_id INT NOT NULL REFERENCES award_ceremonies (id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES award_categories (id) ON DELETE CASCADE,
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    person_id INT REFERENCES people (id) ON DELETE CASCADE,
+    won BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX nominations_unique_idx ON nominations (ceremony_id, category_id, movie_id, COALESCE(person_id, 0));
+CREATE INDEX nominations_movie_id_idx ON nominations (movie_id);
+CREATE INDEX nominations_person_id_idx ON nominations (person_id);
@@ -0,0 +1,12 @@
+-- Adds genres and the movies.genres_id links to databases created before genres.
+-- Databases created from create-tables.sql since then already have them.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS genres (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL
+);
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS genres_id INT[] NOT NULL DEFAULT '{}';
+
+COMMIT;
@@ -0,0 +1,43 @@
+-- Moves actors and their movie links from the actors table and the
+-- movies.actors_id / actors.movies_id arrays into people and movie_crew.
+-- Actor IDs are kept, so existing clients keep addressing the same actors.
+BEGIN;
+
+CREATE TABLE people (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    sex VARCHAR(10),
+    birthday DATE,
+    deleted_at DATE
+);
+
+CREATE TABLE movie_crew (
+    movie_id INT NOT NULL REFERENCES movies (id),
+    person_id INT NOT NULL REFERENCES people (id),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('actor', 'director', 'writer', 'composer', 'producer')),
+    PRIMARY KEY (movie_id, person_id, role)
+);
+
+CREATE INDEX movie_crew_person_id_idx ON movie_crew (person_id);
+
+INSERT INTO people (id, name, sex, birthday, deleted_at)
+SELECT id, name, sex, birthday, deleted_at
+FROM actors;
+
+SELECT setval(pg_get_serial_sequence('people', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM people;
+
+-- Links were not always written on both sides, so take the union of them.
+INSERT INTO movie_crew (movie_id, person_id, role)
+SELECT m.id, a.id, 'actor'
+FROM movies m
+JOIN actors a ON a.id = ANY(m.actors_id)
+UNION
+SELECT m.id, a.id, 'actor'
+FROM actors a
+JOIN movies m ON m.id = ANY(a.movies_id)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE movies DROP COLUMN actors_id;
+DROP TABLE actors;
+
+COMMIT;
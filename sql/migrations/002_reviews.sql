@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE movies
+    ADD COLUMN user_rating FLOAT,
+    ADD COLUMN votes_count INT NOT NULL DEFAULT 0;
+
+CREATE TABLE reviews (
+    id SERIAL PRIMARY KEY,
+    movie_id INT NOT NULL REFERENCES movies (id),
+    user_id INT NOT NULL REFERENCES users (id),
+    rating SMALLINT NOT NULL CHECK (rating >= 0 AND rating <= 10),
+    text VARCHAR(5000),
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (movie_id, user_id)
+);
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE watchlist_items (
+    user_id INT NOT NULL REFERENCES users (id),
+    movie_id INT NOT NULL REFERENCES movies (id),
+    position INT NOT NULL,
+    note VARCHAR(500),
+    added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, movie_id)
+);
+
+CREATE TABLE watched_movies (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id),
+    movie_id INT NOT NULL REFERENCES movies (id),
+    watched_at DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX watched_movies_user_id_idx ON watched_movies (user_id, watched_at);
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE collections (
+    id SERIAL PRIMARY KEY,
+    owner_id INT NOT NULL REFERENCES users (id),
+    title VARCHAR(150) NOT NULL,
+    description VARCHAR(1000),
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX collections_owner_id_idx ON collections (owner_id);
+
+CREATE TABLE collection_items (
+    collection_id INT NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    movie_id INT NOT NULL REFERENCES movies (id),
+    position INT NOT NULL,
+    PRIMARY KEY (collection_id, movie_id)
+);
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE images (
+    id SERIAL PRIMARY KEY,
+    movie_id INT REFERENCES movies (id),
+    person_id INT REFERENCES people (id),
+    content_type VARCHAR(50) NOT NULL,
+    size INT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((movie_id IS NULL) <> (person_id IS NULL))
+);
+
+CREATE INDEX images_movie_id_idx ON images (movie_id);
+CREATE INDEX images_person_id_idx ON images (person_id);
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE movie_translations (
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    locale VARCHAR(3) NOT NULL,
+    title VARCHAR(150) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    PRIMARY KEY (movie_id, locale)
+);
+
+CREATE TABLE person_translations (
+    person_id INT NOT NULL REFERENCES people (id) ON DELETE CASCADE,
+    locale VARCHAR(3) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    PRIMARY KEY (person_id, locale)
+);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE movie_releases (
+    id SERIAL PRIMARY KEY,
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    country CHAR(2) NOT NULL,
+    release_type VARCHAR(20) NOT NULL,
+    release_date DATE NOT NULL,
+    certification VARCHAR(10),
+    min_age INT,
+    UNIQUE (movie_id, country, release_type)
+);
+
+CREATE INDEX movie_releases_country_idx ON movie_releases (country, release_date);
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TABLE franchises (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(150) NOT NULL UNIQUE,
+    description VARCHAR(1000) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE franchise_movies (
+    franchise_id INT NOT NULL REFERENCES franchises (id) ON DELETE CASCADE,
+    movie_id INT NOT NULL UNIQUE REFERENCES movies (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (franchise_id, movie_id)
+);
+
+CREATE TABLE movie_relations (
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    related_movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    relation_type VARCHAR(20) NOT NULL,
+    PRIMARY KEY (movie_id, related_movie_id),
+    CHECK (movie_id <> related_movie_id)
+);
+
+CREATE INDEX movie_relations_related_movie_id_idx ON movie_relations (related_movie_id);
+
+COMMIT;
@@ -0,0 +1,35 @@
+BEGIN;
+
+CREATE TABLE awards (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE
+);
+
+CREATE TABLE award_ceremonies (
+    id SERIAL PRIMARY KEY,
+    award_id INT NOT NULL REFERENCES awards (id) ON DELETE CASCADE,
+    year INT NOT NULL,
+    UNIQUE (award_id, year)
+);
+
+CREATE TABLE award_categories (
+    id SERIAL PRIMARY KEY,
+    award_id INT NOT NULL REFERENCES awards (id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    UNIQUE (award_id, name)
+);
+
+CREATE TABLE nominations (
+    id SERIAL PRIMARY KEY,
+    ceremony_id INT NOT NULL REFERENCES award_ceremonies (id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES award_categories (id) ON DELETE CASCADE,
+    movie_id INT NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    person_id INT REFERENCES people (id) ON DELETE CASCADE,
+    won BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX nominations_unique_idx ON nominations (ceremony_id, category_id, movie_id, COALESCE(person_id, 0));
+CREATE INDEX nominations_movie_id_idx ON nominations (movie_id);
+CREATE INDEX nominations_person_id_idx ON nominations (person_id);
+
+COMMIT;
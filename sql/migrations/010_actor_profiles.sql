@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE people
+    ADD COLUMN death_date DATE,
+    ADD COLUMN birthplace VARCHAR(150),
+    ADD COLUMN biography VARCHAR(5000),
+    ADD COLUMN aliases VARCHAR(100)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN imdb_id VARCHAR(12) UNIQUE,
+    ADD COLUMN kinopoisk_id VARCHAR(12) UNIQUE;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE movies
+    ADD COLUMN runtime INT CHECK (runtime > 0),
+    ADD COLUMN budget_amount BIGINT CHECK (budget_amount >= 0),
+    ADD COLUMN budget_currency CHAR(3),
+    ADD COLUMN gross_amount BIGINT CHECK (gross_amount >= 0),
+    ADD COLUMN gross_currency CHAR(3),
+    ADD COLUMN original_language VARCHAR(3),
+    ADD COLUMN spoken_languages VARCHAR(3)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN production_countries CHAR(2)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN imdb_id VARCHAR(12) UNIQUE,
+    ADD COLUMN kinopoisk_id VARCHAR(12) UNIQUE;
+
+CREATE INDEX movies_gross_idx ON movies (gross_currency, gross_amount);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE movies ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE people ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+COMMIT;